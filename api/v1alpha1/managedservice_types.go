@@ -23,11 +23,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// +kubebuilder:validation:XValidation:rule="(has(self.oauth2) ? 1 : 0) + (has(self.basicAuth) ? 1 : 0) + (has(self.customHeaders) && size(self.customHeaders) > 0 ? 1 : 0) == 1",message="auth must define exactly one of oauth2, basicAuth, or customHeaders"
+// +kubebuilder:validation:XValidation:rule="(has(self.oauth2) ? 1 : 0) + (has(self.basicAuth) ? 1 : 0) + (has(self.customHeaders) && size(self.customHeaders) > 0 ? 1 : 0) + (has(self.sigv4) ? 1 : 0) == 1",message="auth must define exactly one of oauth2, basicAuth, customHeaders, or sigv4"
 type ManagedServiceAuth struct {
 	OAuth2        *ManagedServiceOAuth2Auth  `json:"oauth2,omitempty"`
 	BasicAuth     *ManagedServiceBasicAuth   `json:"basicAuth,omitempty"`
 	CustomHeaders []ManagedServiceHeaderAuth `json:"customHeaders,omitempty"`
+	SigV4         *ManagedServiceSigV4Auth   `json:"sigv4,omitempty"`
 }
 
 type ManagedServiceOAuth2Auth struct {
@@ -37,8 +38,19 @@ type ManagedServiceOAuth2Auth struct {
 	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef,omitempty"`
 	UsernameRef     *corev1.SecretKeySelector `json:"usernameRef,omitempty"`
 	PasswordRef     *corev1.SecretKeySelector `json:"passwordRef,omitempty"`
-	Scope           string                    `json:"scope,omitempty"`
-	Audience        string                    `json:"audience,omitempty"`
+	RefreshTokenRef *corev1.SecretKeySelector `json:"refreshTokenRef,omitempty"`
+	// ClientAuthMethod selects how the client authenticates to the token
+	// endpoint: client_secret_post (default), private_key_jwt, or tls_client_auth.
+	// +kubebuilder:validation:Enum=client_secret_post;private_key_jwt;tls_client_auth
+	ClientAuthMethod      string                    `json:"clientAuthMethod,omitempty"`
+	ClientAssertionKeyRef *corev1.SecretKeySelector `json:"clientAssertionKeyRef,omitempty"`
+	ClientAssertionKeyID  string                    `json:"clientAssertionKeyID,omitempty"`
+	// SubjectTokenRef selects the Secret key holding the token exchanged by
+	// grantType token_exchange.
+	SubjectTokenRef  *corev1.SecretKeySelector `json:"subjectTokenRef,omitempty"`
+	SubjectTokenType string                    `json:"subjectTokenType,omitempty"`
+	Scope            string                    `json:"scope,omitempty"`
+	Audience         string                    `json:"audience,omitempty"`
 }
 
 type ManagedServiceBasicAuth struct {
@@ -46,6 +58,18 @@ type ManagedServiceBasicAuth struct {
 	PasswordRef *corev1.SecretKeySelector `json:"passwordRef,omitempty"`
 }
 
+// ManagedServiceSigV4Auth signs requests with AWS Signature Version 4.
+// Without access key refs the operator pod's AWS_* environment is used.
+type ManagedServiceSigV4Auth struct {
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+	// +kubebuilder:validation:MinLength=1
+	Service            string                    `json:"service"`
+	AccessKeyIDRef     *corev1.SecretKeySelector `json:"accessKeyIDRef,omitempty"`
+	SecretAccessKeyRef *corev1.SecretKeySelector `json:"secretAccessKeyRef,omitempty"`
+	SessionTokenRef    *corev1.SecretKeySelector `json:"sessionTokenRef,omitempty"`
+}
+
 type ManagedServiceHeaderAuth struct {
 	// +kubebuilder:validation:MinLength=1
 	Header   string                    `json:"header"`
@@ -64,6 +88,17 @@ type ManagedServiceRequestThrottling struct {
 	Burst int32 `json:"burst,omitempty"`
 }
 
+type ManagedServiceRetry struct {
+	// +kubebuilder:validation:Minimum=0
+	MaxAttempts    int32            `json:"maxAttempts,omitempty"`
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff     *metav1.Duration `json:"maxBackoff,omitempty"`
+	Jitter         bool             `json:"jitter,omitempty"`
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	RetryableStatusCodes []int32 `json:"retryableStatusCodes,omitempty"`
+}
+
 type ManagedServiceHTTP struct {
 	// +kubebuilder:validation:MinLength=1
 	BaseURL           string                           `json:"baseURL"`
@@ -73,6 +108,7 @@ type ManagedServiceHTTP struct {
 	TLS               *TLSSpec                         `json:"tls,omitempty"`
 	Proxy             *HTTPProxySpec                   `json:"proxy,omitempty"`
 	RequestThrottling *ManagedServiceRequestThrottling `json:"requestThrottling,omitempty"`
+	Retry             *ManagedServiceRetry             `json:"retry,omitempty"`
 }
 
 type ManagedServiceSpec struct {
@@ -139,8 +175,9 @@ func validateManagedServiceSpec(spec *ManagedServiceSpec) error {
 	hasOAuth2 := spec.HTTP.Auth.OAuth2 != nil
 	hasBasic := spec.HTTP.Auth.BasicAuth != nil
 	hasHeaders := len(spec.HTTP.Auth.CustomHeaders) > 0
-	if countTrue(hasOAuth2, hasBasic, hasHeaders) != 1 {
-		return fmt.Errorf("spec.http.auth must define exactly one of oauth2, basicAuth, or customHeaders")
+	hasSigV4 := spec.HTTP.Auth.SigV4 != nil
+	if countTrue(hasOAuth2, hasBasic, hasHeaders, hasSigV4) != 1 {
+		return fmt.Errorf("spec.http.auth must define exactly one of oauth2, basicAuth, customHeaders, or sigv4")
 	}
 	if hasOAuth2 {
 		oauth2 := spec.HTTP.Auth.OAuth2
@@ -153,8 +190,35 @@ func validateManagedServiceSpec(spec *ManagedServiceSpec) error {
 		if err := validateSecretRef(oauth2.ClientIDRef, "spec.http.auth.oauth2.clientIDRef"); err != nil {
 			return err
 		}
-		if err := validateSecretRef(oauth2.ClientSecretRef, "spec.http.auth.oauth2.clientSecretRef"); err != nil {
-			return err
+		publicClientGrant := oauth2.GrantType == "refresh_token" || oauth2.GrantType == "token_exchange"
+		secretlessClientAuth := oauth2.ClientAuthMethod == "private_key_jwt" || oauth2.ClientAuthMethod == "tls_client_auth"
+		if !(publicClientGrant || secretlessClientAuth) || oauth2.ClientSecretRef != nil {
+			if err := validateSecretRef(oauth2.ClientSecretRef, "spec.http.auth.oauth2.clientSecretRef"); err != nil {
+				return err
+			}
+		}
+		if oauth2.GrantType == "refresh_token" || oauth2.RefreshTokenRef != nil {
+			if err := validateSecretRef(oauth2.RefreshTokenRef, "spec.http.auth.oauth2.refreshTokenRef"); err != nil {
+				return err
+			}
+		}
+		if oauth2.GrantType == "token_exchange" || oauth2.SubjectTokenRef != nil {
+			if err := validateSecretRef(oauth2.SubjectTokenRef, "spec.http.auth.oauth2.subjectTokenRef"); err != nil {
+				return err
+			}
+		}
+		switch oauth2.ClientAuthMethod {
+		case "", "client_secret_post":
+		case "private_key_jwt":
+			if err := validateSecretRef(oauth2.ClientAssertionKeyRef, "spec.http.auth.oauth2.clientAssertionKeyRef"); err != nil {
+				return err
+			}
+		case "tls_client_auth":
+			if spec.HTTP.TLS == nil || spec.HTTP.TLS.ClientCertRef == nil || spec.HTTP.TLS.ClientKeyRef == nil {
+				return fmt.Errorf("spec.http.auth.oauth2.clientAuthMethod tls_client_auth requires spec.http.tls.clientCertRef and spec.http.tls.clientKeyRef")
+			}
+		default:
+			return fmt.Errorf("spec.http.auth.oauth2.clientAuthMethod must be one of client_secret_post, private_key_jwt, tls_client_auth")
 		}
 		if oauth2.UsernameRef != nil {
 			if err := validateSecretRef(oauth2.UsernameRef, "spec.http.auth.oauth2.usernameRef"); err != nil {
@@ -185,6 +249,31 @@ func validateManagedServiceSpec(spec *ManagedServiceSpec) error {
 			}
 		}
 	}
+	if hasSigV4 {
+		sigv4 := spec.HTTP.Auth.SigV4
+		if strings.TrimSpace(sigv4.Region) == "" {
+			return fmt.Errorf("spec.http.auth.sigv4.region is required")
+		}
+		if strings.TrimSpace(sigv4.Service) == "" {
+			return fmt.Errorf("spec.http.auth.sigv4.service is required")
+		}
+		if (sigv4.AccessKeyIDRef == nil) != (sigv4.SecretAccessKeyRef == nil) {
+			return fmt.Errorf("spec.http.auth.sigv4.accessKeyIDRef and secretAccessKeyRef must be set together")
+		}
+		if sigv4.AccessKeyIDRef != nil {
+			if err := validateSecretRef(sigv4.AccessKeyIDRef, "spec.http.auth.sigv4.accessKeyIDRef"); err != nil {
+				return err
+			}
+			if err := validateSecretRef(sigv4.SecretAccessKeyRef, "spec.http.auth.sigv4.secretAccessKeyRef"); err != nil {
+				return err
+			}
+		}
+		if sigv4.SessionTokenRef != nil {
+			if err := validateSecretRef(sigv4.SessionTokenRef, "spec.http.auth.sigv4.sessionTokenRef"); err != nil {
+				return err
+			}
+		}
+	}
 	if strings.TrimSpace(spec.OpenAPI.URL) != "" {
 		if err := validateHTTPURL(spec.OpenAPI.URL, "spec.openapi.url"); err != nil {
 			return err
@@ -237,6 +326,26 @@ func validateManagedServiceSpec(spec *ManagedServiceSpec) error {
 			return fmt.Errorf("spec.http.requestThrottling.burst requires requestsPerSecond to be greater than zero")
 		}
 	}
+	if spec.HTTP.Retry != nil {
+		retry := spec.HTTP.Retry
+		if retry.MaxAttempts < 0 {
+			return fmt.Errorf("spec.http.retry.maxAttempts must be greater than zero when set")
+		}
+		if retry.InitialBackoff != nil && retry.InitialBackoff.Duration <= 0 {
+			return fmt.Errorf("spec.http.retry.initialBackoff must be greater than zero")
+		}
+		if retry.MaxBackoff != nil && retry.MaxBackoff.Duration <= 0 {
+			return fmt.Errorf("spec.http.retry.maxBackoff must be greater than zero")
+		}
+		if retry.InitialBackoff != nil && retry.MaxBackoff != nil && retry.MaxBackoff.Duration < retry.InitialBackoff.Duration {
+			return fmt.Errorf("spec.http.retry.maxBackoff must be greater than or equal to initialBackoff")
+		}
+		for idx, statusCode := range retry.RetryableStatusCodes {
+			if statusCode < 100 || statusCode > 599 {
+				return fmt.Errorf("spec.http.retry.retryableStatusCodes[%d] must be a valid HTTP status code", idx)
+			}
+		}
+	}
 	return nil
 }
 
@@ -16,8 +16,10 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestManagedServiceValidateSpecAllowsRequestThrottling(t *testing.T) {
@@ -73,6 +75,33 @@ func TestManagedServiceValidateSpecRejectsInvalidRequestThrottling(t *testing.T)
 	}
 }
 
+func TestManagedServiceValidateSpecRejectsInvalidRetry(t *testing.T) {
+	t.Parallel()
+
+	server := &ManagedService{
+		Spec: ManagedServiceSpec{
+			HTTP: ManagedServiceHTTP{
+				BaseURL: "https://managed-service.example.com",
+				Auth: ManagedServiceAuth{
+					BasicAuth: &ManagedServiceBasicAuth{
+						UsernameRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "managed-service-auth"}, Key: "username"},
+						PasswordRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "managed-service-auth"}, Key: "password"},
+					},
+				},
+				Retry: &ManagedServiceRetry{
+					MaxAttempts:    3,
+					InitialBackoff: &metav1.Duration{Duration: 2 * time.Second},
+					MaxBackoff:     &metav1.Duration{Duration: time.Second},
+				},
+			},
+		},
+	}
+
+	if err := server.ValidateSpec(); err == nil {
+		t.Fatal("ValidateSpec() expected retry validation error, got nil")
+	}
+}
+
 func TestManagedServiceValidateSpecAllowsMetadataBundleRef(t *testing.T) {
 	t.Parallel()
 
@@ -148,3 +177,152 @@ func TestManagedServiceValidateSpecRejectsMetadataURLAndBundleRef(t *testing.T)
 		t.Fatal("ValidateSpec() expected metadata source validation error, got nil")
 	}
 }
+
+func TestManagedServiceOAuth2TokenExchangeRequiresSubjectTokenRefWithoutClientSecret(t *testing.T) {
+	t.Parallel()
+
+	server := &ManagedService{
+		Spec: ManagedServiceSpec{
+			HTTP: ManagedServiceHTTP{
+				BaseURL: "https://managed-service.example.com",
+				Auth: ManagedServiceAuth{
+					OAuth2: &ManagedServiceOAuth2Auth{
+						TokenURL:    "https://sso.example.com/token",
+						GrantType:   "token_exchange",
+						ClientIDRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: "client-id"},
+					},
+				},
+			},
+		},
+	}
+
+	server.Default()
+	if server.Spec.HTTP.Auth.OAuth2.SubjectTokenRef != nil {
+		t.Fatalf("expected no default subject token, got %#v", server.Spec.HTTP.Auth.OAuth2.SubjectTokenRef)
+	}
+	if err := server.ValidateSpec(); err == nil {
+		t.Fatal("ValidateSpec() expected subjectTokenRef validation error, got nil")
+	}
+
+	server.Spec.HTTP.Auth.OAuth2.SubjectTokenRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"},
+		Key:                  "subject-token",
+	}
+	if err := server.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() unexpected error: %v", err)
+	}
+}
+
+func TestManagedServiceOAuth2RefreshTokenRequiresRefreshTokenRef(t *testing.T) {
+	t.Parallel()
+
+	server := &ManagedService{
+		Spec: ManagedServiceSpec{
+			HTTP: ManagedServiceHTTP{
+				BaseURL: "https://managed-service.example.com",
+				Auth: ManagedServiceAuth{
+					OAuth2: &ManagedServiceOAuth2Auth{
+						TokenURL:    "https://sso.example.com/token",
+						GrantType:   "refresh_token",
+						ClientIDRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: "client-id"},
+					},
+				},
+			},
+		},
+	}
+
+	if err := server.ValidateSpec(); err == nil {
+		t.Fatal("expected ValidateSpec() error for missing refreshTokenRef")
+	}
+	server.Spec.HTTP.Auth.OAuth2.RefreshTokenRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"},
+		Key:                  "refresh-token",
+	}
+	if err := server.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() unexpected error: %v", err)
+	}
+}
+
+func TestManagedServiceOAuth2ClientAuthMethodRequirements(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(method string) *ManagedService {
+		return &ManagedService{
+			Spec: ManagedServiceSpec{
+				HTTP: ManagedServiceHTTP{
+					BaseURL: "https://managed-service.example.com",
+					Auth: ManagedServiceAuth{
+						OAuth2: &ManagedServiceOAuth2Auth{
+							TokenURL:         "https://sso.example.com/token",
+							GrantType:        "client_credentials",
+							ClientIDRef:      &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: "client-id"},
+							ClientAuthMethod: method,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if err := newServer("private_key_jwt").ValidateSpec(); err == nil {
+		t.Fatal("expected ValidateSpec() error for private_key_jwt without clientAssertionKeyRef")
+	}
+	server := newServer("private_key_jwt")
+	server.Spec.HTTP.Auth.OAuth2.ClientAssertionKeyRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"},
+		Key:                  "private-key",
+	}
+	if err := server.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() unexpected error: %v", err)
+	}
+
+	if err := newServer("tls_client_auth").ValidateSpec(); err == nil {
+		t.Fatal("expected ValidateSpec() error for tls_client_auth without a client certificate")
+	}
+	server = newServer("tls_client_auth")
+	server.Spec.HTTP.TLS = &TLSSpec{
+		ClientCertRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "tls.crt"},
+		ClientKeyRef:  &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "tls.key"},
+	}
+	if err := server.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() unexpected error: %v", err)
+	}
+}
+
+func TestManagedServiceSigV4Requirements(t *testing.T) {
+	t.Parallel()
+
+	server := &ManagedService{
+		Spec: ManagedServiceSpec{
+			HTTP: ManagedServiceHTTP{
+				BaseURL: "https://search.eu-west-1.es.amazonaws.com",
+				Auth: ManagedServiceAuth{
+					SigV4: &ManagedServiceSigV4Auth{Region: "eu-west-1", Service: "es"},
+				},
+			},
+		},
+	}
+	if err := server.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() unexpected error for environment credentials: %v", err)
+	}
+
+	server.Spec.HTTP.Auth.SigV4.AccessKeyIDRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "aws"},
+		Key:                  "access-key-id",
+	}
+	if err := server.ValidateSpec(); err == nil {
+		t.Fatal("expected ValidateSpec() error for accessKeyIDRef without secretAccessKeyRef")
+	}
+	server.Spec.HTTP.Auth.SigV4.SecretAccessKeyRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "aws"},
+		Key:                  "secret-access-key",
+	}
+	if err := server.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() unexpected error: %v", err)
+	}
+
+	server.Spec.HTTP.Auth.SigV4.Service = ""
+	if err := server.ValidateSpec(); err == nil {
+		t.Fatal("expected ValidateSpec() error for missing service")
+	}
+}
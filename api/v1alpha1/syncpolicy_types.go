@@ -33,6 +33,8 @@ type SyncPolicySource struct {
 type SyncPolicySyncOptions struct {
 	Prune bool `json:"prune,omitempty"`
 	Force bool `json:"force,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	Parallelism int32 `json:"parallelism,omitempty"`
 }
 
 type SyncPolicySpec struct {
@@ -119,6 +121,9 @@ func (s *SyncPolicy) ValidateSpec() error {
 			return fmt.Errorf("spec.fullResyncCron is invalid: %w", err)
 		}
 	}
+	if s.Spec.Sync.Parallelism < 0 {
+		return fmt.Errorf("spec.sync.parallelism must be greater than zero")
+	}
 	normalizedPath, err := normalizePath(s.Spec.Source.Path)
 	if err != nil {
 		return fmt.Errorf("spec.source.path is invalid: %w", err)
@@ -94,3 +94,21 @@ func TestSyncPolicyValidateSpecRejectsTraversalPath(t *testing.T) {
 		t.Fatal("ValidateSpec() expected traversal validation error, got nil")
 	}
 }
+
+func TestSyncPolicyValidateSpecRejectsNegativeParallelism(t *testing.T) {
+	t.Parallel()
+
+	policy := &SyncPolicy{
+		Spec: SyncPolicySpec{
+			ResourceRepositoryRef: NamespacedObjectReference{Name: "repo"},
+			ManagedServiceRef:     NamespacedObjectReference{Name: "server"},
+			SecretStoreRef:        NamespacedObjectReference{Name: "secrets"},
+			Source:                SyncPolicySource{Path: "/customers"},
+			Sync:                  SyncPolicySyncOptions{Parallelism: -1},
+		},
+	}
+
+	if err := policy.ValidateSpec(); err == nil {
+		t.Fatal("ValidateSpec() expected parallelism validation error, got nil")
+	}
+}
@@ -410,6 +410,11 @@ func (in *ManagedServiceAuth) DeepCopyInto(out *ManagedServiceAuth) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SigV4 != nil {
+		in, out := &in.SigV4, &out.SigV4
+		*out = new(ManagedServiceSigV4Auth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServiceAuth.
@@ -473,6 +478,11 @@ func (in *ManagedServiceHTTP) DeepCopyInto(out *ManagedServiceHTTP) {
 		*out = new(ManagedServiceRequestThrottling)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ManagedServiceRetry)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServiceHTTP.
@@ -560,6 +570,21 @@ func (in *ManagedServiceOAuth2Auth) DeepCopyInto(out *ManagedServiceOAuth2Auth)
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RefreshTokenRef != nil {
+		in, out := &in.RefreshTokenRef, &out.RefreshTokenRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientAssertionKeyRef != nil {
+		in, out := &in.ClientAssertionKeyRef, &out.ClientAssertionKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubjectTokenRef != nil {
+		in, out := &in.SubjectTokenRef, &out.SubjectTokenRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServiceOAuth2Auth.
@@ -587,6 +612,66 @@ func (in *ManagedServiceRequestThrottling) DeepCopy() *ManagedServiceRequestThro
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedServiceRetry) DeepCopyInto(out *ManagedServiceRetry) {
+	*out = *in
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryableStatusCodes != nil {
+		in, out := &in.RetryableStatusCodes, &out.RetryableStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServiceRetry.
+func (in *ManagedServiceRetry) DeepCopy() *ManagedServiceRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedServiceRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedServiceSigV4Auth) DeepCopyInto(out *ManagedServiceSigV4Auth) {
+	*out = *in
+	if in.AccessKeyIDRef != nil {
+		in, out := &in.AccessKeyIDRef, &out.AccessKeyIDRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKeyRef != nil {
+		in, out := &in.SecretAccessKeyRef, &out.SecretAccessKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionTokenRef != nil {
+		in, out := &in.SessionTokenRef, &out.SessionTokenRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServiceSigV4Auth.
+func (in *ManagedServiceSigV4Auth) DeepCopy() *ManagedServiceSigV4Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedServiceSigV4Auth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedServiceSpec) DeepCopyInto(out *ManagedServiceSpec) {
 	*out = *in
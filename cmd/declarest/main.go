@@ -39,7 +39,10 @@ func main() {
 	if !shouldSkipContextBootstrap(args, resolvedInvocation, hasRunnableCommand) {
 		session, err := bootstrap.NewSession(
 			bootstrap.BootstrapConfig{},
-			config.ContextSelection{Name: contextNameFromArgs(args, resolvedInvocation, hasRunnableCommand)},
+			config.ContextSelection{
+				Name:      contextNameFromArgs(args, resolvedInvocation, hasRunnableCommand),
+				Overrides: httpCassetteOverrides(resolvedInvocation),
+			},
 		)
 		if err != nil {
 			if !isShellCompletionInvocation(args) {
@@ -75,6 +78,22 @@ func contextNameFromArgs(args []string, resolvedInvocation cli.Invocation, ok bo
 	return contextNameFromPositionalContextArg(resolvedInvocation, ok)
 }
 
+// httpCassetteOverrides maps --record-http and --replay-http to the context
+// preferences that enable HTTP recording or replay for this invocation.
+func httpCassetteOverrides(resolvedInvocation cli.Invocation) map[string]string {
+	overrides := map[string]string{}
+	if resolvedInvocation.RecordHTTP != "" {
+		overrides["preferences."+config.PreferenceRecordHTTP] = resolvedInvocation.RecordHTTP
+	}
+	if resolvedInvocation.ReplayHTTP != "" {
+		overrides["preferences."+config.PreferenceReplayHTTP] = resolvedInvocation.ReplayHTTP
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	return overrides
+}
+
 func contextNameFromExplicitContextFlag(args []string) (string, bool) {
 	for idx := 0; idx < len(args); idx++ {
 		current := args[idx]
@@ -91,6 +91,25 @@ func TestContextNameFromArgs(t *testing.T) {
 	}
 }
 
+func TestHTTPCassetteOverrides(t *testing.T) {
+	t.Setenv("DECLAREST_RECORD_HTTP", "")
+	t.Setenv("DECLAREST_REPLAY_HTTP", "")
+
+	resolvedInvocation, ok := cli.ResolveRunnableInvocation([]string{"resource", "list", "/customers", "--replay-http", "testdata/cassettes"})
+	if !ok {
+		t.Fatal("expected runnable invocation")
+	}
+	overrides := httpCassetteOverrides(resolvedInvocation)
+	if len(overrides) != 1 || overrides["preferences.replayHTTP"] != "testdata/cassettes" {
+		t.Fatalf("unexpected overrides %#v", overrides)
+	}
+
+	resolvedInvocation, _ = cli.ResolveRunnableInvocation([]string{"resource", "list", "/customers"})
+	if overrides := httpCassetteOverrides(resolvedInvocation); overrides != nil {
+		t.Fatalf("expected no overrides, got %#v", overrides)
+	}
+}
+
 func TestIsHelpInvocation(t *testing.T) {
 	t.Parallel()
 
@@ -24,6 +24,21 @@ const (
 	DefaultContextCatalogPath = "~/.declarest/configs/contexts.yaml"
 	GitProviderGitHub         = "github"
 	OAuthClientCreds          = "client_credentials"
+	OAuthAuthorizationCode    = "authorization_code"
+	OAuthDeviceCode           = "device_code"
+	OAuthRefreshToken         = "refresh_token"
+	OAuthTokenExchange        = "token_exchange"
+	OAuthClientSecretPost     = "client_secret_post"
+	OAuthPrivateKeyJWT        = "private_key_jwt"
+	OAuthTLSClientAuth        = "tls_client_auth"
+	SessionBodyForm           = "form"
+	SessionBodyJSON           = "json"
+
+	// PreferenceRecordHTTP and PreferenceReplayHTTP name the context
+	// preferences holding the directory managed-service HTTP interactions are
+	// recorded into or replayed from.
+	PreferenceRecordHTTP = "recordHTTP"
+	PreferenceReplayHTTP = "replayHTTP"
 )
 
 type ContextCatalog struct {
@@ -44,8 +59,25 @@ type Context struct {
 }
 
 type Repository struct {
-	Git        *GitRepository        `json:"git,omitempty" yaml:"git,omitempty"`
-	Filesystem *FilesystemRepository `json:"filesystem,omitempty" yaml:"filesystem,omitempty"`
+	Git           *GitRepository           `json:"git,omitempty" yaml:"git,omitempty"`
+	Filesystem    *FilesystemRepository    `json:"filesystem,omitempty" yaml:"filesystem,omitempty"`
+	Serialization *RepositorySerialization `json:"serialization,omitempty" yaml:"serialization,omitempty"`
+}
+
+const (
+	YAMLStyleIndented = "indented"
+	YAMLStyleCompact  = "compact"
+)
+
+// RepositorySerialization controls how resource payload files are written so
+// that saving an unchanged resource never produces a diff. Unset fields keep
+// each payload codec's default output.
+type RepositorySerialization struct {
+	KeyOrder         []string `json:"keyOrder,omitempty" yaml:"keyOrder,omitempty"`
+	NormalizeNumbers bool     `json:"normalizeNumbers,omitempty" yaml:"normalizeNumbers,omitempty"`
+	YAMLIndent       int      `json:"yamlIndent,omitempty" yaml:"yamlIndent,omitempty"`
+	YAMLStyle        string   `json:"yamlStyle,omitempty" yaml:"yamlStyle,omitempty"`
+	TrailingNewline  bool     `json:"trailingNewline,omitempty" yaml:"trailingNewline,omitempty"`
 }
 
 type GitRepository struct {
@@ -86,6 +118,7 @@ type GitAuth struct {
 	Basic     *BasicAuth     `json:"basic,omitempty" yaml:"basic,omitempty"`
 	SSH       *SSHAuth       `json:"ssh,omitempty" yaml:"ssh,omitempty"`
 	AccessKey *AccessKeyAuth `json:"accessKey,omitempty" yaml:"accessKey,omitempty"`
+	Exec      *ExecAuth      `json:"exec,omitempty" yaml:"exec,omitempty"`
 }
 
 type FilesystemRepository struct {
@@ -93,7 +126,22 @@ type FilesystemRepository struct {
 }
 
 type ManagedService struct {
-	HTTP *HTTPServer `json:"http,omitempty" yaml:"http,omitempty"`
+	HTTP    *HTTPServer    `json:"http,omitempty" yaml:"http,omitempty"`
+	GraphQL *GraphQLServer `json:"graphql,omitempty" yaml:"graphql,omitempty"`
+}
+
+// GraphQLServer configures a managed service that exposes only a GraphQL
+// endpoint. Every operation is POSTed to URL as the query or mutation
+// declared in metadata; auth, proxy, TLS, throttling, and retry behave as
+// they do for HTTPServer.
+type GraphQLServer struct {
+	URL               string                 `json:"url" yaml:"url"`
+	DefaultHeaders    map[string]string      `json:"defaultHeaders,omitempty" yaml:"defaultHeaders,omitempty"`
+	Auth              *HTTPAuth              `json:"auth,omitempty" yaml:"auth,omitempty"`
+	Proxy             *HTTPProxy             `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	TLS               *TLS                   `json:"tls,omitempty" yaml:"tls,omitempty"`
+	RequestThrottling *HTTPRequestThrottling `json:"requestThrottling,omitempty" yaml:"requestThrottling,omitempty"`
+	Retry             *HTTPRetry             `json:"retry,omitempty" yaml:"retry,omitempty"`
 }
 
 type HTTPServer struct {
@@ -105,6 +153,7 @@ type HTTPServer struct {
 	Proxy             *HTTPProxy             `json:"proxy,omitempty" yaml:"proxy,omitempty"`
 	TLS               *TLS                   `json:"tls,omitempty" yaml:"tls,omitempty"`
 	RequestThrottling *HTTPRequestThrottling `json:"requestThrottling,omitempty" yaml:"requestThrottling,omitempty"`
+	Retry             *HTTPRetry             `json:"retry,omitempty" yaml:"retry,omitempty"`
 }
 
 type HTTPRequestThrottling struct {
@@ -115,6 +164,14 @@ type HTTPRequestThrottling struct {
 	ScopeKey              string  `json:"-" yaml:"-"`
 }
 
+type HTTPRetry struct {
+	MaxAttempts          int    `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	InitialBackoff       string `json:"initialBackoff,omitempty" yaml:"initialBackoff,omitempty"`
+	MaxBackoff           string `json:"maxBackoff,omitempty" yaml:"maxBackoff,omitempty"`
+	Jitter               bool   `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+	RetryableStatusCodes []int  `json:"retryableStatusCodes,omitempty" yaml:"retryableStatusCodes,omitempty"`
+}
+
 type HTTPProxy struct {
 	HTTPURL  string     `json:"http,omitempty" yaml:"http,omitempty"`
 	HTTPSURL string     `json:"https,omitempty" yaml:"https,omitempty"`
@@ -130,17 +187,89 @@ type HTTPAuth struct {
 	OAuth2        *OAuth2           `json:"oauth2,omitempty" yaml:"oauth2,omitempty"`
 	Basic         *BasicAuth        `json:"basic,omitempty" yaml:"basic,omitempty"`
 	CustomHeaders []HeaderTokenAuth `json:"customHeaders,omitempty" yaml:"customHeaders,omitempty"`
+	SigV4         *SigV4Auth        `json:"sigv4,omitempty" yaml:"sigv4,omitempty"`
+	Session       *SessionAuth      `json:"session,omitempty" yaml:"session,omitempty"`
+	Exec          *ExecAuth         `json:"exec,omitempty" yaml:"exec,omitempty"`
+}
+
+// SessionAuth logs in by sending a form or JSON login request and then
+// authenticates later requests with the cookies it sets, plus an optional
+// CSRF token. The credentials are sent in usernameField and passwordField
+// (default "username" and "password") next to any extra fields.
+type SessionAuth struct {
+	LoginURL      string            `json:"loginURL" yaml:"loginURL"`
+	Method        string            `json:"method,omitempty" yaml:"method,omitempty"`
+	BodyFormat    string            `json:"bodyFormat,omitempty" yaml:"bodyFormat,omitempty"`
+	Credentials   *BasicAuth        `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+	UsernameField string            `json:"usernameField,omitempty" yaml:"usernameField,omitempty"`
+	PasswordField string            `json:"passwordField,omitempty" yaml:"passwordField,omitempty"`
+	Fields        map[string]string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	CSRF          *SessionCSRF      `json:"csrf,omitempty" yaml:"csrf,omitempty"`
+}
+
+// SessionCSRF reads a CSRF token from the login response, either from a
+// response header or from a JSON pointer into the response body, and sends
+// it in header on every request of the session.
+type SessionCSRF struct {
+	Header         string `json:"header" yaml:"header"`
+	ResponseHeader string `json:"responseHeader,omitempty" yaml:"responseHeader,omitempty"`
+	JSONPointer    string `json:"jsonPointer,omitempty" yaml:"jsonPointer,omitempty"`
+}
+
+// SigV4Auth signs requests with AWS Signature Version 4. Credentials come from
+// the static access key when set, then from credentialsFile (an AWS shared
+// credentials file), then from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+// and AWS_SESSION_TOKEN environment variables.
+type SigV4Auth struct {
+	Region          string `json:"region" yaml:"region"`
+	Service         string `json:"service" yaml:"service"`
+	AccessKeyID     string `json:"accessKeyID,omitempty" yaml:"accessKeyID,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty" yaml:"secretAccessKey,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty" yaml:"sessionToken,omitempty"`
+	CredentialsFile string `json:"credentialsFile,omitempty" yaml:"credentialsFile,omitempty"`
+	Profile         string `json:"profile,omitempty" yaml:"profile,omitempty"`
 }
 
 type OAuth2 struct {
-	TokenURL     string `json:"tokenURL" yaml:"tokenURL"`
-	GrantType    string `json:"grantType" yaml:"grantType"`
-	ClientID     string `json:"clientID" yaml:"clientID"`
-	ClientSecret string `json:"clientSecret" yaml:"clientSecret"`
-	Username     string `json:"username,omitempty" yaml:"username,omitempty"`
-	Password     string `json:"password,omitempty" yaml:"password,omitempty"`
-	Scope        string `json:"scope,omitempty" yaml:"scope,omitempty"`
-	Audience     string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	TokenURL               string `json:"tokenURL" yaml:"tokenURL"`
+	GrantType              string `json:"grantType" yaml:"grantType"`
+	ClientID               string `json:"clientID" yaml:"clientID"`
+	ClientSecret           string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	Username               string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password               string `json:"password,omitempty" yaml:"password,omitempty"`
+	Scope                  string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Audience               string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	AuthorizationURL       string `json:"authorizationURL,omitempty" yaml:"authorizationURL,omitempty"`
+	DeviceAuthorizationURL string `json:"deviceAuthorizationURL,omitempty" yaml:"deviceAuthorizationURL,omitempty"`
+	RedirectURL            string `json:"redirectURL,omitempty" yaml:"redirectURL,omitempty"`
+	RefreshToken           string `json:"refreshToken,omitempty" yaml:"refreshToken,omitempty"`
+	SubjectTokenFile       string `json:"subjectTokenFile,omitempty" yaml:"subjectTokenFile,omitempty"`
+	SubjectTokenType       string `json:"subjectTokenType,omitempty" yaml:"subjectTokenType,omitempty"`
+	ClientAuthMethod       string `json:"clientAuthMethod,omitempty" yaml:"clientAuthMethod,omitempty"`
+	ClientAssertionKeyFile string `json:"clientAssertionKeyFile,omitempty" yaml:"clientAssertionKeyFile,omitempty"`
+	ClientAssertionKeyID   string `json:"clientAssertionKeyID,omitempty" yaml:"clientAssertionKeyID,omitempty"`
+}
+
+// RequiresClientSecret reports whether the grant type authenticates with the
+// client secret alone. The other grants carry their own user or subject
+// credential, so the secret is optional for public clients, and the
+// private_key_jwt and tls_client_auth methods replace the secret entirely.
+func (o OAuth2) RequiresClientSecret() bool {
+	if o.ClientAuthMethod != "" && o.ClientAuthMethod != OAuthClientSecretPost {
+		return false
+	}
+	return o.GrantType == OAuthClientCreds
+}
+
+// IsInteractiveGrant reports whether the grant type requires a user login in
+// a browser instead of client credentials alone.
+func (o OAuth2) IsInteractiveGrant() bool {
+	switch o.GrantType {
+	case OAuthAuthorizationCode, OAuthDeviceCode:
+		return true
+	default:
+		return false
+	}
 }
 
 type BasicAuth struct {
@@ -167,6 +296,20 @@ type AccessKeyAuth struct {
 	Token string `json:"token" yaml:"token"`
 }
 
+// ExecAuth runs an external credential helper, modeled on kubectl exec
+// credential plugins. The command prints an ExecCredential JSON document
+// whose status.token is cached until status.expirationTimestamp. Managed
+// services send the token in header (default Authorization) with prefix
+// (default Bearer for Authorization); Git remotes send it like accessKey.
+type ExecAuth struct {
+	Command string            `json:"command" yaml:"command"`
+	Args    []string          `json:"args,omitempty" yaml:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	Timeout string            `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Header  string            `json:"header,omitempty" yaml:"header,omitempty"`
+	Prefix  string            `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+}
+
 type SecretStore struct {
 	File  *FileSecretStore  `json:"file,omitempty" yaml:"file,omitempty"`
 	Vault *VaultSecretStore `json:"vault,omitempty" yaml:"vault,omitempty"`
@@ -37,3 +37,7 @@ func Transport(message string, cause error) *TypedError {
 func Internal(message string, cause error) *TypedError {
 	return NewTypedError(InternalError, message, cause)
 }
+
+func PartialFailure(message string, cause error) *TypedError {
+	return NewTypedError(PartialFailureError, message, cause)
+}
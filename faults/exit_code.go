@@ -37,6 +37,8 @@ func ExitCodeForError(err error) int {
 		return 5
 	case TransportError:
 		return 6
+	case PartialFailureError:
+		return 7
 	default:
 		return 1
 	}
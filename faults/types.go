@@ -25,6 +25,9 @@ const (
 	AuthError       ErrorCategory = "AuthError"
 	TransportError  ErrorCategory = "TransportError"
 	InternalError   ErrorCategory = "InternalError"
+	// PartialFailureError marks a bulk operation that completed with one or
+	// more failed items.
+	PartialFailureError ErrorCategory = "PartialFailureError"
 )
 
 type TypedError struct {
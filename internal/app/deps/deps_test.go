@@ -237,6 +237,10 @@ func (n *noopOrchestrator) Template(context.Context, string, resource.Content) (
 	return resource.Content{}, nil
 }
 
+func (n *noopOrchestrator) OrderByDependencies(_ context.Context, targets []resource.Resource) (orchestratordomain.DependencyOrder, error) {
+	return orchestratordomain.OrderByAncestry(targets), nil
+}
+
 type noopContextService struct{}
 
 func (n *noopContextService) Create(context.Context, configdomain.Context) error { return nil }
@@ -0,0 +1,84 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import "github.com/crmarques/declarest/resource"
+
+type Status string
+
+const (
+	StatusInSync        Status = "in-sync"
+	StatusModified      Status = "modified"
+	StatusMissingRemote Status = "missing-remote"
+	StatusRemoteOnly    Status = "remote-only"
+)
+
+// Entry classifies one logical path. Diff holds the compare-transformed
+// differences for modified and missing-remote resources.
+type Entry struct {
+	LogicalPath string               `json:"logicalPath" yaml:"logicalPath"`
+	Status      Status               `json:"status" yaml:"status"`
+	Diff        []resource.DiffEntry `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+type Summary struct {
+	Total         int `json:"total" yaml:"total"`
+	InSync        int `json:"inSync" yaml:"inSync"`
+	Modified      int `json:"modified" yaml:"modified"`
+	MissingRemote int `json:"missingRemote" yaml:"missingRemote"`
+	RemoteOnly    int `json:"remoteOnly" yaml:"remoteOnly"`
+}
+
+// Drifted returns the number of paths that are not in sync.
+func (s Summary) Drifted() int {
+	return s.Modified + s.MissingRemote + s.RemoteOnly
+}
+
+// Report is the drift classification of every local and remote resource
+// under Path. Entries are sorted by logical path.
+type Report struct {
+	Path      string  `json:"path" yaml:"path"`
+	Recursive bool    `json:"recursive" yaml:"recursive"`
+	Summary   Summary `json:"summary" yaml:"summary"`
+	Entries   []Entry `json:"entries" yaml:"entries"`
+}
+
+// Drifted returns the entries that are not in sync.
+func (r Report) Drifted() []Entry {
+	drifted := make([]Entry, 0, r.Summary.Drifted())
+	for _, entry := range r.Entries {
+		if entry.Status != StatusInSync {
+			drifted = append(drifted, entry)
+		}
+	}
+	return drifted
+}
+
+func summarize(entries []Entry) Summary {
+	summary := Summary{Total: len(entries)}
+	for _, entry := range entries {
+		switch entry.Status {
+		case StatusInSync:
+			summary.InSync++
+		case StatusModified:
+			summary.Modified++
+		case StatusMissingRemote:
+			summary.MissingRemote++
+		case StatusRemoteOnly:
+			summary.RemoteOnly++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/crmarques/declarest/faults"
+	appdeps "github.com/crmarques/declarest/internal/app/deps"
+	mutateapp "github.com/crmarques/declarest/internal/app/resource/mutate"
+	resourceplanapp "github.com/crmarques/declarest/internal/app/resource/plan"
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+type Dependencies = appdeps.Dependencies
+
+type Request struct {
+	LogicalPath string
+	Recursive   bool
+}
+
+// Build classifies every local resource under req.LogicalPath against its
+// remote counterpart and adds the remote resources listed under the same
+// path that have no local counterpart. It never mutates remote state.
+func Build(ctx context.Context, deps Dependencies, req Request) (Report, error) {
+	orchestratorService, err := appdeps.RequireOrchestrator(deps)
+	if err != nil {
+		return Report{}, err
+	}
+	planner, err := resourceplanapp.RequirePlanner(orchestratorService)
+	if err != nil {
+		return Report{}, err
+	}
+
+	localTargets, err := mutateapp.ListLocalTargets(ctx, orchestratorService, req.LogicalPath, req.Recursive)
+	if err != nil && !faults.IsCategory(err, faults.NotFoundError) {
+		return Report{}, err
+	}
+	remoteItems, remoteErr := orchestratorService.ListRemote(
+		ctx,
+		req.LogicalPath,
+		orchestratordomain.ListPolicy{Recursive: req.Recursive},
+	)
+	if remoteErr != nil && !faults.IsCategory(remoteErr, faults.NotFoundError) {
+		return Report{}, remoteErr
+	}
+	if len(localTargets) == 0 && len(remoteItems) == 0 {
+		return Report{}, faults.NotFound(
+			fmt.Sprintf("no local or remote resources found under %q", req.LogicalPath),
+			nil,
+		)
+	}
+
+	entries := make([]Entry, 0, len(localTargets)+len(remoteItems))
+	localPaths := make(map[string]struct{}, len(localTargets))
+	for _, target := range localTargets {
+		localPaths[target.LogicalPath] = struct{}{}
+
+		entry, err := classifyLocal(ctx, planner, target)
+		if err != nil {
+			return Report{}, err
+		}
+		entries = append(entries, entry)
+	}
+	for _, remote := range remoteItems {
+		if _, exists := localPaths[remote.LogicalPath]; exists {
+			continue
+		}
+		entries = append(entries, Entry{LogicalPath: remote.LogicalPath, Status: StatusRemoteOnly})
+	}
+
+	sort.Slice(entries, func(i int, j int) bool {
+		return entries[i].LogicalPath < entries[j].LogicalPath
+	})
+	return Report{
+		Path:      req.LogicalPath,
+		Recursive: req.Recursive,
+		Summary:   summarize(entries),
+		Entries:   entries,
+	}, nil
+}
+
+func classifyLocal(ctx context.Context, planner resourceplanapp.Planner, target resource.Resource) (Entry, error) {
+	item, err := planner.PlanApply(ctx, target.LogicalPath)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{LogicalPath: target.LogicalPath, Diff: item.Diff}
+	switch item.Action {
+	case resourceplanapp.ActionNoop:
+		entry.Status = StatusInSync
+	case resourceplanapp.ActionUpdate:
+		entry.Status = StatusModified
+	case resourceplanapp.ActionCreate:
+		entry.Status = StatusMissingRemote
+	default:
+		return Entry{}, faults.Internal(
+			fmt.Sprintf("unexpected plan action %q for %q", item.Action, target.LogicalPath),
+			nil,
+		)
+	}
+	return entry, nil
+}
+
+// DetectedError returns a ConflictError summarizing the drifted paths of
+// report, or nil when every path is in sync.
+func DetectedError(report Report) error {
+	drifted := report.Summary.Drifted()
+	if drifted == 0 {
+		return nil
+	}
+	return faults.Conflict(
+		fmt.Sprintf(
+			"drift detected for %d of %d resource(s) under %q: %d modified, %d missing-remote, %d remote-only",
+			drifted,
+			report.Summary.Total,
+			report.Path,
+			report.Summary.Modified,
+			report.Summary.MissingRemote,
+			report.Summary.RemoteOnly,
+		),
+		nil,
+	)
+}
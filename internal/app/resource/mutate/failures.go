@@ -0,0 +1,105 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/crmarques/declarest/faults"
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+// Failure records a single target that failed during a continue-on-error
+// bulk mutation.
+type Failure struct {
+	LogicalPath string               `json:"logicalPath" yaml:"logicalPath"`
+	Category    faults.ErrorCategory `json:"category" yaml:"category"`
+	Message     string               `json:"message" yaml:"message"`
+}
+
+// NewFailure builds a Failure for logicalPath, classifying err by its typed
+// category and falling back to InternalError for untyped errors.
+func NewFailure(logicalPath string, err error) Failure {
+	category := faults.InternalError
+	var typedErr *faults.TypedError
+	if errors.As(err, &typedErr) && typedErr.Category != "" {
+		category = typedErr.Category
+	}
+
+	message := ""
+	if err != nil {
+		message = strings.TrimSpace(err.Error())
+	}
+	return Failure{
+		LogicalPath: logicalPath,
+		Category:    category,
+		Message:     message,
+	}
+}
+
+// FailuresError returns a PartialFailureError summarizing failures, or nil
+// when there are none.
+func FailuresError(operation string, failures []Failure, targetedCount int) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return faults.PartialFailure(
+		fmt.Sprintf(
+			"resource %s failed for %d of %d target(s)",
+			strings.TrimSpace(operation),
+			len(failures),
+			targetedCount,
+		),
+		nil,
+	)
+}
+
+// RunTargetsCollectingFailures behaves like RunTargets but records per-target
+// errors instead of stopping at the first one. Context cancellation still
+// aborts the run. Failures are returned sorted by logical path.
+func RunTargetsCollectingFailures(
+	ctx context.Context,
+	order orchestratordomain.DependencyOrder,
+	parallelism int,
+	run func(context.Context, int, resource.Resource) error,
+) ([]Failure, error) {
+	var mu sync.Mutex
+	failures := make([]Failure, 0)
+	err := RunTargets(ctx, order, parallelism, func(runCtx context.Context, idx int, target resource.Resource) error {
+		if err := run(runCtx, idx, target); err != nil {
+			if ctxErr := runCtx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			mu.Lock()
+			failures = append(failures, NewFailure(target.LogicalPath, err))
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(failures, func(i int, j int) bool {
+		return failures[i].LogicalPath < failures[j].LogicalPath
+	})
+	return failures, nil
+}
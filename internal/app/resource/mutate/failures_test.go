@@ -0,0 +1,84 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+func TestExecuteMutationForTargetsContinueOnErrorCollectsFailures(t *testing.T) {
+	t.Parallel()
+
+	targets := parallelTestTargets("/c", "/a", "/b", "/d")
+	items, failures, err := executeMutationForTargets(context.Background(), orchestratordomain.OrderByAncestry(targets), 2, true, func(_ context.Context, logicalPath string) (resource.Resource, error) {
+		switch logicalPath {
+		case "/c":
+			return resource.Resource{}, faults.NotFound("missing", nil)
+		case "/a":
+			return resource.Resource{}, errors.New("boom")
+		}
+		return resource.Resource{LogicalPath: logicalPath}, nil
+	})
+	if err != nil {
+		t.Fatalf("executeMutationForTargets returned error: %v", err)
+	}
+
+	paths := make([]string, 0, len(items))
+	for _, item := range items {
+		paths = append(paths, item.LogicalPath)
+	}
+	if got := strings.Join(paths, ","); got != "/b,/d" {
+		t.Fatalf("expected successful items /b,/d, got %q", got)
+	}
+
+	if len(failures) != 2 {
+		t.Fatalf("expected two failures, got %#v", failures)
+	}
+	if failures[0].LogicalPath != "/a" || failures[0].Category != faults.InternalError || failures[0].Message != "boom" {
+		t.Fatalf("unexpected untyped failure: %#v", failures[0])
+	}
+	if failures[1].LogicalPath != "/c" || failures[1].Category != faults.NotFoundError {
+		t.Fatalf("unexpected typed failure: %#v", failures[1])
+	}
+
+	failuresErr := FailuresError("apply", failures, len(targets))
+	if !faults.IsCategory(failuresErr, faults.PartialFailureError) {
+		t.Fatalf("expected partial failure error, got %v", failuresErr)
+	}
+	if code := faults.ExitCodeForError(failuresErr); code != 7 {
+		t.Fatalf("expected exit code 7, got %d", code)
+	}
+}
+
+func TestRunTargetsCollectingFailuresStopsOnCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	targets := parallelTestTargets("/a", "/b")
+	_, err := RunTargetsCollectingFailures(ctx, orchestratordomain.OrderByAncestry(targets), 1, func(runCtx context.Context, _ int, _ resource.Resource) error {
+		cancel()
+		return runCtx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+}
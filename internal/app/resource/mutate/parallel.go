@@ -0,0 +1,62 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutate
+
+import (
+	"context"
+
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+	"golang.org/x/sync/errgroup"
+)
+
+// RunTargets calls run once per target of order. With parallelism <= 1
+// targets run one by one in order.Targets sequence. Otherwise each level of
+// order runs with at most parallelism concurrent calls, so every target starts
+// only after the targets it depends on have completed. The index passed to run
+// refers to order.Targets. The first error cancels the remaining work and is
+// returned.
+func RunTargets(
+	ctx context.Context,
+	order orchestratordomain.DependencyOrder,
+	parallelism int,
+	run func(context.Context, int, resource.Resource) error,
+) error {
+	if parallelism <= 1 {
+		for idx, target := range order.Targets {
+			if err := run(ctx, idx, target); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, level := range order.Levels {
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(parallelism)
+		for _, idx := range level {
+			group.Go(func() error {
+				if err := groupCtx.Err(); err != nil {
+					return err
+				}
+				return run(groupCtx, idx, order.Targets[idx])
+			})
+		}
+		if err := group.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
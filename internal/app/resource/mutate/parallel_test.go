@@ -0,0 +1,146 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+func parallelTestTargets(paths ...string) []resource.Resource {
+	targets := make([]resource.Resource, 0, len(paths))
+	for _, logicalPath := range paths {
+		targets = append(targets, resource.Resource{LogicalPath: logicalPath})
+	}
+	return targets
+}
+
+func TestRunTargetsSequentialKeepsInputOrder(t *testing.T) {
+	t.Parallel()
+
+	targets := parallelTestTargets("/b/x", "/a", "/c")
+	var visited []string
+	err := RunTargets(context.Background(), orchestratordomain.OrderByAncestry(targets), 1, func(_ context.Context, _ int, target resource.Resource) error {
+		visited = append(visited, target.LogicalPath)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunTargets returned error: %v", err)
+	}
+	if got := strings.Join(visited, ","); got != "/b/x,/a,/c" {
+		t.Fatalf("expected input order, got %q", got)
+	}
+}
+
+func TestRunTargetsRunsParentDepthBeforeChildren(t *testing.T) {
+	t.Parallel()
+
+	targets := parallelTestTargets("/realms/a/clients/x", "/realms/a", "/realms/b", "/realms/b/clients/y")
+	var mu sync.Mutex
+	finished := map[string]bool{}
+	err := RunTargets(context.Background(), orchestratordomain.OrderByAncestry(targets), 4, func(_ context.Context, _ int, target resource.Resource) error {
+		if strings.Contains(target.LogicalPath, "/clients/") {
+			mu.Lock()
+			defer mu.Unlock()
+			if !finished["/realms/a"] || !finished["/realms/b"] {
+				t.Errorf("child %q started before its parent depth finished", target.LogicalPath)
+			}
+			return nil
+		}
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		finished[target.LogicalPath] = true
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunTargets returned error: %v", err)
+	}
+}
+
+func TestRunTargetsBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	targets := parallelTestTargets("/a", "/b", "/c", "/d", "/e", "/f")
+	var inFlight, peak atomic.Int32
+	err := RunTargets(context.Background(), orchestratordomain.OrderByAncestry(targets), 2, func(_ context.Context, _ int, _ resource.Resource) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := peak.Load()
+			if current <= observed || peak.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunTargets returned error: %v", err)
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent targets, got %d", peak.Load())
+	}
+}
+
+func TestRunTargetsStopsAfterFirstError(t *testing.T) {
+	t.Parallel()
+
+	expected := errors.New("boom")
+	targets := parallelTestTargets("/a", "/b", "/a/child")
+	var childRuns atomic.Int32
+	err := RunTargets(context.Background(), orchestratordomain.OrderByAncestry(targets), 2, func(_ context.Context, _ int, target resource.Resource) error {
+		if target.LogicalPath == "/a/child" {
+			childRuns.Add(1)
+		}
+		if target.LogicalPath == "/b" {
+			return expected
+		}
+		return nil
+	})
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected first error to be returned, got %v", err)
+	}
+	if childRuns.Load() != 0 {
+		t.Fatal("expected deeper targets to be skipped after an error")
+	}
+}
+
+func TestExecuteMutationForTargetsSortsParallelResults(t *testing.T) {
+	t.Parallel()
+
+	targets := parallelTestTargets("/b", "/a", "/c")
+	items, _, err := executeMutationForTargets(context.Background(), orchestratordomain.OrderByAncestry(targets), 3, false, func(_ context.Context, logicalPath string) (resource.Resource, error) {
+		return resource.Resource{LogicalPath: logicalPath}, nil
+	})
+	if err != nil {
+		t.Fatalf("executeMutationForTargets returned error: %v", err)
+	}
+	paths := make([]string, 0, len(items))
+	for _, item := range items {
+		paths = append(paths, item.LogicalPath)
+	}
+	if got := strings.Join(paths, ","); got != "/a,/b,/c" {
+		t.Fatalf("expected sorted results, got %q", got)
+	}
+}
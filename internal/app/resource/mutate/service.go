@@ -45,12 +45,19 @@ type Request struct {
 	Value            resource.Content
 	HasExplicitInput bool
 	RefreshLocal     bool
+	// Parallelism bounds concurrent target mutations; values <= 1 run
+	// targets sequentially.
+	Parallelism int
+	// ContinueOnError keeps mutating remaining targets after a failure and
+	// reports per-target failures in Result.Failures.
+	ContinueOnError bool
 }
 
 type Result struct {
 	ResolvedPath  string
 	TargetedCount int
 	Items         []resource.Resource
+	Failures      []Failure
 }
 
 func Execute(ctx context.Context, deps Dependencies, req Request) (Result, error) {
@@ -89,8 +96,12 @@ func Execute(ctx context.Context, deps Dependencies, req Request) (Result, error
 		return Result{}, err
 	}
 	targetedCount := len(targets)
+	order, err := orchestratorService.OrderByDependencies(ctx, targets)
+	if err != nil {
+		return Result{}, err
+	}
 
-	items, err := executeMutationForTargets(ctx, targets, func(runCtx context.Context, logicalPath string) (resource.Resource, error) {
+	items, failures, err := executeMutationForTargets(ctx, order, req.Parallelism, req.ContinueOnError, func(runCtx context.Context, logicalPath string) (resource.Resource, error) {
 		switch req.Operation {
 		case OperationApply:
 			return orchestratorService.Apply(runCtx, logicalPath, orchestratordomain.ApplyPolicy{
@@ -125,7 +136,12 @@ func Execute(ctx context.Context, deps Dependencies, req Request) (Result, error
 		}
 	}
 
-	return Result{ResolvedPath: req.LogicalPath, TargetedCount: targetedCount, Items: items}, nil
+	return Result{
+		ResolvedPath:  req.LogicalPath,
+		TargetedCount: targetedCount,
+		Items:         items,
+		Failures:      failures,
+	}, nil
 }
 
 func runExplicitMutation(
@@ -216,22 +232,44 @@ func ListLocalTargetsOrFallbackPath(
 
 func executeMutationForTargets(
 	ctx context.Context,
-	targets []resource.Resource,
+	order orchestratordomain.DependencyOrder,
+	parallelism int,
+	continueOnError bool,
 	runMutation func(context.Context, string) (resource.Resource, error),
-) ([]resource.Resource, error) {
-	results := make([]resource.Resource, 0, len(targets))
-	for _, target := range targets {
-		item, err := runMutation(ctx, target.LogicalPath)
+) ([]resource.Resource, []Failure, error) {
+	results := make([]resource.Resource, len(order.Targets))
+	succeeded := make([]bool, len(order.Targets))
+	run := func(runCtx context.Context, idx int, target resource.Resource) error {
+		item, err := runMutation(runCtx, target.LogicalPath)
+		if err != nil {
+			return err
+		}
+		results[idx] = item
+		succeeded[idx] = true
+		return nil
+	}
+
+	var failures []Failure
+	if continueOnError {
+		var err error
+		failures, err = RunTargetsCollectingFailures(ctx, order, parallelism, run)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		results = append(results, item)
+	} else if err := RunTargets(ctx, order, parallelism, run); err != nil {
+		return nil, nil, err
 	}
 
-	sort.Slice(results, func(i int, j int) bool {
-		return results[i].LogicalPath < results[j].LogicalPath
+	items := make([]resource.Resource, 0, len(results))
+	for idx, item := range results {
+		if succeeded[idx] {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		return items[i].LogicalPath < items[j].LogicalPath
 	})
-	return results, nil
+	return items, failures, nil
 }
 
 func refreshRepositoryForPaths(ctx context.Context, deps Dependencies, items []resource.Resource) error {
@@ -0,0 +1,107 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/crmarques/declarest/resource"
+)
+
+// FormatVersion identifies the serialized plan layout accepted by Execute.
+const FormatVersion = 1
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionNoop   Action = "no-op"
+	ActionDelete Action = "delete"
+)
+
+// Item is the planned outcome for a single resource. RemoteDigest captures
+// the remote state the plan was computed against; it is empty when the
+// resource did not exist remotely. LocalDigest captures the rendered desired
+// payload the plan was computed from; it is empty for deletes.
+type Item struct {
+	LogicalPath  string               `json:"logicalPath" yaml:"logicalPath"`
+	Action       Action               `json:"action" yaml:"action"`
+	Method       string               `json:"method,omitempty" yaml:"method,omitempty"`
+	RemotePath   string               `json:"remotePath,omitempty" yaml:"remotePath,omitempty"`
+	LocalDigest  string               `json:"localDigest,omitempty" yaml:"localDigest,omitempty"`
+	RemoteDigest string               `json:"remoteDigest,omitempty" yaml:"remoteDigest,omitempty"`
+	Diff         []resource.DiffEntry `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// Plan is a reviewable, serializable set of planned actions. Items are kept
+// in execution order: creates and updates follow dependency order and
+// deletes come last in reverse dependency order.
+type Plan struct {
+	Version   int    `json:"version" yaml:"version"`
+	Path      string `json:"path" yaml:"path"`
+	Recursive bool   `json:"recursive" yaml:"recursive"`
+	Prune     bool   `json:"prune" yaml:"prune"`
+	Items     []Item `json:"items" yaml:"items"`
+}
+
+// Planner is an optional orchestrator capability that computes plan items
+// without mutating remote state.
+type Planner interface {
+	PlanApply(ctx context.Context, logicalPath string) (Item, error)
+	PlanDelete(ctx context.Context, logicalPath string) (Item, error)
+}
+
+// RemoteDigest fingerprints remote content so that Execute can detect remote
+// changes made after the plan was computed. Missing content has an empty
+// digest.
+func RemoteDigest(content resource.Content) (string, error) {
+	return contentDigest(content)
+}
+
+// LocalDigest fingerprints the rendered desired payload so that Execute can
+// detect repository edits made after the plan was computed.
+func LocalDigest(content resource.Content) (string, error) {
+	return contentDigest(content)
+}
+
+func contentDigest(content resource.Content) (string, error) {
+	if content.Value == nil {
+		return "", nil
+	}
+
+	normalized, err := resource.Normalize(content.Value)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Counts returns the number of items per action.
+func (p Plan) Counts() map[Action]int {
+	counts := make(map[Action]int, 4)
+	for _, item := range p.Items {
+		counts[item.Action]++
+	}
+	return counts
+}
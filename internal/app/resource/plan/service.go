@@ -0,0 +1,201 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	appdeps "github.com/crmarques/declarest/internal/app/deps"
+	mutateapp "github.com/crmarques/declarest/internal/app/resource/mutate"
+	pruneapp "github.com/crmarques/declarest/internal/app/resource/prune"
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+type Dependencies = appdeps.Dependencies
+
+type Request struct {
+	LogicalPath string
+	Recursive   bool
+	// Prune plans deletes for remote resources under LogicalPath that have no
+	// local counterpart, skipping paths whose metadata opts out of pruning.
+	Prune bool
+}
+
+type Result struct {
+	Items   []resource.Resource
+	Deleted []string
+}
+
+// Build computes a plan for req without mutating remote state.
+func Build(ctx context.Context, deps Dependencies, req Request) (Plan, error) {
+	orchestratorService, err := appdeps.RequireOrchestrator(deps)
+	if err != nil {
+		return Plan{}, err
+	}
+	planner, err := RequirePlanner(orchestratorService)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	targets, err := mutateapp.ListLocalTargets(ctx, orchestratorService, req.LogicalPath, req.Recursive)
+	if err != nil {
+		if !req.Prune || !faults.IsCategory(err, faults.NotFoundError) {
+			return Plan{}, err
+		}
+		targets = nil
+	}
+	order, err := orchestratorService.OrderByDependencies(ctx, targets)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	result := Plan{
+		Version:   FormatVersion,
+		Path:      req.LogicalPath,
+		Recursive: req.Recursive,
+		Prune:     req.Prune,
+		Items:     make([]Item, 0, len(order.Targets)),
+	}
+	for _, target := range order.Targets {
+		item, err := planner.PlanApply(ctx, target.LogicalPath)
+		if err != nil {
+			return Plan{}, err
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	if !req.Prune {
+		return result, nil
+	}
+	deleteItems, err := buildPruneItems(ctx, deps, planner, req)
+	if err != nil {
+		return Plan{}, err
+	}
+	result.Items = append(result.Items, deleteItems...)
+	return result, nil
+}
+
+func buildPruneItems(ctx context.Context, deps Dependencies, planner Planner, req Request) ([]Item, error) {
+	candidates, err := pruneapp.ListCandidates(ctx, deps, req.LogicalPath, req.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(candidates.Order.Targets))
+	for _, candidate := range candidates.Order.Targets {
+		item, err := planner.PlanDelete(ctx, candidate.LogicalPath)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Execute runs a previously built plan. It first re-reads the local and
+// remote state of every planned resource and refuses to mutate anything when
+// a digest no longer matches the plan.
+func Execute(ctx context.Context, deps Dependencies, planned Plan) (Result, error) {
+	if planned.Version != FormatVersion {
+		return Result{}, faults.Invalid(
+			fmt.Sprintf("unsupported plan version %d; expected %d", planned.Version, FormatVersion),
+			nil,
+		)
+	}
+
+	orchestratorService, err := appdeps.RequireOrchestrator(deps)
+	if err != nil {
+		return Result{}, err
+	}
+	planner, err := RequirePlanner(orchestratorService)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err := verifyPlannedState(ctx, planner, planned.Items); err != nil {
+		return Result{}, err
+	}
+
+	result := Result{}
+	for _, item := range planned.Items {
+		switch item.Action {
+		case ActionNoop:
+			continue
+		case ActionCreate, ActionUpdate:
+			applied, err := orchestratorService.Apply(ctx, item.LogicalPath, orchestratordomain.ApplyPolicy{
+				Force: item.Action == ActionUpdate,
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Items = append(result.Items, applied)
+		case ActionDelete:
+			if err := orchestratorService.Delete(ctx, item.LogicalPath, orchestratordomain.DeletePolicy{}); err != nil {
+				return result, err
+			}
+			result.Deleted = append(result.Deleted, item.LogicalPath)
+		default:
+			return result, faults.Invalid(
+				fmt.Sprintf("unsupported plan action %q for %q", item.Action, item.LogicalPath),
+				nil,
+			)
+		}
+	}
+	return result, nil
+}
+
+func verifyPlannedState(ctx context.Context, planner Planner, items []Item) error {
+	stale := make([]string, 0)
+	for _, item := range items {
+		var current Item
+		var err error
+		if item.Action == ActionDelete {
+			current, err = planner.PlanDelete(ctx, item.LogicalPath)
+		} else {
+			current, err = planner.PlanApply(ctx, item.LogicalPath)
+		}
+		if err != nil {
+			return err
+		}
+		if current.LocalDigest != item.LocalDigest || current.RemoteDigest != item.RemoteDigest {
+			stale = append(stale, item.LogicalPath)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	return faults.Conflict(
+		fmt.Sprintf(
+			"local or remote state changed since the plan was made for %d resource(s): %s; re-run resource plan",
+			len(stale),
+			strings.Join(stale, ", "),
+		),
+		nil,
+	)
+}
+
+// RequirePlanner returns the Planner capability of orchestratorService.
+func RequirePlanner(orchestratorService orchestratordomain.Orchestrator) (Planner, error) {
+	planner, ok := orchestratorService.(Planner)
+	if !ok {
+		return nil, faults.Invalid("configured orchestrator does not support resource plans", nil)
+	}
+	return planner, nil
+}
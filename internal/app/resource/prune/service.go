@@ -0,0 +1,137 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prune
+
+import (
+	"context"
+	"sort"
+
+	appdeps "github.com/crmarques/declarest/internal/app/deps"
+	mutateapp "github.com/crmarques/declarest/internal/app/resource/mutate"
+	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+type Dependencies = appdeps.Dependencies
+
+// Candidates holds the remote-only resources under a path. Order lists the
+// resources to delete, dependents before the resources they depend on;
+// Skipped lists remote-only paths whose metadata sets resource.prune to false.
+type Candidates struct {
+	Order   orchestratordomain.DependencyOrder
+	Skipped []string
+}
+
+// Paths returns the candidate logical paths in deletion order.
+func (c Candidates) Paths() []string {
+	paths := make([]string, 0, len(c.Order.Targets))
+	for _, target := range c.Order.Targets {
+		paths = append(paths, target.LogicalPath)
+	}
+	return paths
+}
+
+type Result struct {
+	Deleted  []string
+	Skipped  []string
+	Failures []mutateapp.Failure
+}
+
+// ListCandidates compares remote and local listings under logicalPath and
+// returns the remote-only resources that may be pruned.
+func ListCandidates(ctx context.Context, deps Dependencies, logicalPath string, recursive bool) (Candidates, error) {
+	orchestratorService, err := appdeps.RequireOrchestrator(deps)
+	if err != nil {
+		return Candidates{}, err
+	}
+	metadataService, err := appdeps.RequireMetadataService(deps)
+	if err != nil {
+		return Candidates{}, err
+	}
+
+	policy := orchestratordomain.ListPolicy{Recursive: recursive}
+	localItems, err := orchestratorService.ListLocal(ctx, logicalPath, policy)
+	if err != nil {
+		return Candidates{}, err
+	}
+	remoteItems, err := orchestratorService.ListRemote(ctx, logicalPath, policy)
+	if err != nil {
+		return Candidates{}, err
+	}
+
+	localPaths := make(map[string]struct{}, len(localItems))
+	for _, item := range localItems {
+		localPaths[item.LogicalPath] = struct{}{}
+	}
+
+	targets := make([]resource.Resource, 0)
+	skipped := make([]string, 0)
+	for _, remote := range remoteItems {
+		if _, exists := localPaths[remote.LogicalPath]; exists {
+			continue
+		}
+		resolvedMetadata, err := metadataService.ResolveForPath(ctx, remote.LogicalPath)
+		if err != nil {
+			return Candidates{}, err
+		}
+		if !resolvedMetadata.AllowsPrune() {
+			skipped = append(skipped, remote.LogicalPath)
+			continue
+		}
+		targets = append(targets, remote)
+	}
+	sort.Slice(targets, func(i int, j int) bool {
+		return targets[i].LogicalPath < targets[j].LogicalPath
+	})
+	sort.Strings(skipped)
+
+	order, err := orchestratorService.OrderByDependencies(ctx, targets)
+	if err != nil {
+		return Candidates{}, err
+	}
+	return Candidates{Order: order.Reverse(), Skipped: skipped}, nil
+}
+
+// Execute deletes candidates from the managed service. With continueOnError
+// set, failed deletes are reported in Result.Failures instead of stopping the
+// run.
+func Execute(ctx context.Context, deps Dependencies, candidates Candidates, continueOnError bool) (Result, error) {
+	orchestratorService, err := appdeps.RequireOrchestrator(deps)
+	if err != nil {
+		return Result{}, err
+	}
+
+	deleted := make([]bool, len(candidates.Order.Targets))
+	deleteTarget := func(runCtx context.Context, idx int, target resource.Resource) error {
+		if err := orchestratorService.Delete(runCtx, target.LogicalPath, orchestratordomain.DeletePolicy{}); err != nil {
+			return err
+		}
+		deleted[idx] = true
+		return nil
+	}
+
+	result := Result{Skipped: candidates.Skipped}
+	if continueOnError {
+		result.Failures, err = mutateapp.RunTargetsCollectingFailures(ctx, candidates.Order, 1, deleteTarget)
+	} else {
+		err = mutateapp.RunTargets(ctx, candidates.Order, 1, deleteTarget)
+	}
+	for idx, target := range candidates.Order.Targets {
+		if deleted[idx] {
+			result.Deleted = append(result.Deleted, target.LogicalPath)
+		}
+	}
+	return result, err
+}
@@ -197,3 +197,7 @@ func (f *fakeRequestOrchestrator) Diff(context.Context, string) ([]resource.Diff
 func (f *fakeRequestOrchestrator) Template(context.Context, string, resource.Content) (resource.Content, error) {
 	return resource.Content{}, nil
 }
+
+func (f *fakeRequestOrchestrator) OrderByDependencies(_ context.Context, targets []resource.Resource) (orchestratordomain.DependencyOrder, error) {
+	return orchestratordomain.OrderByAncestry(targets), nil
+}
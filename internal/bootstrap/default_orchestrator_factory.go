@@ -25,6 +25,7 @@ import (
 	"github.com/crmarques/declarest/internal/cli/cliutil"
 	internalorchestrator "github.com/crmarques/declarest/internal/orchestrator"
 	"github.com/crmarques/declarest/internal/promptauth"
+	graphqlmanagedservice "github.com/crmarques/declarest/internal/providers/managedservice/graphql"
 	httpmanagedservice "github.com/crmarques/declarest/internal/providers/managedservice/http"
 	bundlemetadata "github.com/crmarques/declarest/internal/providers/metadata/bundle"
 	fsmetadata "github.com/crmarques/declarest/internal/providers/metadata/fs"
@@ -35,6 +36,7 @@ import (
 	"github.com/crmarques/declarest/managedservice"
 	"github.com/crmarques/declarest/metadata"
 	"github.com/crmarques/declarest/repository"
+	"github.com/crmarques/declarest/resource"
 	"github.com/crmarques/declarest/secrets"
 )
 
@@ -94,13 +96,17 @@ func buildOrchestratorFromResolvedContext(
 	}
 
 	var repo repository.ResourceStore
+	serialization := repositorySerializationPolicy(resolvedContext.Repository.Serialization)
 	switch {
 	case resolvedContext.Repository.Filesystem != nil:
-		repo = fsstore.NewLocalResourceRepository(resolvedContext.Repository.Filesystem.BaseDir)
+		localRepository := fsstore.NewLocalResourceRepository(resolvedContext.Repository.Filesystem.BaseDir)
+		localRepository.SetSerializationPolicy(serialization)
+		repo = localRepository
 	case resolvedContext.Repository.Git != nil:
 		repo = gitrepository.NewGitResourceRepository(
 			*resolvedContext.Repository.Git,
 			gitrepository.WithPromptRuntime(authRuntime),
+			gitrepository.WithSerializationPolicy(serialization),
 		)
 	}
 	if repo != nil {
@@ -111,26 +117,48 @@ func buildOrchestratorFromResolvedContext(
 
 	var srv managedservice.ManagedServiceClient
 	if resolvedContext.ManagedService != nil {
-		if resolvedContext.ManagedService.HTTP == nil {
-			return nil, nil, faults.Internal("managed service provider is invalid", nil)
+		httpOptions := []httpmanagedservice.ClientOption{httpmanagedservice.WithPromptRuntime(authRuntime)}
+		cassetteOption, err := httpCassetteOption(resolvedContext.Preferences)
+		if err != nil {
+			return nil, nil, err
 		}
+		if cassetteOption != nil {
+			httpOptions = append(httpOptions, cassetteOption)
+		}
+		renderer, hasRenderer := metadataService.(metadata.ResourceOperationSpecRenderer)
 
-		serverConfig := *resolvedContext.ManagedService.HTTP
-		serverConfig.OpenAPI = effectiveOpenAPISource(serverConfig.OpenAPI, metadataSource.OpenAPI)
+		switch {
+		case resolvedContext.ManagedService.HTTP != nil:
+			serverConfig := *resolvedContext.ManagedService.HTTP
+			serverConfig.OpenAPI = effectiveOpenAPISource(serverConfig.OpenAPI, metadataSource.OpenAPI)
 
-		serverOptions := []httpmanagedservice.ClientOption{}
-		if renderer, ok := metadataService.(metadata.ResourceOperationSpecRenderer); ok {
-			serverOptions = append(serverOptions, httpmanagedservice.WithMetadataRenderer(renderer))
-		}
-		serverOptions = append(serverOptions, httpmanagedservice.WithPromptRuntime(authRuntime))
-		serverManager, err := httpmanagedservice.NewClient(
-			serverConfig,
-			serverOptions...,
-		)
-		if err != nil {
-			return nil, nil, err
+			if hasRenderer {
+				httpOptions = append(httpOptions, httpmanagedservice.WithMetadataRenderer(renderer))
+			}
+			serverManager, err := httpmanagedservice.NewClient(
+				serverConfig,
+				httpOptions...,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			srv = serverManager
+		case resolvedContext.ManagedService.GraphQL != nil:
+			graphqlOptions := []graphqlmanagedservice.ClientOption{graphqlmanagedservice.WithHTTPOptions(httpOptions...)}
+			if hasRenderer {
+				graphqlOptions = append(graphqlOptions, graphqlmanagedservice.WithMetadataRenderer(renderer))
+			}
+			serverManager, err := graphqlmanagedservice.NewClient(
+				*resolvedContext.ManagedService.GraphQL,
+				graphqlOptions...,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			srv = serverManager
+		default:
+			return nil, nil, faults.Internal("managed service provider is invalid", nil)
 		}
-		srv = serverManager
 	}
 
 	var sec secrets.SecretProvider
@@ -171,6 +199,26 @@ func effectiveOpenAPISource(configOpenAPI string, metadataOpenAPI string) string
 	return strings.TrimSpace(metadataOpenAPI)
 }
 
+// httpCassetteOption maps the recordHTTP/replayHTTP context preferences to the
+// managed-service client option that records or replays HTTP interactions.
+func httpCassetteOption(preferences map[string]string) (httpmanagedservice.ClientOption, error) {
+	recordDir := strings.TrimSpace(preferences[config.PreferenceRecordHTTP])
+	replayDir := strings.TrimSpace(preferences[config.PreferenceReplayHTTP])
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, faults.Invalid(
+			"preferences "+config.PreferenceRecordHTTP+" and "+config.PreferenceReplayHTTP+" cannot be combined",
+			nil,
+		)
+	case recordDir != "":
+		return httpmanagedservice.WithHTTPRecording(recordDir), nil
+	case replayDir != "":
+		return httpmanagedservice.WithHTTPReplay(replayDir), nil
+	default:
+		return nil, nil
+	}
+}
+
 type metadataSourceResolution struct {
 	BaseDir           string
 	OpenAPI           string
@@ -178,6 +226,19 @@ type metadataSourceResolution struct {
 	WriteTarget       fsmetadata.LayeredMetadataWriteTarget
 }
 
+func repositorySerializationPolicy(value *config.RepositorySerialization) resource.SerializationPolicy {
+	if value == nil {
+		return resource.SerializationPolicy{}
+	}
+	return resource.SerializationPolicy{
+		KeyOrder:             append([]string(nil), value.KeyOrder...),
+		NormalizeNumbers:     value.NormalizeNumbers,
+		YAMLIndent:           value.YAMLIndent,
+		YAMLCompactSequences: value.YAMLStyle == config.YAMLStyleCompact,
+		TrailingNewline:      value.TrailingNewline,
+	}
+}
+
 func resolvedRepositoryBaseDir(ctx config.Context) string {
 	switch {
 	case ctx.Repository.Filesystem != nil:
@@ -208,6 +269,14 @@ func collectSecurityWarnings(resolvedContext config.Context) []string {
 			warnings = append(warnings, "managed-service.http.tls.insecure-skip-verify is enabled, TLS certificate verification is disabled")
 		}
 	}
+	if resolvedContext.ManagedService != nil && resolvedContext.ManagedService.GraphQL != nil {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(resolvedContext.ManagedService.GraphQL.URL)), "http://") {
+			warnings = append(warnings, "managed-service.graphql.url uses plain HTTP, credentials will be transmitted in cleartext")
+		}
+		if resolvedContext.ManagedService.GraphQL.TLS != nil && resolvedContext.ManagedService.GraphQL.TLS.InsecureSkipVerify {
+			warnings = append(warnings, "managed-service.graphql.tls.insecure-skip-verify is enabled, TLS certificate verification is disabled")
+		}
+	}
 
 	if resolvedContext.SecretStore != nil && resolvedContext.SecretStore.Vault != nil {
 		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(resolvedContext.SecretStore.Vault.Address)), "http://") {
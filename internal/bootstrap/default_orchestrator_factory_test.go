@@ -867,6 +867,31 @@ func TestBuildOrchestratorValidationAndErrors(t *testing.T) {
 		}
 	})
 
+	t.Run("record_and_replay_http_preferences_are_exclusive", func(t *testing.T) {
+		t.Parallel()
+
+		contextService := &fakeContextService{
+			resolvedContext: config.Context{
+				Name: "cassette",
+				ManagedService: &config.ManagedService{
+					HTTP: &config.HTTPServer{
+						BaseURL: "https://example.com/api",
+						Auth: &config.HTTPAuth{
+							CustomHeaders: []config.HeaderTokenAuth{{Header: "X-Api-Key", Value: "token"}},
+						},
+					},
+				},
+				Preferences: map[string]string{
+					config.PreferenceRecordHTTP: "/tmp/record",
+					config.PreferenceReplayHTTP: "/tmp/replay",
+				},
+			},
+		}
+
+		_, _, err := buildOrchestrator(context.Background(), contextService, config.ContextSelection{Name: "cassette"})
+		assertTypedCategory(t, err, faults.ValidationError)
+	})
+
 	t.Run("invalid_managed_service_provider_configuration", func(t *testing.T) {
 		t.Parallel()
 
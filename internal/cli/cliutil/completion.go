@@ -52,6 +52,9 @@ var (
 		resource.PayloadTypeHCL,
 		resource.PayloadTypeINI,
 		resource.PayloadTypeProperties,
+		resource.PayloadTypeTOML,
+		resource.PayloadTypeCSV,
+		resource.PayloadTypeNDJSON,
 		resource.PayloadTypeText,
 		resource.PayloadTypeBinary,
 	}
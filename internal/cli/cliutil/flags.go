@@ -35,6 +35,8 @@ const (
 	GlobalFlagNoColor                = "no-color"
 	GlobalFlagOutput                 = "output"
 	GlobalFlagOutputShort            = "o"
+	GlobalFlagRecordHTTP             = "record-http"
+	GlobalFlagReplayHTTP             = "replay-http"
 
 	GlobalEnvContext                 = "DECLAREST_CONTEXT"
 	GlobalEnvOutput                  = "DECLAREST_OUTPUT"
@@ -45,6 +47,8 @@ const (
 	GlobalEnvIgnoreWarnings          = "DECLAREST_IGNORE_WARNINGS"
 	GlobalEnvNoColor                 = "DECLAREST_NO_COLOR"
 	GlobalEnvNoColorLegacy           = "NO_COLOR"
+	GlobalEnvRecordHTTP              = "DECLAREST_RECORD_HTTP"
+	GlobalEnvReplayHTTP              = "DECLAREST_REPLAY_HTTP"
 )
 
 type GlobalFlags struct {
@@ -55,6 +59,8 @@ type GlobalFlags struct {
 	IgnoreWarnings    bool
 	NoColor           bool
 	Output            string
+	RecordHTTP        string
+	ReplayHTTP        string
 }
 
 type InputFlags struct {
@@ -73,6 +79,8 @@ func BindGlobalFlags(command *cobra.Command, flags *GlobalFlags) {
 	flags.IgnoreWarnings = EnvBoolOrDefault(GlobalEnvIgnoreWarnings, false)
 	flags.NoColor = EnvBoolOrDefault(GlobalEnvNoColor, EnvPresentOrDefault(GlobalEnvNoColorLegacy, false))
 	flags.Output = EnvOrDefault(GlobalEnvOutput, OutputAuto)
+	flags.RecordHTTP = EnvOrDefault(GlobalEnvRecordHTTP, "")
+	flags.ReplayHTTP = EnvOrDefault(GlobalEnvReplayHTTP, "")
 
 	command.PersistentFlags().StringVarP(
 		&flags.Context,
@@ -123,9 +131,32 @@ func BindGlobalFlags(command *cobra.Command, flags *GlobalFlags) {
 		flags.Output,
 		"output format: auto|text|json|yaml (env: "+GlobalEnvOutput+")",
 	)
+	command.PersistentFlags().StringVar(
+		&flags.RecordHTTP,
+		GlobalFlagRecordHTTP,
+		flags.RecordHTTP,
+		"record managed-service HTTP interactions into this directory (env: "+GlobalEnvRecordHTTP+")",
+	)
+	command.PersistentFlags().StringVar(
+		&flags.ReplayHTTP,
+		GlobalFlagReplayHTTP,
+		flags.ReplayHTTP,
+		"replay managed-service HTTP interactions recorded in this directory (env: "+GlobalEnvReplayHTTP+")",
+	)
 	RegisterOutputFlagCompletion(command)
 }
 
+// ValidateHTTPCassetteFlags rejects combining --record-http with --replay-http.
+func ValidateHTTPCassetteFlags(flags *GlobalFlags) error {
+	if flags == nil {
+		return nil
+	}
+	if strings.TrimSpace(flags.RecordHTTP) != "" && strings.TrimSpace(flags.ReplayHTTP) != "" {
+		return ValidationError("flags --"+GlobalFlagRecordHTTP+" and --"+GlobalFlagReplayHTTP+" cannot be combined", nil)
+	}
+	return nil
+}
+
 // IsVerbose returns true when verbosity level is >= 1.
 func IsVerbose(flags *GlobalFlags) bool {
 	return flags != nil && flags.Verbose >= 1
@@ -155,7 +186,7 @@ func BindResourceInputFlags(command *cobra.Command, flags *InputFlags) {
 		&flags.ContentType,
 		"content-type",
 		"",
-		"input content type: json|yaml|xml|hcl|ini|properties|text|binary",
+		"input content type: json|yaml|xml|hcl|ini|properties|toml|csv|ndjson|text|binary",
 	)
 	RegisterResourceInputContentTypeFlagCompletion(command)
 }
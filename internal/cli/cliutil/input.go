@@ -227,7 +227,7 @@ func normalizeCLIInputContentType(contentType string) (string, error) {
 		return "", nil
 	default:
 		return "", ValidationError(
-			"invalid input content type: use json, yaml, xml, hcl, ini, properties, text, txt, binary, or a supported media type",
+			"invalid input content type: use json, yaml, xml, hcl, ini, properties, toml, csv, ndjson, text, txt, binary, or a supported media type",
 			nil,
 		)
 	}
@@ -281,6 +281,9 @@ func checkManagedService(command *cobra.Command, deps cliutil.CommandDependencie
 }
 
 func renderManagedServiceHealthCheckTarget(cfg configdomain.Context) string {
+	if cfg.ManagedService != nil && cfg.ManagedService.GraphQL != nil {
+		return strings.TrimSpace(cfg.ManagedService.GraphQL.URL)
+	}
 	if cfg.ManagedService == nil || cfg.ManagedService.HTTP == nil {
 		return "/"
 	}
@@ -2314,6 +2314,10 @@ func (s *testOrchestratorService) Template(context.Context, string, resource.Con
 	return resource.Content{}, nil
 }
 
+func (s *testOrchestratorService) OrderByDependencies(_ context.Context, targets []resource.Resource) (orchestratordomain.DependencyOrder, error) {
+	return orchestratordomain.OrderByAncestry(targets), nil
+}
+
 type testManagedServiceClientService struct {
 	requestErr error
 }
@@ -244,7 +244,7 @@ func promptGitAuth(
 	prompter configPrompter,
 	credentials map[string]configdomain.Credential,
 ) (*configdomain.GitAuth, error) {
-	method, err := prompter.Select(command, "Select git auth method", []string{"basic", "ssh", "accessKey"})
+	method, err := prompter.Select(command, "Select git auth method", []string{"basic", "ssh", "accessKey", "exec"})
 	if err != nil {
 		return nil, err
 	}
@@ -296,6 +296,12 @@ func promptGitAuth(
 			return nil, inputErr
 		}
 		auth.AccessKey = &configdomain.AccessKeyAuth{Token: token}
+	case "exec":
+		exec, inputErr := promptExecAuth(command, prompter, false)
+		if inputErr != nil {
+			return nil, inputErr
+		}
+		auth.Exec = exec
 	default:
 		return nil, cliutil.ValidationError("invalid git auth method selected", nil)
 	}
@@ -420,7 +426,7 @@ func promptHTTPAuth(
 	method, err := prompter.Select(
 		command,
 		"Select managedService auth method",
-		[]string{"oauth2", "basic", "customHeaders"},
+		[]string{"oauth2", "basic", "customHeaders", "sigv4", "session", "exec"},
 	)
 	if err != nil {
 		return nil, err
@@ -453,10 +459,66 @@ func promptHTTPAuth(
 		if inputErr != nil {
 			return nil, inputErr
 		}
-		clientSecret, inputErr := promptRequiredInput(command, prompter, "OAuth2 clientSecret: ", "oauth2 clientSecret")
+		oauth2 := &configdomain.OAuth2{GrantType: grantType}
+		var clientSecret string
+		if !oauth2.RequiresClientSecret() {
+			clientSecret, inputErr = promptOptionalInput(command, prompter, "OAuth2 clientSecret (optional): ")
+		} else {
+			clientSecret, inputErr = promptRequiredInput(command, prompter, "OAuth2 clientSecret: ", "oauth2 clientSecret")
+		}
 		if inputErr != nil {
 			return nil, inputErr
 		}
+		switch grantType {
+		case configdomain.OAuthAuthorizationCode:
+			oauth2.AuthorizationURL, inputErr = promptRequiredInput(
+				command,
+				prompter,
+				"OAuth2 authorizationURL: ",
+				"oauth2 authorizationURL",
+			)
+			if inputErr != nil {
+				return nil, inputErr
+			}
+			oauth2.RedirectURL, inputErr = promptOptionalInput(command, prompter, "OAuth2 redirectURL (optional): ")
+			if inputErr != nil {
+				return nil, inputErr
+			}
+		case configdomain.OAuthDeviceCode:
+			oauth2.DeviceAuthorizationURL, inputErr = promptRequiredInput(
+				command,
+				prompter,
+				"OAuth2 deviceAuthorizationURL: ",
+				"oauth2 deviceAuthorizationURL",
+			)
+			if inputErr != nil {
+				return nil, inputErr
+			}
+		case configdomain.OAuthRefreshToken:
+			oauth2.RefreshToken, inputErr = promptRequiredInput(
+				command,
+				prompter,
+				"OAuth2 refreshToken: ",
+				"oauth2 refreshToken",
+			)
+			if inputErr != nil {
+				return nil, inputErr
+			}
+		case configdomain.OAuthTokenExchange:
+			oauth2.SubjectTokenFile, inputErr = promptRequiredInput(
+				command,
+				prompter,
+				"OAuth2 subjectTokenFile: ",
+				"oauth2 subjectTokenFile",
+			)
+			if inputErr != nil {
+				return nil, inputErr
+			}
+			oauth2.SubjectTokenType, inputErr = promptOptionalInput(command, prompter, "OAuth2 subjectTokenType (optional): ")
+			if inputErr != nil {
+				return nil, inputErr
+			}
+		}
 		username, inputErr := promptOptionalInput(command, prompter, "OAuth2 username (optional): ")
 		if inputErr != nil {
 			return nil, inputErr
@@ -473,16 +535,14 @@ func promptHTTPAuth(
 		if inputErr != nil {
 			return nil, inputErr
 		}
-		auth.OAuth2 = &configdomain.OAuth2{
-			TokenURL:     tokenURL,
-			GrantType:    grantType,
-			ClientID:     clientID,
-			ClientSecret: clientSecret,
-			Username:     username,
-			Password:     password,
-			Scope:        scope,
-			Audience:     audience,
-		}
+		oauth2.TokenURL = tokenURL
+		oauth2.ClientID = clientID
+		oauth2.ClientSecret = clientSecret
+		oauth2.Username = username
+		oauth2.Password = password
+		oauth2.Scope = scope
+		oauth2.Audience = audience
+		auth.OAuth2 = oauth2
 	case "basic":
 		basic, inputErr := promptCredentialRef(command, prompter, credentials, "Managed-service basic auth")
 		if inputErr != nil {
@@ -495,6 +555,24 @@ func promptHTTPAuth(
 			return nil, inputErr
 		}
 		auth.CustomHeaders = customHeaders
+	case "sigv4":
+		sigv4, inputErr := promptSigV4Auth(command, prompter)
+		if inputErr != nil {
+			return nil, inputErr
+		}
+		auth.SigV4 = sigv4
+	case "session":
+		session, inputErr := promptSessionAuth(command, prompter, credentials)
+		if inputErr != nil {
+			return nil, inputErr
+		}
+		auth.Session = session
+	case "exec":
+		exec, inputErr := promptExecAuth(command, prompter, true)
+		if inputErr != nil {
+			return nil, inputErr
+		}
+		auth.Exec = exec
 	default:
 		return nil, cliutil.ValidationError("invalid managedService auth method selected", nil)
 	}
@@ -502,6 +580,138 @@ func promptHTTPAuth(
 	return auth, nil
 }
 
+// promptSigV4Auth asks for static keys only when an access key ID is given;
+// otherwise credentials come from a shared credentials file or the environment.
+func promptSigV4Auth(command *cobra.Command, prompter configPrompter) (*configdomain.SigV4Auth, error) {
+	region, err := promptRequiredInput(command, prompter, "SigV4 region: ", "sigv4 region")
+	if err != nil {
+		return nil, err
+	}
+	service, err := promptRequiredInput(command, prompter, "SigV4 service: ", "sigv4 service")
+	if err != nil {
+		return nil, err
+	}
+	sigv4 := &configdomain.SigV4Auth{Region: region, Service: service}
+
+	sigv4.AccessKeyID, err = promptOptionalInput(command, prompter, "SigV4 accessKeyID (optional): ")
+	if err != nil {
+		return nil, err
+	}
+	if sigv4.AccessKeyID != "" {
+		sigv4.SecretAccessKey, err = promptRequiredInput(command, prompter, "SigV4 secretAccessKey: ", "sigv4 secretAccessKey")
+		if err != nil {
+			return nil, err
+		}
+		sigv4.SessionToken, err = promptOptionalInput(command, prompter, "SigV4 sessionToken (optional): ")
+		if err != nil {
+			return nil, err
+		}
+		return sigv4, nil
+	}
+
+	sigv4.CredentialsFile, err = promptOptionalInput(command, prompter, "SigV4 credentialsFile (optional): ")
+	if err != nil {
+		return nil, err
+	}
+	sigv4.Profile, err = promptOptionalInput(command, prompter, "SigV4 profile (optional): ")
+	if err != nil {
+		return nil, err
+	}
+	return sigv4, nil
+}
+
+func promptSessionAuth(
+	command *cobra.Command,
+	prompter configPrompter,
+	credentials map[string]configdomain.Credential,
+) (*configdomain.SessionAuth, error) {
+	loginURL, err := promptRequiredInput(command, prompter, "Session loginURL: ", "session loginURL")
+	if err != nil {
+		return nil, err
+	}
+	bodyFormat, err := prompter.Select(
+		command,
+		"Select session login body format",
+		[]string{configdomain.SessionBodyForm, configdomain.SessionBodyJSON},
+	)
+	if err != nil {
+		return nil, err
+	}
+	session := &configdomain.SessionAuth{LoginURL: loginURL, BodyFormat: bodyFormat}
+
+	session.Credentials, err = promptCredentialRef(command, prompter, credentials, "Session login")
+	if err != nil {
+		return nil, err
+	}
+	session.UsernameField, err = promptOptionalInput(command, prompter, "Session usernameField (default username): ")
+	if err != nil {
+		return nil, err
+	}
+	session.PasswordField, err = promptOptionalInput(command, prompter, "Session passwordField (default password): ")
+	if err != nil {
+		return nil, err
+	}
+
+	csrfHeader, err := promptOptionalInput(command, prompter, "Session CSRF request header (optional): ")
+	if err != nil || csrfHeader == "" {
+		return session, err
+	}
+	source, err := prompter.Select(command, "Select CSRF token source", []string{"responseHeader", "jsonPointer"})
+	if err != nil {
+		return nil, err
+	}
+	csrf := &configdomain.SessionCSRF{Header: csrfHeader}
+	if source == "jsonPointer" {
+		csrf.JSONPointer, err = promptRequiredInput(command, prompter, "CSRF token JSON pointer: ", "csrf jsonPointer")
+	} else {
+		csrf.ResponseHeader, err = promptRequiredInput(command, prompter, "CSRF token response header: ", "csrf responseHeader")
+	}
+	if err != nil {
+		return nil, err
+	}
+	session.CSRF = csrf
+	return session, nil
+}
+
+// promptExecAuth asks for the credential helper command; the token header and
+// prefix apply only to managed services.
+func promptExecAuth(command *cobra.Command, prompter configPrompter, withHeader bool) (*configdomain.ExecAuth, error) {
+	helper, err := promptRequiredInput(command, prompter, "Exec command: ", "exec command")
+	if err != nil {
+		return nil, err
+	}
+	exec := &configdomain.ExecAuth{Command: helper}
+
+	args, err := promptOptionalInput(command, prompter, "Exec args (space-separated, optional): ")
+	if err != nil {
+		return nil, err
+	}
+	exec.Args = strings.Fields(args)
+	exec.Env, err = promptStringMap(command, prompter, "Exec env")
+	if err != nil {
+		return nil, err
+	}
+	exec.Timeout, err = promptOptionalInput(command, prompter, "Exec timeout (default 30s): ")
+	if err != nil {
+		return nil, err
+	}
+	if !withHeader {
+		return exec, nil
+	}
+
+	exec.Header, err = promptOptionalInput(command, prompter, "Exec token header (default Authorization): ")
+	if err != nil {
+		return nil, err
+	}
+	if exec.Header != "" {
+		exec.Prefix, err = promptOptionalInput(command, prompter, "Exec token prefix (optional): ")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exec, nil
+}
+
 func promptCustomHeaders(command *cobra.Command, prompter configPrompter) ([]configdomain.HeaderTokenAuth, error) {
 	customHeaders := make([]configdomain.HeaderTokenAuth, 0, 1)
 	for {
@@ -64,6 +64,9 @@ contexts:
         #     #   insecureIgnoreHostKey: false
         #     # accessKey:
         #     #   token: change-me
+        #     # exec:
+        #     #   command: git-token-helper
+        #     #   args: ["--audience", "git"]
         #
         #   # Optional TLS.
         #   tls:
@@ -115,12 +118,44 @@ contexts:
           #   password: change-me
           #   scope: api.read
           #   audience: https://example.com/
+          #   # For interactive login, set grantType to authorization_code (with
+          #   # authorizationURL) or device_code (with deviceAuthorizationURL).
+          #   # Use refresh_token (with refreshToken) for offline tokens, or
+          #   # token_exchange (with subjectTokenFile) for RFC 8693 exchange.
+          #   # clientSecret is optional for all grants but client_credentials.
+          #   # Replace clientSecret with clientAuthMethod: private_key_jwt (plus
+          #   # clientAssertionKeyFile) or tls_client_auth (uses tls client cert).
           # basic:
           #   credentialsRef:
           #     name: shared-basic
           # customHeaders:
           #   - header: X-API-Key
           #     value: change-me
+          # sigv4:
+          #   region: us-east-1
+          #   service: execute-api
+          #   # Omit the keys to use credentialsFile/profile or AWS_* env vars.
+          #   accessKeyID: change-me
+          #   secretAccessKey: change-me
+          # session:
+          #   loginURL: /j_security_check
+          #   bodyFormat: form
+          #   credentials:
+          #     credentialsRef:
+          #       name: shared-basic
+          #   usernameField: j_username
+          #   passwordField: j_password
+          #   csrf:
+          #     header: X-CSRF-Token
+          #     responseHeader: X-CSRF-Token
+          # exec:
+          #   # Prints an ExecCredential JSON document; the token is cached
+          #   # until status.expirationTimestamp.
+          #   command: company-token-helper
+          #   args: ["--audience", "api"]
+          #   timeout: 30s
+          #   # Defaults to Authorization with the Bearer prefix.
+          #   header: Authorization
 
         # Optional TLS.
         # tls:
@@ -129,6 +164,12 @@ contexts:
         #   clientKeyFile: /path/to/client-key.pem
         #   insecureSkipVerify: false
 
+      # Mutually exclusive with http: GraphQL-only APIs use graphql, which
+      # accepts the same auth, defaultHeaders, proxy, tls, requestThrottling,
+      # and retry blocks.
+      # graphql:
+      #   url: https://example.com/graphql
+
     # Optional secret store.
     # secretStore:
     #   # Mutually exclusive: choose exactly one provider.
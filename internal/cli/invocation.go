@@ -16,6 +16,9 @@ package cli
 
 import (
 	"strings"
+
+	"github.com/crmarques/declarest/internal/cli/cliutil"
+	"github.com/spf13/cobra"
 )
 
 type Invocation struct {
@@ -23,6 +26,8 @@ type Invocation struct {
 	ParentCommandName        string
 	PositionalArgs           []string
 	RequiresContextBootstrap bool
+	RecordHTTP               string
+	ReplayHTTP               string
 }
 
 func ResolveRunnableInvocation(args []string) (Invocation, bool) {
@@ -50,5 +55,15 @@ func ResolveRunnableInvocation(args []string) (Invocation, bool) {
 		ParentCommandName:        parentName,
 		PositionalArgs:           positionalArgs,
 		RequiresContextBootstrap: RequiresContextBootstrap(command),
+		RecordHTTP:               stringFlagValue(command, cliutil.GlobalFlagRecordHTTP),
+		ReplayHTTP:               stringFlagValue(command, cliutil.GlobalFlagReplayHTTP),
 	}, true
 }
+
+func stringFlagValue(command *cobra.Command, name string) string {
+	value, err := command.Flags().GetString(name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
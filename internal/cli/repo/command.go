@@ -18,14 +18,18 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	configdomain "github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
 	"github.com/crmarques/declarest/internal/cli/cliutil"
 	"github.com/crmarques/declarest/internal/cli/commandmeta"
+	"github.com/crmarques/declarest/metadata"
 	"github.com/crmarques/declarest/repository"
+	"github.com/crmarques/declarest/resource"
 	"github.com/spf13/cobra"
 )
 
@@ -47,12 +51,14 @@ func NewCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFla
 	commitCommand := newCommitCommand(deps, globalFlags)
 	statusCommand := newStatusCommand(deps, globalFlags)
 	treeCommand := newTreeCommand(deps, globalFlags)
+	normalizeCommand := newNormalizeCommand(deps, globalFlags)
 
 	commandmeta.MarkTextDefaultStructuredOutput(historyCommand)
 	commandmeta.MarkEmitsExecutionStatus(commitCommand)
 	commandmeta.MarkTextDefaultStructuredOutput(commitCommand)
 	commandmeta.MarkTextDefaultStructuredOutput(statusCommand)
 	commandmeta.MarkTextOnlyOutput(treeCommand)
+	commandmeta.MarkTextDefaultStructuredOutput(normalizeCommand)
 
 	command.AddCommand(
 		initCommand,
@@ -65,6 +71,7 @@ func NewCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFla
 		statusCommand,
 		treeCommand,
 		historyCommand,
+		normalizeCommand,
 	)
 
 	return command
@@ -380,6 +387,152 @@ func newTreeCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Globa
 	}
 }
 
+func newNormalizeCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFlags) *cobra.Command {
+	var pathFlag string
+	var check bool
+
+	command := &cobra.Command{
+		Use:   "normalize [path]",
+		Short: "Rewrite repository resource files in canonical form",
+		Long: strings.Join([]string{
+			"Re-encode every resource payload file under a path (default /) with the repository.serialization policy of the active context,",
+			"ordering arrays declared in resource.arrayAttributes metadata.",
+			"Use --check to list files that are not canonical without rewriting them and exit with a conflict error (exit code 5) when any would change.",
+		}, " "),
+		Example: strings.Join([]string{
+			"  declarest repository normalize",
+			"  declarest repository normalize /admin/realms",
+			"  declarest repository normalize --check",
+		}, "\n"),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			resolvedPath, err := cliutil.ResolvePathInput(pathFlag, args, false)
+			if err != nil {
+				return err
+			}
+			if resolvedPath == "" {
+				resolvedPath = "/"
+			}
+
+			store, err := cliutil.RequireResourceStore(deps)
+			if err != nil {
+				return err
+			}
+			normalizer, err := requireResourceNormalizer(deps)
+			if err != nil {
+				return err
+			}
+
+			output, err := normalizeRepositoryResources(
+				command.Context(),
+				store,
+				normalizer,
+				deps.MetadataService(),
+				resolvedPath,
+				check,
+			)
+			if err != nil {
+				return err
+			}
+
+			format := cliutil.ResolveCommandOutputFormat(command, globalFlags)
+			if err := cliutil.WriteOutput(command, format, output, renderRepoNormalizeText); err != nil {
+				return err
+			}
+			if check && len(output.Changed) > 0 {
+				return faults.Conflict(
+					fmt.Sprintf(
+						"%d of %d resource file(s) under %q are not in canonical form",
+						len(output.Changed),
+						output.Total,
+						output.Path,
+					),
+					nil,
+				)
+			}
+			return nil
+		},
+	}
+
+	cliutil.BindPathFlag(command, &pathFlag)
+	command.Flags().BoolVar(&check, "check", false, "report files that are not canonical without rewriting them")
+	cliutil.RegisterPathFlagCompletion(command, deps)
+	command.ValidArgsFunction = cliutil.SinglePathArgCompletionFunc(deps)
+	return command
+}
+
+// normalizeRepositoryResources rewrites every resource under logicalPath,
+// sorting metadata-declared arrays first when a resolver is available.
+func normalizeRepositoryResources(
+	ctx context.Context,
+	store repository.ResourceStore,
+	normalizer repository.ResourceNormalizer,
+	resolver metadata.MetadataResolver,
+	logicalPath string,
+	check bool,
+) (repoNormalizeOutput, error) {
+	normalizedPath, err := resource.NormalizeLogicalPath(logicalPath)
+	if err != nil {
+		return repoNormalizeOutput{}, err
+	}
+
+	items, err := store.List(ctx, normalizedPath, repository.ListPolicy{Recursive: true})
+	if err != nil && !faults.IsCategory(err, faults.NotFoundError) {
+		return repoNormalizeOutput{}, err
+	}
+	paths := make([]string, 0, len(items)+1)
+	for _, item := range items {
+		paths = append(paths, item.LogicalPath)
+	}
+	if normalizedPath != "/" {
+		exists, err := store.Exists(ctx, normalizedPath)
+		if err != nil {
+			return repoNormalizeOutput{}, err
+		}
+		if exists && !slices.Contains(paths, normalizedPath) {
+			paths = append(paths, normalizedPath)
+		}
+	}
+	sort.Strings(paths)
+
+	output := repoNormalizeOutput{
+		Path:    normalizedPath,
+		Check:   check,
+		Total:   len(paths),
+		Changed: []string{},
+	}
+	for _, itemPath := range paths {
+		var transform func(resource.Value) (resource.Value, error)
+		if resolver != nil {
+			md, err := resolver.ResolveForPath(ctx, itemPath)
+			if err != nil {
+				return repoNormalizeOutput{}, err
+			}
+			if len(md.ArrayAttributes) > 0 {
+				transform = func(value resource.Value) (resource.Value, error) {
+					return metadata.SortArrayAttributes(value, md.ArrayAttributes)
+				}
+			}
+		}
+
+		changed, err := normalizer.NormalizeResource(ctx, itemPath, transform, check)
+		if err != nil {
+			return repoNormalizeOutput{}, err
+		}
+		if changed {
+			output.Changed = append(output.Changed, itemPath)
+		}
+	}
+	return output, nil
+}
+
+type repoNormalizeOutput struct {
+	Path    string   `json:"path" yaml:"path"`
+	Check   bool     `json:"check" yaml:"check"`
+	Total   int      `json:"total" yaml:"total"`
+	Changed []string `json:"changed" yaml:"changed"`
+}
+
 type repoStatusOutput struct {
 	State          repository.SyncState             `json:"state" yaml:"state"`
 	Ahead          int                              `json:"ahead" yaml:"ahead"`
@@ -428,6 +581,18 @@ func requireRepositoryCommitter(deps cliutil.CommandDependencies) (repository.Re
 	return nil, cliutil.ValidationError("git repository commit capability is not available", nil)
 }
 
+func requireResourceNormalizer(deps cliutil.CommandDependencies) (repository.ResourceNormalizer, error) {
+	if deps.Services != nil {
+		if candidate, ok := deps.Services.RepositoryStore().(repository.ResourceNormalizer); ok {
+			return candidate, nil
+		}
+		if candidate, ok := deps.Services.RepositorySync().(repository.ResourceNormalizer); ok {
+			return candidate, nil
+		}
+	}
+	return nil, cliutil.ValidationError("repository normalize is not supported by the active repository provider", nil)
+}
+
 func requireRepositoryTreeReader(deps cliutil.CommandDependencies) (repository.RepositoryTreeReader, bool) {
 	if deps.Services != nil {
 		if candidate, ok := deps.Services.RepositorySync().(repository.RepositoryTreeReader); ok {
@@ -582,6 +747,20 @@ func renderRepoWorktreeStatusText(w io.Writer, entries []repository.WorktreeStat
 	return nil
 }
 
+func renderRepoNormalizeText(w io.Writer, value repoNormalizeOutput) error {
+	prefix, summary := "normalized", "rewritten"
+	if value.Check {
+		prefix, summary = "not-canonical", "not in canonical form"
+	}
+	for _, itemPath := range value.Changed {
+		if _, err := fmt.Fprintf(w, "%s %s\n", prefix, itemPath); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "normalize: %d of %d resource file(s) %s\n", len(value.Changed), value.Total, summary)
+	return err
+}
+
 func renderRepoCommitText(w io.Writer, value repoCommitOutput) error {
 	if value.Committed {
 		_, err := fmt.Fprintln(w, "committed=true")
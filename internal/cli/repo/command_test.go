@@ -14,7 +14,16 @@
 
 package repo
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/repository"
+	"github.com/crmarques/declarest/resource"
+)
 
 func TestRenderRepoTreeText(t *testing.T) {
 	t.Parallel()
@@ -44,3 +53,89 @@ func TestRenderRepoTreeText(t *testing.T) {
 		t.Fatalf("renderRepoTreeText() = %q, want %q", got, want)
 	}
 }
+
+type fakeNormalizeStore struct {
+	repository.ResourceStore
+	payloads map[string]resource.Value
+	written  map[string]resource.Value
+}
+
+func (f *fakeNormalizeStore) List(context.Context, string, repository.ListPolicy) ([]resource.Resource, error) {
+	items := make([]resource.Resource, 0, len(f.payloads))
+	for logicalPath := range f.payloads {
+		items = append(items, resource.Resource{LogicalPath: logicalPath})
+	}
+	return items, nil
+}
+
+func (f *fakeNormalizeStore) NormalizeResource(
+	_ context.Context,
+	logicalPath string,
+	transform func(resource.Value) (resource.Value, error),
+	dryRun bool,
+) (bool, error) {
+	value := f.payloads[logicalPath]
+	if transform == nil {
+		return false, nil
+	}
+	transformed, err := transform(value)
+	if err != nil {
+		return false, err
+	}
+	if reflect.DeepEqual(transformed, value) {
+		return false, nil
+	}
+	if !dryRun {
+		f.written[logicalPath] = transformed
+	}
+	return true, nil
+}
+
+type fakeNormalizeResolver struct{}
+
+func (fakeNormalizeResolver) ResolveForPath(context.Context, string) (metadata.ResourceMetadata, error) {
+	return metadata.ResourceMetadata{
+		ArrayAttributes: []metadata.ArrayAttribute{{Path: "/roles", SortBy: []string{""}}},
+	}, nil
+}
+
+func TestNormalizeRepositoryResources(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeNormalizeStore{
+		payloads: map[string]resource.Value{
+			"/customers/b": map[string]any{"roles": []any{"admin", "viewer"}},
+			"/customers/a": map[string]any{"roles": []any{"viewer", "admin"}},
+		},
+		written: map[string]resource.Value{},
+	}
+
+	output, err := normalizeRepositoryResources(context.Background(), store, store, fakeNormalizeResolver{}, "/", true)
+	if err != nil {
+		t.Fatalf("normalizeRepositoryResources returned error: %v", err)
+	}
+	want := repoNormalizeOutput{Path: "/", Check: true, Total: 2, Changed: []string{"/customers/a"}}
+	if !reflect.DeepEqual(output, want) {
+		t.Fatalf("unexpected normalize output: got %#v want %#v", output, want)
+	}
+	if len(store.written) != 0 {
+		t.Fatalf("expected check mode to leave files untouched, got %#v", store.written)
+	}
+
+	if _, err := normalizeRepositoryResources(context.Background(), store, store, fakeNormalizeResolver{}, "/", false); err != nil {
+		t.Fatalf("normalizeRepositoryResources returned error: %v", err)
+	}
+	wantWritten := map[string]resource.Value{"/customers/a": map[string]any{"roles": []any{"admin", "viewer"}}}
+	if !reflect.DeepEqual(store.written, wantWritten) {
+		t.Fatalf("unexpected rewritten payloads: got %#v want %#v", store.written, wantWritten)
+	}
+
+	var buffer bytes.Buffer
+	if err := renderRepoNormalizeText(&buffer, output); err != nil {
+		t.Fatalf("renderRepoNormalizeText returned error: %v", err)
+	}
+	wantText := "not-canonical /customers/a\nnormalize: 1 of 2 resource file(s) not in canonical form\n"
+	if buffer.String() != wantText {
+		t.Fatalf("renderRepoNormalizeText() = %q, want %q", buffer.String(), wantText)
+	}
+}
@@ -125,8 +125,11 @@ func NewCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFla
 	applyCommand := newApplyCommand(deps, globalFlags)
 	createCommand := newCreateCommand(deps, globalFlags)
 	updateCommand := newUpdateCommand(deps, globalFlags)
-	deleteCommand := newDeleteCommand(deps)
+	deleteCommand := newDeleteCommand(deps, globalFlags)
 	diffCommand := newDiffCommand(deps, globalFlags)
+	planCommand := newPlanCommand(deps, globalFlags)
+	pruneCommand := newPruneCommand(deps, globalFlags)
+	driftCommand := newDriftCommand(deps, globalFlags)
 	listCommand := newListCommand(deps, globalFlags)
 	editCommand := newEditCommand(deps, globalFlags)
 	copyCommand := newCopyCommand(deps, globalFlags)
@@ -145,6 +148,9 @@ func NewCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFla
 	commandmeta.MarkEmitsExecutionStatus(editCommand)
 	commandmeta.MarkEmitsExecutionStatus(copyCommand)
 	commandmeta.MarkTextDefaultStructuredOutput(diffCommand)
+	commandmeta.MarkTextDefaultStructuredOutput(planCommand)
+	commandmeta.MarkTextDefaultStructuredOutput(pruneCommand)
+	commandmeta.MarkTextDefaultStructuredOutput(driftCommand)
 
 	command.AddCommand(
 		getCommand,
@@ -154,6 +160,9 @@ func NewCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFla
 		updateCommand,
 		deleteCommand,
 		diffCommand,
+		planCommand,
+		pruneCommand,
+		driftCommand,
 		listCommand,
 		editCommand,
 		copyCommand,
@@ -31,6 +31,11 @@ func newApplyCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glob
 	var force bool
 	var httpMethod string
 	var refresh bool
+	var parallelism int
+	var continueOnError bool
+	var planFile string
+	var prune bool
+	var yes bool
 
 	command := &cobra.Command{
 		Use:   "apply [path]",
@@ -42,21 +47,38 @@ func newApplyCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glob
 			"When remote and desired state are equal after metadata compare transforms, apply skips updates unless --force is set.",
 			"This explicit-input mode is useful for direct remote operations when no repository is configured.",
 			"Use --refresh to fetch the remote state after each mutation and persist it back into the repository.",
+			"Use --parallelism N with collection targets to mutate up to N resources concurrently; parent collections are processed before their children.",
+			"Use --continue-on-error with collection targets to keep going after failures; a failure report is printed and the command exits with code 7 when any target failed.",
+			"Use --prune to also delete remote resources under the target that no longer exist in the repository; confirm interactively or pass --yes.",
+			"Use --plan <file> to execute a plan saved by `resource plan --out`; apply refuses to run when the local payload or remote state changed since the plan was made.",
 		}, " "),
 		Example: strings.Join([]string{
 			"  declarest resource apply /customers/acme",
 			"  declarest resource apply /customers/ --recursive",
+			"  declarest resource apply / --recursive --parallelism 8",
 			"  declarest resource apply /customers/acme --payload payload.json",
 			"  cat payload.json | declarest resource apply /customers/acme --payload -",
 			"  declarest resource apply /customers/acme --force",
 			"  declarest resource apply /customers/acme --refresh",
+			"  declarest resource apply /customers/ --recursive --prune --yes",
+			"  declarest resource apply --plan plan.json",
 		}, "\n"),
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(command *cobra.Command, args []string) error {
+			if strings.TrimSpace(planFile) != "" {
+				return runPlannedApply(command, deps, globalFlags, planFile, pathFlag, args)
+			}
+
 			resolvedPath, err := cliutil.ResolvePathInput(pathFlag, args, true)
 			if err != nil {
 				return err
 			}
+			if err := validateParallelismFlag(parallelism); err != nil {
+				return err
+			}
+			if prune && !yes && !cliutil.IsInteractiveTerminal(command) {
+				return cliutil.ValidationError(pruneConfirmationRequiredMessage, nil)
+			}
 
 			runCtx, _, err := applyHTTPMethodOverride(
 				command.Context(),
@@ -72,6 +94,9 @@ func newApplyCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glob
 			if err != nil {
 				return err
 			}
+			if prune && hasExplicitInput {
+				return cliutil.ValidationError("flag --prune cannot be combined with explicit input; remove input to prune against repository resources", nil)
+			}
 
 			mutationPath := resolvedPath
 			if hasExplicitInput {
@@ -95,10 +120,20 @@ func newApplyCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glob
 				Value:            value,
 				HasExplicitInput: hasExplicitInput,
 				RefreshLocal:     refresh,
+				Parallelism:      parallelism,
+				ContinueOnError:  continueOnError,
 			})
 			if err != nil {
 				return err
 			}
+			if len(result.Failures) > 0 {
+				return writeMutationFailureReport(command, globalFlags, string(mutateapp.OperationApply), result.TargetedCount, result.Failures)
+			}
+			if prune {
+				if err := runApplyPrune(command, deps, globalFlags, mutationPath, recursive, yes, continueOnError); err != nil {
+					return err
+				}
+			}
 
 			if !cliutil.IsVerbose(globalFlags) {
 				return nil
@@ -123,6 +158,11 @@ func newApplyCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glob
 	command.Flags().BoolVarP(&recursive, "recursive", "r", false, "walk collection recursively")
 	command.Flags().BoolVar(&force, "force", false, "force update even when compare output has no drift")
 	command.Flags().BoolVar(&refresh, "refresh", false, "re-fetch remote mutation results into the repository")
+	bindParallelismFlag(command, &parallelism)
+	bindContinueOnErrorFlag(command, &continueOnError)
 	bindHTTPMethodFlag(command, &httpMethod)
+	command.Flags().BoolVar(&prune, "prune", false, "delete remote resources under the target that are missing from the repository")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "confirm prune deletions")
+	command.Flags().StringVar(&planFile, "plan", "", "execute a plan file written by resource plan --out")
 	return command
 }
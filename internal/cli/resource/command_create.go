@@ -30,6 +30,8 @@ func newCreateCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glo
 	var recursive bool
 	var httpMethod string
 	var refresh bool
+	var parallelism int
+	var continueOnError bool
 
 	command := &cobra.Command{
 		Use:   "create [path]",
@@ -39,6 +41,8 @@ func newCreateCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glo
 			"When --payload <path|-> or stdin is provided, the explicit payload overrides repository input for a single target path.",
 			"This explicit-input mode is useful for direct remote operations when no repository is configured.",
 			"Use --refresh to fetch the remote state after the create and persist it locally.",
+			"Use --parallelism N with collection targets to mutate up to N resources concurrently; parent collections are processed before their children.",
+			"Use --continue-on-error with collection targets to keep going after failures; a failure report is printed and the command exits with code 7 when any target failed.",
 		}, " "),
 		Example: strings.Join([]string{
 			"  declarest resource create /customers/acme",
@@ -53,6 +57,9 @@ func newCreateCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glo
 			if err != nil {
 				return err
 			}
+			if err := validateParallelismFlag(parallelism); err != nil {
+				return err
+			}
 
 			runCtx, _, err := applyHTTPMethodOverride(command.Context(), httpMethod, metadata.OperationCreate)
 			if err != nil {
@@ -85,10 +92,15 @@ func newCreateCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glo
 				Value:            value,
 				HasExplicitInput: hasExplicitInput,
 				RefreshLocal:     refresh,
+				Parallelism:      parallelism,
+				ContinueOnError:  continueOnError,
 			})
 			if err != nil {
 				return err
 			}
+			if len(result.Failures) > 0 {
+				return writeMutationFailureReport(command, globalFlags, string(mutateapp.OperationCreate), result.TargetedCount, result.Failures)
+			}
 
 			if !cliutil.IsVerbose(globalFlags) {
 				return nil
@@ -111,6 +123,8 @@ func newCreateCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.Glo
 	}
 	command.Flags().BoolVarP(&recursive, "recursive", "r", false, "walk collection recursively")
 	command.Flags().BoolVar(&refresh, "refresh", false, "re-fetch remote mutation results into the repository")
+	bindParallelismFlag(command, &parallelism)
+	bindContinueOnErrorFlag(command, &continueOnError)
 	bindHTTPMethodFlag(command, &httpMethod)
 	return command
 }
@@ -15,6 +15,7 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -24,23 +25,31 @@ import (
 	"github.com/crmarques/declarest/metadata"
 	orchestratordomain "github.com/crmarques/declarest/orchestrator"
 	"github.com/crmarques/declarest/repository"
+	"github.com/crmarques/declarest/resource"
 	"github.com/spf13/cobra"
 )
 
-func newDeleteCommand(deps cliutil.CommandDependencies) *cobra.Command {
+func newDeleteCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFlags) *cobra.Command {
 	var pathFlag string
 	var sourceFlag string
 	var yes bool
 	var recursive bool
 	var httpMethod string
 	var commitMessage string
+	var continueOnError bool
 
 	command := &cobra.Command{
 		Use:   "delete [path]",
 		Short: "Delete a resource",
+		Long: strings.Join([]string{
+			"Delete a resource from the managed service, the repository, or both.",
+			"Use --continue-on-error with --recursive to keep deleting remaining remote targets after failures;",
+			"a failure report is printed, repository deletion is skipped, and the command exits with code 7 when any target failed.",
+		}, " "),
 		Example: strings.Join([]string{
 			"  declarest resource delete /customers/acme --yes",
 			"  declarest resource delete /customers/ --recursive --yes",
+			"  declarest resource delete /customers/ --recursive --continue-on-error --yes",
 			"  declarest resource delete /customers/acme --source repository --yes",
 			"  declarest resource delete /customers/acme --source both --yes",
 		}, "\n"),
@@ -106,13 +115,28 @@ func newDeleteCommand(deps cliutil.CommandDependencies) *cobra.Command {
 					return err
 				}
 
-				for _, target := range targets {
+				order, err := orchestratorService.OrderByDependencies(runCtx, targets)
+				if err != nil {
+					return err
+				}
+				order = order.Reverse()
+
+				deleteTarget := func(ctx context.Context, _ int, target resource.Resource) error {
 					policy := orchestratordomain.DeletePolicy{
 						Recursive: recursive && target.LogicalPath == resolvedPath,
 					}
-					if err := orchestratorService.Delete(runCtx, target.LogicalPath, policy); err != nil {
+					return orchestratorService.Delete(ctx, target.LogicalPath, policy)
+				}
+				if continueOnError {
+					failures, err := mutateapp.RunTargetsCollectingFailures(runCtx, order, 1, deleteTarget)
+					if err != nil {
 						return err
 					}
+					if len(failures) > 0 {
+						return writeMutationFailureReport(command, globalFlags, "delete", len(targets), failures)
+					}
+				} else if err := mutateapp.RunTargets(runCtx, order, 1, deleteTarget); err != nil {
+					return err
 				}
 			}
 
@@ -136,6 +160,7 @@ func newDeleteCommand(deps cliutil.CommandDependencies) *cobra.Command {
 	command.ValidArgsFunction = cliutil.SinglePathArgCompletionFunc(deps)
 	command.Flags().BoolVarP(&yes, "yes", "y", false, "confirm deletion")
 	command.Flags().BoolVarP(&recursive, "recursive", "r", false, "delete recursively")
+	command.Flags().BoolVar(&continueOnError, "continue-on-error", false, "keep deleting remaining remote targets after a failure and report all failures")
 	bindDeleteSourceFlags(command, &sourceFlag)
 	bindHTTPMethodFlag(command, &httpMethod)
 	bindRepositoryCommitMessageFlags(command, &commitMessage)
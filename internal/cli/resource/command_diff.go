@@ -185,6 +185,15 @@ func sortDiffEntries(items []resource.DiffEntry) {
 }
 
 func renderDiffTextLine(basePath string, entry resource.DiffEntry) (string, error) {
+	if entry.Operation == "move" {
+		return fmt.Sprintf(
+			"%s [Moved] Local index %v => Remote index %v",
+			formatDiffTextPath(basePath, entry),
+			entry.Local,
+			entry.Remote,
+		), nil
+	}
+
 	local, err := marshalDiffTextValue(entry.Local)
 	if err != nil {
 		return "", err
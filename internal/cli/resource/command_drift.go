@@ -0,0 +1,183 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	driftapp "github.com/crmarques/declarest/internal/app/resource/drift"
+	"github.com/crmarques/declarest/internal/cli/cliutil"
+	"github.com/spf13/cobra"
+)
+
+func newDriftCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFlags) *cobra.Command {
+	var pathFlag string
+	var recursive bool
+	var junitFile string
+	var failOnDrift bool
+
+	command := &cobra.Command{
+		Use:   "drift [path]",
+		Short: "Report which resources drifted between the repository and the managed service",
+		Long: strings.Join([]string{
+			"Classify every local resource and every remote resource listed under a path as in-sync, modified, missing-remote, or remote-only, using the compare transforms from metadata.",
+			"Text output lists drifted paths and a summary; use -o json|yaml for the full report and --junit to write a JUnit XML report for CI.",
+			"Use --fail-on-drift to exit with a conflict error (exit code 5) when any path drifted.",
+		}, " "),
+		Example: strings.Join([]string{
+			"  declarest resource drift /customers",
+			"  declarest resource drift / --recursive -o json",
+			"  declarest resource drift / --recursive --junit drift.xml --fail-on-drift",
+		}, "\n"),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			resolvedPath, err := cliutil.ResolvePathInput(pathFlag, args, true)
+			if err != nil {
+				return err
+			}
+			outputFormat := cliutil.ResolveCommandOutputFormat(command, globalFlags)
+
+			report, err := driftapp.Build(command.Context(), deps, driftapp.Request{
+				LogicalPath: resolvedPath,
+				Recursive:   recursive,
+			})
+			if err != nil {
+				return err
+			}
+
+			if strings.TrimSpace(junitFile) != "" {
+				if err := writeDriftJUnitFile(junitFile, report); err != nil {
+					return err
+				}
+			}
+			if err := cliutil.WriteOutput(command, outputFormat, report, renderDriftText); err != nil {
+				return err
+			}
+			if failOnDrift {
+				return driftapp.DetectedError(report)
+			}
+			return nil
+		},
+	}
+
+	cliutil.BindPathFlag(command, &pathFlag)
+	command.Flags().BoolVarP(&recursive, "recursive", "r", false, "walk collection recursively")
+	command.Flags().StringVar(&junitFile, "junit", "", "write a JUnit XML drift report to this file")
+	command.Flags().BoolVar(&failOnDrift, "fail-on-drift", false, "exit with a conflict error when any path drifted")
+	cliutil.RegisterPathFlagCompletion(command, deps)
+	command.ValidArgsFunction = cliutil.SinglePathArgCompletionFunc(deps)
+	return command
+}
+
+func renderDriftText(w io.Writer, report driftapp.Report) error {
+	drifted := report.Drifted()
+	if len(drifted) > 0 {
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if _, err := fmt.Fprintln(table, "STATUS\tPATH"); err != nil {
+			return err
+		}
+		for _, entry := range drifted {
+			if _, err := fmt.Fprintf(table, "%s\t%s\n", entry.Status, entry.LogicalPath); err != nil {
+				return err
+			}
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		w,
+		"drift: %d in-sync, %d modified, %d missing-remote, %d remote-only\n",
+		report.Summary.InSync,
+		report.Summary.Modified,
+		report.Summary.MissingRemote,
+		report.Summary.RemoteOnly,
+	)
+	return err
+}
+
+type driftJUnitSuite struct {
+	XMLName  xml.Name         `xml:"testsuite"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Cases    []driftJUnitCase `xml:"testcase"`
+}
+
+type driftJUnitCase struct {
+	ClassName string             `xml:"classname,attr"`
+	Name      string             `xml:"name,attr"`
+	Failure   *driftJUnitFailure `xml:"failure,omitempty"`
+}
+
+type driftJUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// buildDriftJUnitSuite maps each report entry to one test case; drifted
+// entries fail with their status as the failure type.
+func buildDriftJUnitSuite(report driftapp.Report) (driftJUnitSuite, error) {
+	suite := driftJUnitSuite{
+		Name:     "declarest drift " + report.Path,
+		Tests:    report.Summary.Total,
+		Failures: report.Summary.Drifted(),
+		Cases:    make([]driftJUnitCase, 0, len(report.Entries)),
+	}
+	for _, entry := range report.Entries {
+		testCase := driftJUnitCase{ClassName: "declarest.drift", Name: entry.LogicalPath}
+		if entry.Status != driftapp.StatusInSync {
+			lines := make([]string, 0, len(entry.Diff))
+			for _, diffEntry := range entry.Diff {
+				line, err := renderDiffTextLine(entry.LogicalPath, diffEntry)
+				if err != nil {
+					return driftJUnitSuite{}, err
+				}
+				lines = append(lines, line)
+			}
+			testCase.Failure = &driftJUnitFailure{
+				Message: fmt.Sprintf("%s is %s", entry.LogicalPath, entry.Status),
+				Type:    string(entry.Status),
+				Body:    strings.Join(lines, "\n"),
+			}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	return suite, nil
+}
+
+func writeDriftJUnitFile(path string, report driftapp.Report) error {
+	suite, err := buildDriftJUnitSuite(report)
+	if err != nil {
+		return err
+	}
+	encoded, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	content := append([]byte(xml.Header), encoded...)
+	if err := os.WriteFile(path, append(content, '\n'), 0o600); err != nil {
+		return cliutil.ValidationError(fmt.Sprintf("failed to write JUnit report %q", path), err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	resourceplanapp "github.com/crmarques/declarest/internal/app/resource/plan"
+	"github.com/crmarques/declarest/internal/cli/cliutil"
+	"github.com/spf13/cobra"
+)
+
+func newPlanCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFlags) *cobra.Command {
+	var pathFlag string
+	var recursive bool
+	var prune bool
+	var outFile string
+
+	command := &cobra.Command{
+		Use:   "plan [path]",
+		Short: "Show what apply would change without mutating remote state",
+		Long: strings.Join([]string{
+			"Compute, per resource, the action apply would take (create, update, no-op) together with the rendered HTTP method, remote path, and payload diff.",
+			"Use --prune to also plan deletes for remote resources that no longer exist in the repository.",
+			"Use --out to save the plan as JSON; `resource apply --plan <file>` executes it and refuses to run when the local payload or remote state changed since the plan was made.",
+		}, " "),
+		Example: strings.Join([]string{
+			"  declarest resource plan /customers/acme",
+			"  declarest resource plan /customers --recursive",
+			"  declarest resource plan /customers --recursive --prune --out plan.json",
+			"  declarest resource plan /customers --recursive -o json",
+		}, "\n"),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			resolvedPath, err := cliutil.ResolvePathInput(pathFlag, args, true)
+			if err != nil {
+				return err
+			}
+			outputFormat := cliutil.ResolveCommandOutputFormat(command, globalFlags)
+
+			planned, err := resourceplanapp.Build(command.Context(), deps, resourceplanapp.Request{
+				LogicalPath: resolvedPath,
+				Recursive:   recursive,
+				Prune:       prune,
+			})
+			if err != nil {
+				return err
+			}
+
+			if strings.TrimSpace(outFile) != "" {
+				if err := writePlanFile(outFile, planned); err != nil {
+					return err
+				}
+			}
+
+			return cliutil.WriteOutput(command, outputFormat, planned, renderPlanText)
+		},
+	}
+
+	cliutil.BindPathFlag(command, &pathFlag)
+	command.Flags().BoolVarP(&recursive, "recursive", "r", false, "walk collection recursively")
+	command.Flags().BoolVar(&prune, "prune", false, "plan deletes for remote resources missing from the repository")
+	command.Flags().StringVar(&outFile, "out", "", "write the plan as JSON to this file for resource apply --plan")
+	cliutil.RegisterPathFlagCompletion(command, deps)
+	command.ValidArgsFunction = cliutil.SinglePathArgCompletionFunc(deps)
+	return command
+}
+
+func writePlanFile(path string, planned resourceplanapp.Plan) error {
+	encoded, err := json.MarshalIndent(planned, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(encoded, '\n'), 0o600); err != nil {
+		return cliutil.ValidationError(fmt.Sprintf("failed to write plan file %q", path), err)
+	}
+	return nil
+}
+
+func readPlanFile(path string) (resourceplanapp.Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return resourceplanapp.Plan{}, cliutil.ValidationError(fmt.Sprintf("failed to read plan file %q", path), err)
+	}
+
+	var planned resourceplanapp.Plan
+	if err := json.Unmarshal(data, &planned); err != nil {
+		return resourceplanapp.Plan{}, cliutil.ValidationError(fmt.Sprintf("invalid plan file %q", path), err)
+	}
+	return planned, nil
+}
+
+func renderPlanText(w io.Writer, planned resourceplanapp.Plan) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(table, "ACTION\tPATH\tMETHOD\tREMOTE PATH"); err != nil {
+		return err
+	}
+	for _, item := range planned.Items {
+		if _, err := fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", item.Action, item.LogicalPath, item.Method, item.RemotePath); err != nil {
+			return err
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, item := range planned.Items {
+		if len(item.Diff) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n%s %s\n", item.Action, item.LogicalPath); err != nil {
+			return err
+		}
+		for _, entry := range item.Diff {
+			line, err := renderDiffTextLine(item.LogicalPath, entry)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "  %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+
+	counts := planned.Counts()
+	_, err := fmt.Fprintf(
+		w,
+		"\nplan: %d to create, %d to update, %d to delete, %d unchanged\n",
+		counts[resourceplanapp.ActionCreate],
+		counts[resourceplanapp.ActionUpdate],
+		counts[resourceplanapp.ActionDelete],
+		counts[resourceplanapp.ActionNoop],
+	)
+	return err
+}
+
+var plannedApplyExclusiveFlags = []string{
+	"recursive",
+	"force",
+	"refresh",
+	"parallelism",
+	"continue-on-error",
+	"prune",
+	"http-method",
+	"payload",
+}
+
+// runPlannedApply executes a saved plan for `resource apply --plan`. The plan
+// fully determines targets and actions, so path and mutation flags are
+// rejected.
+func runPlannedApply(
+	command *cobra.Command,
+	deps cliutil.CommandDependencies,
+	globalFlags *cliutil.GlobalFlags,
+	planFile string,
+	pathFlag string,
+	args []string,
+) error {
+	if strings.TrimSpace(pathFlag) != "" || len(args) > 0 {
+		return cliutil.ValidationError("flag --plan cannot be combined with a path; the plan defines its targets", nil)
+	}
+	for _, name := range plannedApplyExclusiveFlags {
+		if flag := command.Flags().Lookup(name); flag != nil && flag.Changed {
+			return cliutil.ValidationError(fmt.Sprintf("flag --plan cannot be combined with --%s", name), nil)
+		}
+	}
+
+	planned, err := readPlanFile(planFile)
+	if err != nil {
+		return err
+	}
+	result, err := resourceplanapp.Execute(command.Context(), deps, planned)
+	if err != nil {
+		return err
+	}
+
+	if !cliutil.IsVerbose(globalFlags) {
+		return nil
+	}
+	outputFormat, err := cliutil.ResolveContextOutputFormat(command.Context(), deps, globalFlags)
+	if err != nil {
+		return err
+	}
+	return writeCollectionMutationOutput(command, outputFormat, planned.Path, result.Items)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	pruneapp "github.com/crmarques/declarest/internal/app/resource/prune"
+	"github.com/crmarques/declarest/internal/cli/cliutil"
+	"github.com/spf13/cobra"
+)
+
+var prunePromptConfirm = cliutil.PromptConfirm
+
+const pruneConfirmationRequiredMessage = "flag --yes is required: confirm deletion of remote resources missing from the repository"
+
+type pruneReport struct {
+	Path    string   `json:"path" yaml:"path"`
+	DryRun  bool     `json:"dryRun" yaml:"dryRun"`
+	Deleted []string `json:"deleted" yaml:"deleted"`
+	Skipped []string `json:"skipped" yaml:"skipped"`
+}
+
+func newPruneCommand(deps cliutil.CommandDependencies, globalFlags *cliutil.GlobalFlags) *cobra.Command {
+	var pathFlag string
+	var recursive bool
+	var yes bool
+	var dryRun bool
+	var continueOnError bool
+
+	command := &cobra.Command{
+		Use:   "prune [collection]",
+		Short: "Delete remote resources that no longer exist in the repository",
+		Long: strings.Join([]string{
+			"List remote resources under a collection, compare them with the repository, and delete remote-only resources.",
+			"Resources whose metadata sets resource.prune to false are never deleted and are reported as skipped.",
+			"Use --dry-run to preview the deletions; otherwise confirm interactively or pass --yes.",
+		}, " "),
+		Example: strings.Join([]string{
+			"  declarest resource prune /customers --dry-run",
+			"  declarest resource prune /customers --recursive --yes",
+			"  declarest resource prune /customers --recursive --continue-on-error --yes",
+		}, "\n"),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			resolvedPath, err := cliutil.ResolvePathInput(pathFlag, args, true)
+			if err != nil {
+				return err
+			}
+			outputFormat := cliutil.ResolveCommandOutputFormat(command, globalFlags)
+
+			candidates, err := pruneapp.ListCandidates(command.Context(), deps, resolvedPath, recursive)
+			if err != nil {
+				return err
+			}
+			report := pruneReport{
+				Path:    resolvedPath,
+				DryRun:  dryRun,
+				Deleted: candidates.Paths(),
+				Skipped: candidates.Skipped,
+			}
+			if dryRun || len(report.Deleted) == 0 {
+				return cliutil.WriteOutput(command, outputFormat, report, renderPruneReport)
+			}
+
+			if err := confirmPrune(command, yes, report.Deleted); err != nil {
+				return err
+			}
+			result, err := pruneapp.Execute(command.Context(), deps, candidates, continueOnError)
+			if err != nil {
+				return err
+			}
+			if len(result.Failures) > 0 {
+				return writeMutationFailureReport(command, globalFlags, "prune", len(report.Deleted), result.Failures)
+			}
+
+			report.Deleted = result.Deleted
+			return cliutil.WriteOutput(command, outputFormat, report, renderPruneReport)
+		},
+	}
+
+	cliutil.BindPathFlag(command, &pathFlag)
+	cliutil.RegisterPathFlagCompletion(command, deps)
+	command.ValidArgsFunction = cliutil.SinglePathArgCompletionFunc(deps)
+	command.Flags().BoolVarP(&recursive, "recursive", "r", false, "compare descendant collections recursively")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "confirm deletion")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "only print the remote resources that would be deleted")
+	bindContinueOnErrorFlag(command, &continueOnError)
+	return command
+}
+
+// confirmPrune asks for interactive confirmation unless --yes was passed.
+func confirmPrune(command *cobra.Command, yes bool, paths []string) error {
+	if yes || len(paths) == 0 {
+		return nil
+	}
+	if !cliutil.IsInteractiveTerminal(command) {
+		return cliutil.ValidationError(pruneConfirmationRequiredMessage, nil)
+	}
+
+	confirmed, err := prunePromptConfirm(
+		command,
+		fmt.Sprintf("Delete %d remote resource(s) missing from the repository: %s?", len(paths), strings.Join(paths, ", ")),
+		false,
+	)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return cliutil.ValidationError("prune aborted", nil)
+	}
+	return nil
+}
+
+func renderPruneReport(w io.Writer, report pruneReport) error {
+	verb := "delete"
+	if report.DryRun {
+		verb = "would delete"
+	}
+	for _, logicalPath := range report.Deleted {
+		if _, err := fmt.Fprintf(w, "%s %s\n", verb, logicalPath); err != nil {
+			return err
+		}
+	}
+	for _, logicalPath := range report.Skipped {
+		if _, err := fmt.Fprintf(w, "skip %s (resource.prune=false)\n", logicalPath); err != nil {
+			return err
+		}
+	}
+
+	summary := "prune: %d deleted, %d skipped\n"
+	if report.DryRun {
+		summary = "prune (dry run): %d to delete, %d skipped\n"
+	}
+	_, err := fmt.Fprintf(w, summary, len(report.Deleted), len(report.Skipped))
+	return err
+}
+
+// runApplyPrune deletes remote-only resources after a successful
+// `resource apply --prune`.
+func runApplyPrune(
+	command *cobra.Command,
+	deps cliutil.CommandDependencies,
+	globalFlags *cliutil.GlobalFlags,
+	logicalPath string,
+	recursive bool,
+	yes bool,
+	continueOnError bool,
+) error {
+	candidates, err := pruneapp.ListCandidates(command.Context(), deps, logicalPath, recursive)
+	if err != nil {
+		return err
+	}
+	paths := candidates.Paths()
+	if len(paths) == 0 {
+		return nil
+	}
+	if err := confirmPrune(command, yes, paths); err != nil {
+		return err
+	}
+
+	result, err := pruneapp.Execute(command.Context(), deps, candidates, continueOnError)
+	if err != nil {
+		return err
+	}
+	if len(result.Failures) > 0 {
+		return writeMutationFailureReport(command, globalFlags, "prune", len(paths), result.Failures)
+	}
+	return nil
+}
@@ -149,3 +149,51 @@ func TestRenderDiffReportTextColorAlwaysAddsANSI(t *testing.T) {
 		t.Fatalf("expected colored add/remove lines, got %q", rendered)
 	}
 }
+
+func TestRenderDiffTextLineRendersMovesAsIndexChanges(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		entry resource.DiffEntry
+		want  string
+	}{
+		{
+			name: "replace",
+			entry: resource.DiffEntry{
+				ResourcePath: "/clients/web",
+				Path:         "/protocolMappers/0/claim",
+				Operation:    "replace",
+				Local:        "mail",
+				Remote:       "email",
+			},
+			want: `.protocolMappers.0.claim [Local="mail"] => [Remote="email"]`,
+		},
+		{
+			name: "move",
+			entry: resource.DiffEntry{
+				ResourcePath: "/clients/web",
+				Path:         "/protocolMappers/0",
+				Operation:    "move",
+				Local:        int64(0),
+				Remote:       int64(2),
+			},
+			want: `.protocolMappers.0 [Moved] Local index 0 => Remote index 2`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			line, err := renderDiffTextLine("/clients/web", tc.entry)
+			if err != nil {
+				t.Fatalf("renderDiffTextLine returned error: %v", err)
+			}
+			if line != tc.want {
+				t.Fatalf("unexpected line: got %q want %q", line, tc.want)
+			}
+		})
+	}
+}
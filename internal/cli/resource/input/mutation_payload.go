@@ -59,7 +59,7 @@ func DecodeOptionalMutationPayloadInput(
 		if objectValue, err := cliutil.ParsePointerAssignmentsObject(payloadArg); err == nil {
 			payloadType := assignmentPayloadType(flags.ContentType)
 			return resource.Content{
-				Value:      objectValue,
+				Value:      assignmentPayloadValue(objectValue, payloadType),
 				Descriptor: resource.NormalizePayloadDescriptor(resource.PayloadDescriptor{PayloadType: payloadType}),
 			}, true, nil
 		}
@@ -71,7 +71,7 @@ func DecodeOptionalMutationPayloadInput(
 			}
 			payloadType := assignmentPayloadType(flags.ContentType)
 			return resource.Content{
-				Value:      objectValue,
+				Value:      assignmentPayloadValue(objectValue, payloadType),
 				Descriptor: resource.NormalizePayloadDescriptor(resource.PayloadDescriptor{PayloadType: payloadType}),
 			}, true, nil
 		}
@@ -137,6 +137,17 @@ func assignmentPayloadType(contentType string) string {
 	return descriptor.PayloadType
 }
 
+// assignmentPayloadValue wraps an assignment object as the single record of
+// record-list payload types such as csv and ndjson.
+func assignmentPayloadValue(objectValue map[string]any, payloadType string) resource.Value {
+	switch payloadType {
+	case resource.PayloadTypeCSV, resource.PayloadTypeNDJSON:
+		return []any{objectValue}
+	default:
+		return objectValue
+	}
+}
+
 func allowsStructuredAssignmentInput(contentType string) (bool, error) {
 	trimmed := strings.TrimSpace(contentType)
 	if trimmed == "" {
@@ -146,7 +157,7 @@ func allowsStructuredAssignmentInput(contentType string) (bool, error) {
 	descriptor, ok := resource.PayloadDescriptorForContentType(trimmed)
 	if !ok {
 		return false, cliutil.ValidationError(
-			"invalid input content type: use json, yaml, xml, hcl, ini, properties, text, txt, binary, or a supported media type",
+			"invalid input content type: use json, yaml, xml, hcl, ini, properties, toml, csv, ndjson, text, txt, binary, or a supported media type",
 			nil,
 		)
 	}
@@ -215,6 +215,30 @@ func TestDecodeOptionalMutationPayloadInputPointerAssignmentsRespectStructuredCo
 	}
 }
 
+func TestDecodeOptionalMutationPayloadInputAssignmentsBecomeOneRecordForRecordLists(t *testing.T) {
+	t.Parallel()
+
+	for _, contentType := range []string{"csv", "ndjson"} {
+		content, hasInput, err := DecodeOptionalMutationPayloadInput(&cobra.Command{}, cliutil.InputFlags{
+			Payload:     "name=test,port=80",
+			ContentType: contentType,
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", contentType, err)
+		}
+		if !hasInput {
+			t.Fatalf("%s: expected explicit payload input", contentType)
+		}
+		expected := []any{map[string]any{"name": "test", "port": "80"}}
+		if !reflect.DeepEqual(content.Value, expected) {
+			t.Fatalf("%s: expected %#v, got %#v", contentType, expected, content.Value)
+		}
+		if content.Descriptor.PayloadType != contentType {
+			t.Fatalf("expected %s payload type, got %q", contentType, content.Descriptor.PayloadType)
+		}
+	}
+}
+
 func TestDecodeOptionalMutationPayloadInputExplicitTextContentTypeTreatsInlinePayloadAsLiteralText(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,95 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	mutateapp "github.com/crmarques/declarest/internal/app/resource/mutate"
+	"github.com/crmarques/declarest/internal/cli/cliutil"
+	"github.com/spf13/cobra"
+)
+
+type mutationFailureReport struct {
+	Operation string              `json:"operation" yaml:"operation"`
+	Targeted  int                 `json:"targeted" yaml:"targeted"`
+	Succeeded int                 `json:"succeeded" yaml:"succeeded"`
+	Failed    int                 `json:"failed" yaml:"failed"`
+	Failures  []mutateapp.Failure `json:"failures" yaml:"failures"`
+}
+
+func bindContinueOnErrorFlag(command *cobra.Command, continueOnError *bool) {
+	command.Flags().BoolVar(continueOnError, "continue-on-error", false, "keep mutating remaining targets after a failure and report all failures")
+}
+
+func mutationFailureOutputFormat(globalFlags *cliutil.GlobalFlags) string {
+	if globalFlags == nil || strings.TrimSpace(globalFlags.Output) == "" {
+		return cliutil.OutputAuto
+	}
+	return globalFlags.Output
+}
+
+// writeMutationFailureReport prints the aggregated failure report and returns
+// the partial-failure error that drives the CLI exit code.
+func writeMutationFailureReport(
+	command *cobra.Command,
+	globalFlags *cliutil.GlobalFlags,
+	operation string,
+	targetedCount int,
+	failures []mutateapp.Failure,
+) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	report := mutationFailureReport{
+		Operation: operation,
+		Targeted:  targetedCount,
+		Succeeded: targetedCount - len(failures),
+		Failed:    len(failures),
+		Failures:  failures,
+	}
+	if err := cliutil.WriteOutput(command, mutationFailureOutputFormat(globalFlags), report, renderMutationFailureReport); err != nil {
+		return err
+	}
+	return mutateapp.FailuresError(operation, failures, targetedCount)
+}
+
+func renderMutationFailureReport(w io.Writer, report mutationFailureReport) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(table, "PATH\tCATEGORY\tERROR"); err != nil {
+		return err
+	}
+	for _, failure := range report.Failures {
+		if _, err := fmt.Fprintf(table, "%s\t%s\t%s\n", failure.LogicalPath, failure.Category, failure.Message); err != nil {
+			return err
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(
+		w,
+		"%s: %d succeeded, %d failed, %d targeted\n",
+		report.Operation,
+		report.Succeeded,
+		report.Failed,
+		report.Targeted,
+	)
+	return err
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/crmarques/declarest/internal/cli/cliutil"
+	"github.com/spf13/cobra"
+)
+
+func bindParallelismFlag(command *cobra.Command, parallelism *int) {
+	command.Flags().IntVar(parallelism, "parallelism", 1, "maximum number of collection targets mutated concurrently")
+}
+
+func validateParallelismFlag(value int) error {
+	if value < 1 {
+		return cliutil.ValidationError("flag --parallelism must be greater than zero", nil)
+	}
+	return nil
+}
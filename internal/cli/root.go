@@ -85,6 +85,8 @@ func NewRootCommand(deps Dependencies) *cobra.Command {
 			"  DECLAREST_NO_STATUS (legacy)",
 			"  DECLAREST_NO_COLOR",
 			"  NO_COLOR",
+			"  DECLAREST_RECORD_HTTP",
+			"  DECLAREST_REPLAY_HTTP",
 		}, "\n"),
 		RunE: func(command *cobra.Command, _ []string) error {
 			return command.Help()
@@ -97,6 +99,9 @@ func NewRootCommand(deps Dependencies) *cobra.Command {
 			if err := cliutil.ValidateOutputFormatForCommand(command, globalFlags.Output); err != nil {
 				return err
 			}
+			if err := cliutil.ValidateHTTPCassetteFlags(&globalFlags); err != nil {
+				return err
+			}
 
 			verboseLevel := cliutil.VerboseLevel(&globalFlags)
 
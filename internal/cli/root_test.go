@@ -5640,6 +5640,459 @@ func TestResourceApplyCollectionPath(t *testing.T) {
 	}
 }
 
+func TestResourceApplyContinueOnErrorReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	orchestrator := &testOrchestrator{
+		metadataService: newTestMetadata(),
+		localList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+			{LogicalPath: "/customers/beta"},
+			{LogicalPath: "/customers/gamma"},
+		},
+		applyErrs: map[string]error{
+			"/customers/beta": faults.Conflict("remote version changed", nil),
+		},
+	}
+	deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+	_, err := executeForTest(deps, "", "resource", "apply", "/customers")
+	assertTypedCategory(t, err, faults.ConflictError)
+	if !reflect.DeepEqual(orchestrator.applyCalls, []string{"/customers/acme", "/customers/beta"}) {
+		t.Fatalf("expected apply to stop at the first failure, got %#v", orchestrator.applyCalls)
+	}
+
+	orchestrator.applyCalls = nil
+	output, err := executeForTest(deps, "", "resource", "apply", "/customers", "--continue-on-error", "--output", "json")
+	assertTypedCategory(t, err, faults.PartialFailureError)
+	if code := ExitCodeForError(err); code != 7 {
+		t.Fatalf("expected partial failure exit code 7, got %d", code)
+	}
+	expectedCalls := []string{"/customers/acme", "/customers/beta", "/customers/gamma"}
+	if !reflect.DeepEqual(orchestrator.applyCalls, expectedCalls) {
+		t.Fatalf("expected apply calls %#v, got %#v", expectedCalls, orchestrator.applyCalls)
+	}
+
+	var report struct {
+		Operation string `json:"operation"`
+		Targeted  int    `json:"targeted"`
+		Failed    int    `json:"failed"`
+		Failures  []struct {
+			LogicalPath string `json:"logicalPath"`
+			Category    string `json:"category"`
+		} `json:"failures"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("expected JSON failure report, got %q: %v", output, err)
+	}
+	if report.Operation != "apply" || report.Targeted != 3 || report.Failed != 1 {
+		t.Fatalf("unexpected failure report summary: %#v", report)
+	}
+	if len(report.Failures) != 1 ||
+		report.Failures[0].LogicalPath != "/customers/beta" ||
+		report.Failures[0].Category != string(faults.ConflictError) {
+		t.Fatalf("unexpected failure report entries: %#v", report.Failures)
+	}
+
+	orchestrator.applyCalls = nil
+	textOutput, err := executeForTest(deps, "", "resource", "apply", "/customers", "--continue-on-error", "--output", "text")
+	assertTypedCategory(t, err, faults.PartialFailureError)
+	if !strings.Contains(textOutput, "PATH") || !strings.Contains(textOutput, "/customers/beta") ||
+		!strings.Contains(textOutput, "apply: 2 succeeded, 1 failed, 3 targeted") {
+		t.Fatalf("expected text failure table, got %q", textOutput)
+	}
+}
+
+func TestResourcePlanAndApplyPlanRefusesStaleRemoteState(t *testing.T) {
+	t.Parallel()
+
+	orchestrator := &testOrchestrator{
+		metadataService: newTestMetadata(),
+		localList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+			{LogicalPath: "/customers/beta"},
+			{LogicalPath: "/customers/gamma"},
+		},
+		getLocalValues: map[string]resource.Value{
+			"/customers/acme":  map[string]any{"name": "acme"},
+			"/customers/beta":  map[string]any{"name": "beta"},
+			"/customers/gamma": map[string]any{"name": "gamma"},
+		},
+		getRemoteValues: map[string]resource.Value{
+			"/customers/beta":  map[string]any{"name": "old-beta"},
+			"/customers/gamma": map[string]any{"name": "gamma"},
+		},
+	}
+	deps := testDepsWith(orchestrator, orchestrator.metadataService)
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	output, err := executeForTest(deps, "", "resource", "plan", "/customers", "--out", planFile, "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected plan error: %v", err)
+	}
+	var planned struct {
+		Items []struct {
+			LogicalPath string `json:"logicalPath"`
+			Action      string `json:"action"`
+			Method      string `json:"method"`
+			RemotePath  string `json:"remotePath"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(output), &planned); err != nil {
+		t.Fatalf("expected JSON plan output, got %q: %v", output, err)
+	}
+	actions := make([]string, 0, len(planned.Items))
+	for _, item := range planned.Items {
+		actions = append(actions, item.LogicalPath+"="+item.Action)
+	}
+	expectedActions := []string{"/customers/acme=create", "/customers/beta=update", "/customers/gamma=no-op"}
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Fatalf("expected plan actions %#v, got %#v", expectedActions, actions)
+	}
+	if planned.Items[1].Method != "PUT" || planned.Items[1].RemotePath != "/api/customers/beta" {
+		t.Fatalf("expected rendered update request in plan, got %#v", planned.Items[1])
+	}
+	if len(orchestrator.applyCalls) != 0 {
+		t.Fatalf("expected plan not to mutate remote state, got apply calls %#v", orchestrator.applyCalls)
+	}
+
+	textOutput, err := executeForTest(deps, "", "resource", "plan", "/customers", "--output", "text")
+	if err != nil {
+		t.Fatalf("unexpected plan error: %v", err)
+	}
+	if !strings.Contains(textOutput, "ACTION") || !strings.Contains(textOutput, "plan: 1 to create, 1 to update, 0 to delete, 1 unchanged") {
+		t.Fatalf("expected text plan summary, got %q", textOutput)
+	}
+
+	if _, err := executeForTest(deps, "", "resource", "apply", "/customers", "--plan", planFile); err == nil {
+		t.Fatal("expected --plan combined with a path to fail")
+	} else {
+		assertTypedCategory(t, err, faults.ValidationError)
+	}
+
+	if _, err := executeForTest(deps, "", "resource", "apply", "--plan", planFile); err != nil {
+		t.Fatalf("unexpected apply --plan error: %v", err)
+	}
+	if !reflect.DeepEqual(orchestrator.applyCalls, []string{"/customers/acme", "/customers/beta"}) {
+		t.Fatalf("expected planned create and update only, got %#v", orchestrator.applyCalls)
+	}
+	if orchestrator.applyPolicies[0].Force || !orchestrator.applyPolicies[1].Force {
+		t.Fatalf("expected only planned updates to force apply, got %#v", orchestrator.applyPolicies)
+	}
+
+	orchestrator.applyCalls = nil
+	orchestrator.getRemoteValues["/customers/gamma"] = map[string]any{"name": "changed-remotely"}
+	_, err = executeForTest(deps, "", "resource", "apply", "--plan", planFile)
+	assertTypedCategory(t, err, faults.ConflictError)
+	if !strings.Contains(err.Error(), "/customers/gamma") {
+		t.Fatalf("expected stale path in error, got %v", err)
+	}
+	if len(orchestrator.applyCalls) != 0 {
+		t.Fatalf("expected stale plan not to mutate remote state, got apply calls %#v", orchestrator.applyCalls)
+	}
+}
+
+func TestResourceApplyPlanRefusesLocalEditsAfterPlan(t *testing.T) {
+	t.Parallel()
+
+	orchestrator := &testOrchestrator{
+		metadataService: newTestMetadata(),
+		localList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+			{LogicalPath: "/customers/beta"},
+		},
+		getLocalValues: map[string]resource.Value{
+			"/customers/acme": map[string]any{"name": "acme"},
+			"/customers/beta": map[string]any{"name": "beta"},
+		},
+		getRemoteValues: map[string]resource.Value{
+			"/customers/acme": map[string]any{"name": "old-acme"},
+			"/customers/beta": map[string]any{"name": "beta"},
+		},
+	}
+	deps := testDepsWith(orchestrator, orchestrator.metadataService)
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	if _, err := executeForTest(deps, "", "resource", "plan", "/customers", "--out", planFile, "--output", "json"); err != nil {
+		t.Fatalf("unexpected plan error: %v", err)
+	}
+
+	orchestrator.getLocalValues["/customers/beta"] = map[string]any{"name": "edited-after-plan"}
+	_, err := executeForTest(deps, "", "resource", "apply", "--plan", planFile)
+	assertTypedCategory(t, err, faults.ConflictError)
+	if !strings.Contains(err.Error(), "/customers/beta") || strings.Contains(err.Error(), "/customers/acme") {
+		t.Fatalf("expected only the locally edited no-op path in error, got %v", err)
+	}
+	if len(orchestrator.applyCalls) != 0 {
+		t.Fatalf("expected stale plan not to mutate remote state, got apply calls %#v", orchestrator.applyCalls)
+	}
+}
+
+func TestResourcePlanPruneIncludesRemoteOnlyDeletes(t *testing.T) {
+	t.Parallel()
+
+	orchestrator := &testOrchestrator{
+		metadataService: newTestMetadata(),
+		localList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+		},
+		remoteList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+			{LogicalPath: "/customers/legacy"},
+		},
+		getLocalValues: map[string]resource.Value{
+			"/customers/acme": map[string]any{"name": "acme"},
+		},
+		getRemoteValues: map[string]resource.Value{
+			"/customers/acme":   map[string]any{"name": "acme"},
+			"/customers/legacy": map[string]any{"name": "legacy"},
+		},
+	}
+	deps := testDepsWith(orchestrator, orchestrator.metadataService)
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	output, err := executeForTest(deps, "", "resource", "plan", "/customers", "--prune", "--out", planFile, "--output", "text")
+	if err != nil {
+		t.Fatalf("unexpected plan error: %v", err)
+	}
+	if !strings.Contains(output, "/customers/legacy") || !strings.Contains(output, "0 to create, 0 to update, 1 to delete, 1 unchanged") {
+		t.Fatalf("expected prune delete in plan output, got %q", output)
+	}
+
+	if _, err := executeForTest(deps, "", "resource", "apply", "--plan", planFile); err != nil {
+		t.Fatalf("unexpected apply --plan error: %v", err)
+	}
+	if len(orchestrator.applyCalls) != 0 {
+		t.Fatalf("expected no apply calls for no-op plan items, got %#v", orchestrator.applyCalls)
+	}
+	if len(orchestrator.deleteCalls) != 1 || orchestrator.deleteCalls[0].logicalPath != "/customers/legacy" {
+		t.Fatalf("expected planned prune delete, got %#v", orchestrator.deleteCalls)
+	}
+}
+
+func TestResourceDriftClassifiesLocalAndRemotePaths(t *testing.T) {
+	t.Parallel()
+
+	newOrchestrator := func() *testOrchestrator {
+		return &testOrchestrator{
+			metadataService: newTestMetadata(),
+			localList: []resource.Resource{
+				{LogicalPath: "/customers/acme"},
+				{LogicalPath: "/customers/beta"},
+				{LogicalPath: "/customers/gamma"},
+			},
+			remoteList: []resource.Resource{
+				{LogicalPath: "/customers/acme"},
+				{LogicalPath: "/customers/beta"},
+				{LogicalPath: "/customers/legacy"},
+			},
+			getLocalValues: map[string]resource.Value{
+				"/customers/acme":  map[string]any{"name": "acme"},
+				"/customers/beta":  map[string]any{"name": "beta", "tier": "gold"},
+				"/customers/gamma": map[string]any{"name": "gamma"},
+			},
+			getRemoteValues: map[string]resource.Value{
+				"/customers/acme":   map[string]any{"name": "acme"},
+				"/customers/beta":   map[string]any{"name": "beta", "tier": "silver"},
+				"/customers/legacy": map[string]any{"name": "legacy"},
+			},
+		}
+	}
+
+	t.Run("json_report", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		output, err := executeForTest(deps, "", "resource", "drift", "/customers", "--output", "json")
+		if err != nil {
+			t.Fatalf("unexpected drift error: %v", err)
+		}
+
+		var report struct {
+			Summary map[string]int `json:"summary"`
+			Entries []struct {
+				LogicalPath string `json:"logicalPath"`
+				Status      string `json:"status"`
+			} `json:"entries"`
+		}
+		if err := json.Unmarshal([]byte(output), &report); err != nil {
+			t.Fatalf("failed to decode drift report %q: %v", output, err)
+		}
+		statuses := make(map[string]string, len(report.Entries))
+		for _, entry := range report.Entries {
+			statuses[entry.LogicalPath] = entry.Status
+		}
+		expected := map[string]string{
+			"/customers/acme":   "in-sync",
+			"/customers/beta":   "modified",
+			"/customers/gamma":  "missing-remote",
+			"/customers/legacy": "remote-only",
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Fatalf("unexpected drift statuses: %#v", statuses)
+		}
+		if report.Summary["total"] != 4 || report.Summary["modified"] != 1 {
+			t.Fatalf("unexpected drift summary: %#v", report.Summary)
+		}
+		if len(orchestrator.applyCalls) != 0 || len(orchestrator.deleteCalls) != 0 {
+			t.Fatal("expected drift not to mutate remote state")
+		}
+	})
+
+	t.Run("text_summary_and_junit_with_fail_on_drift", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+		junitFile := filepath.Join(t.TempDir(), "drift.xml")
+
+		output, err := executeForTest(deps, "", "resource", "drift", "/customers", "--junit", junitFile, "--fail-on-drift", "--output", "text")
+		assertTypedCategory(t, err, faults.ConflictError)
+		if strings.Contains(output, "/customers/acme") {
+			t.Fatalf("expected in-sync paths to be omitted from text output, got %q", output)
+		}
+		if !strings.Contains(output, "drift: 1 in-sync, 1 modified, 1 missing-remote, 1 remote-only") {
+			t.Fatalf("expected drift summary, got %q", output)
+		}
+
+		junit, readErr := os.ReadFile(junitFile)
+		if readErr != nil {
+			t.Fatalf("failed to read JUnit report: %v", readErr)
+		}
+		if !strings.Contains(string(junit), `tests="4" failures="3"`) || !strings.Contains(string(junit), `type="remote-only"`) {
+			t.Fatalf("unexpected JUnit report: %s", junit)
+		}
+	})
+
+	t.Run("fail_on_drift_passes_when_in_sync", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newOrchestrator()
+		orchestrator.localList = orchestrator.localList[:1]
+		orchestrator.remoteList = orchestrator.remoteList[:1]
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		if _, err := executeForTest(deps, "", "resource", "drift", "/customers", "--fail-on-drift"); err != nil {
+			t.Fatalf("expected no error without drift, got %v", err)
+		}
+	})
+}
+
+func newPruneTestOrchestrator() *testOrchestrator {
+	disabled := false
+	metadataService := newTestMetadata()
+	metadataService.items["/customers/locked"] = metadatadomain.ResourceMetadata{Prune: &disabled}
+
+	return &testOrchestrator{
+		metadataService: metadataService,
+		localList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+		},
+		remoteList: []resource.Resource{
+			{LogicalPath: "/customers/acme"},
+			{LogicalPath: "/customers/legacy"},
+			{LogicalPath: "/customers/locked"},
+		},
+	}
+}
+
+func TestResourcePruneDeletesRemoteOnlyResources(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dry_run_lists_candidates_without_deleting", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newPruneTestOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		output, err := executeForTest(deps, "", "resource", "prune", "/customers", "--dry-run", "--output", "text")
+		if err != nil {
+			t.Fatalf("unexpected prune error: %v", err)
+		}
+		if !strings.Contains(output, "would delete /customers/legacy") || !strings.Contains(output, "skip /customers/locked") {
+			t.Fatalf("expected dry-run candidates and skipped paths, got %q", output)
+		}
+		if strings.Contains(output, "would delete /customers/acme") {
+			t.Fatalf("expected local resource to be kept, got %q", output)
+		}
+		if len(orchestrator.deleteCalls) != 0 {
+			t.Fatalf("expected no delete calls on dry run, got %#v", orchestrator.deleteCalls)
+		}
+	})
+
+	t.Run("requires_yes_when_not_interactive", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newPruneTestOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		_, err := executeForTest(deps, "", "resource", "prune", "/customers")
+		assertTypedCategory(t, err, faults.ValidationError)
+		if len(orchestrator.deleteCalls) != 0 {
+			t.Fatalf("expected no delete calls without confirmation, got %#v", orchestrator.deleteCalls)
+		}
+	})
+
+	t.Run("deletes_with_yes_and_skips_opted_out_paths", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newPruneTestOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		output, err := executeForTest(deps, "", "resource", "prune", "/customers", "--yes", "--output", "json")
+		if err != nil {
+			t.Fatalf("unexpected prune error: %v", err)
+		}
+		if len(orchestrator.deleteCalls) != 1 || orchestrator.deleteCalls[0].logicalPath != "/customers/legacy" {
+			t.Fatalf("expected only /customers/legacy to be deleted, got %#v", orchestrator.deleteCalls)
+		}
+
+		var report map[string]any
+		if err := json.Unmarshal([]byte(output), &report); err != nil {
+			t.Fatalf("failed to decode prune report %q: %v", output, err)
+		}
+		if !reflect.DeepEqual(report["deleted"], []any{"/customers/legacy"}) || !reflect.DeepEqual(report["skipped"], []any{"/customers/locked"}) {
+			t.Fatalf("unexpected prune report: %#v", report)
+		}
+	})
+}
+
+func TestResourceApplyPruneDeletesAfterApply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies_then_prunes", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newPruneTestOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		if _, err := executeForTest(deps, "", "resource", "apply", "/customers", "--prune", "--yes"); err != nil {
+			t.Fatalf("unexpected apply --prune error: %v", err)
+		}
+		if !reflect.DeepEqual(orchestrator.applyCalls, []string{"/customers/acme"}) {
+			t.Fatalf("expected /customers/acme to be applied, got %#v", orchestrator.applyCalls)
+		}
+		if len(orchestrator.deleteCalls) != 1 || orchestrator.deleteCalls[0].logicalPath != "/customers/legacy" {
+			t.Fatalf("expected /customers/legacy to be pruned, got %#v", orchestrator.deleteCalls)
+		}
+	})
+
+	t.Run("requires_yes_before_applying", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := newPruneTestOrchestrator()
+		deps := testDepsWith(orchestrator, orchestrator.metadataService)
+
+		_, err := executeForTest(deps, "", "resource", "apply", "/customers", "--prune")
+		assertTypedCategory(t, err, faults.ValidationError)
+		if len(orchestrator.applyCalls) != 0 || len(orchestrator.deleteCalls) != 0 {
+			t.Fatalf("expected no mutations without confirmation, got apply=%#v delete=%#v", orchestrator.applyCalls, orchestrator.deleteCalls)
+		}
+	})
+}
+
 func TestResourceApplyUsesExplicitInputOverride(t *testing.T) {
 	t.Parallel()
 
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/crmarques/declarest/config"
 	"github.com/crmarques/declarest/faults"
 	resourcediffapp "github.com/crmarques/declarest/internal/app/resource/diff"
+	resourceplanapp "github.com/crmarques/declarest/internal/app/resource/plan"
 	clitestkit "github.com/crmarques/declarest/internal/cli/testkit"
 	managedservicedomain "github.com/crmarques/declarest/managedservice"
 	metadatadomain "github.com/crmarques/declarest/metadata"
@@ -294,6 +296,7 @@ type testOrchestrator struct {
 	listRemoteDetail []listCall
 	listRemoteErr    error
 	applyCalls       []string
+	applyErrs        map[string]error
 	applyPolicies    []orchestrator.ApplyPolicy
 	applyValueCalls  []savedResource
 	applyValuePolicy []orchestrator.ApplyPolicy
@@ -394,6 +397,9 @@ func (r *testOrchestrator) Save(_ context.Context, logicalPath string, content r
 func (r *testOrchestrator) Apply(_ context.Context, logicalPath string, policy orchestrator.ApplyPolicy) (resource.Resource, error) {
 	r.applyCalls = append(r.applyCalls, logicalPath)
 	r.applyPolicies = append(r.applyPolicies, policy)
+	if err, ok := r.applyErrs[logicalPath]; ok {
+		return resource.Resource{}, err
+	}
 	return resource.Resource{LogicalPath: logicalPath}, nil
 }
 func (r *testOrchestrator) ApplyWithContent(_ context.Context, logicalPath string, content resource.Content, policy orchestrator.ApplyPolicy) (resource.Resource, error) {
@@ -563,6 +569,79 @@ func (r *testOrchestrator) Template(_ context.Context, _ string, content resourc
 	return content, nil
 }
 
+func (r *testOrchestrator) PlanApply(ctx context.Context, logicalPath string) (resourceplanapp.Item, error) {
+	localValue, err := r.GetLocal(ctx, logicalPath)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	localDigest, err := resourceplanapp.LocalDigest(localValue)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	remoteValue, err := r.GetRemote(ctx, logicalPath)
+	if err != nil {
+		if !faults.IsCategory(err, faults.NotFoundError) {
+			return resourceplanapp.Item{}, err
+		}
+		return resourceplanapp.Item{
+			LogicalPath: logicalPath,
+			Action:      resourceplanapp.ActionCreate,
+			LocalDigest: localDigest,
+			Method:      "POST",
+			RemotePath:  "/api" + path.Dir(logicalPath),
+			Diff:        []resource.DiffEntry{{ResourcePath: logicalPath, Operation: "replace", Local: localValue.Value}},
+		}, nil
+	}
+
+	digest, err := resourceplanapp.RemoteDigest(remoteValue)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	item := resourceplanapp.Item{
+		LogicalPath:  logicalPath,
+		Action:       resourceplanapp.ActionNoop,
+		LocalDigest:  localDigest,
+		RemoteDigest: digest,
+	}
+	if !reflect.DeepEqual(localValue.Value, remoteValue.Value) {
+		item.Action = resourceplanapp.ActionUpdate
+		item.Method = "PUT"
+		item.RemotePath = "/api" + logicalPath
+		item.Diff = []resource.DiffEntry{{
+			ResourcePath: logicalPath,
+			Operation:    "replace",
+			Local:        localValue.Value,
+			Remote:       remoteValue.Value,
+		}}
+	}
+	return item, nil
+}
+
+func (r *testOrchestrator) PlanDelete(ctx context.Context, logicalPath string) (resourceplanapp.Item, error) {
+	remoteValue, err := r.GetRemote(ctx, logicalPath)
+	if err != nil {
+		if faults.IsCategory(err, faults.NotFoundError) {
+			return resourceplanapp.Item{LogicalPath: logicalPath, Action: resourceplanapp.ActionNoop}, nil
+		}
+		return resourceplanapp.Item{}, err
+	}
+	digest, err := resourceplanapp.RemoteDigest(remoteValue)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	return resourceplanapp.Item{
+		LogicalPath:  logicalPath,
+		Action:       resourceplanapp.ActionDelete,
+		Method:       "DELETE",
+		RemotePath:   "/api" + logicalPath,
+		RemoteDigest: digest,
+	}, nil
+}
+
+func (r *testOrchestrator) OrderByDependencies(_ context.Context, targets []resource.Resource) (orchestrator.DependencyOrder, error) {
+	return orchestrator.OrderByAncestry(targets), nil
+}
+
 func isDirectChildPath(basePath string, candidatePath string) bool {
 	base := path.Clean(basePath)
 	candidate := path.Clean(candidatePath)
@@ -0,0 +1,207 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package execcredential runs external credential helpers, modeled on kubectl
+// exec credential plugins, and caches the tokens they print until expiry.
+package execcredential
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	// expirySkew renews a token shortly before it expires, so a request
+	// never leaves with a token that expires in flight.
+	expirySkew = 30 * time.Second
+	// maxStderrLength bounds the helper output quoted in error messages.
+	maxStderrLength = 512
+	// waitDelay bounds the wait for output pipes held open by children of a
+	// helper that was killed on timeout.
+	waitDelay = time.Second
+)
+
+// Provider runs one configured credential helper. It is safe for concurrent
+// use; concurrent callers share a single helper run.
+type Provider struct {
+	field   string
+	command string
+	args    []string
+	env     []string
+	timeout time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// response is the ExecCredential document printed by the helper. A bare
+// {"token": ..., "expirationTimestamp": ...} object is accepted as well.
+type response struct {
+	Status *status `json:"status"`
+	status
+}
+
+type status struct {
+	Token               string `json:"token"`
+	ExpirationTimestamp string `json:"expirationTimestamp"`
+}
+
+// New validates cfg; field is the configuration path used in error messages.
+func New(field string, cfg config.ExecAuth) (*Provider, error) {
+	command := strings.TrimSpace(cfg.Command)
+	if command == "" {
+		return nil, faults.Invalid(field+".command is required", nil)
+	}
+
+	timeout := defaultTimeout
+	if value := strings.TrimSpace(cfg.Timeout); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, faults.Invalid(field+".timeout must be a positive duration", err)
+		}
+		timeout = parsed
+	}
+
+	keys := make([]string, 0, len(cfg.Env))
+	for key := range cfg.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return nil, faults.Invalid(field+".env keys must not be empty", nil)
+		}
+		env = append(env, key+"="+cfg.Env[key])
+	}
+
+	return &Provider{
+		field:   field,
+		command: command,
+		args:    append([]string(nil), cfg.Args...),
+		env:     env,
+		timeout: timeout,
+		now:     time.Now,
+	}, nil
+}
+
+// Token returns the cached token, running the helper when there is none or
+// it is about to expire. Tokens without an expiry are kept until Invalidate.
+func (p *Provider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && (p.expiresAt.IsZero() || p.now().Before(p.expiresAt.Add(-expirySkew))) {
+		debugctx.Detailf(ctx, "exec credential using cached token command=%q", p.command)
+		return p.token, nil
+	}
+
+	token, expiresAt, err := p.run(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.token = token
+	p.expiresAt = expiresAt
+	return token, nil
+}
+
+// Invalidate drops the cached token, so the next Token call runs the helper.
+func (p *Provider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+	p.expiresAt = time.Time{}
+}
+
+func (p *Provider) run(ctx context.Context) (string, time.Time, error) {
+	runCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	debugctx.Detailf(ctx, "exec credential running command=%q", p.command)
+	cmd := exec.CommandContext(runCtx, p.command, p.args...)
+	cmd.Env = append(os.Environ(), p.env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+
+	if err := cmd.Run(); err != nil {
+		message := fmt.Sprintf("%s command %q failed", p.field, p.command)
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			message = fmt.Sprintf("%s command %q timed out after %s", p.field, p.command, p.timeout)
+		}
+		if detail := truncate(strings.TrimSpace(stderr.String())); detail != "" {
+			message += ": " + detail
+		}
+		return "", time.Time{}, faults.Auth(message, err)
+	}
+
+	var decoded response
+	if err := json.Unmarshal(stdout.Bytes(), &decoded); err != nil {
+		return "", time.Time{}, faults.Auth(fmt.Sprintf("%s command %q printed invalid JSON", p.field, p.command), err)
+	}
+	result := decoded.status
+	if decoded.Status != nil {
+		result = *decoded.Status
+	}
+
+	token := strings.TrimSpace(result.Token)
+	if token == "" {
+		return "", time.Time{}, faults.Auth(fmt.Sprintf("%s command %q returned no token", p.field, p.command), nil)
+	}
+	var expiresAt time.Time
+	if value := strings.TrimSpace(result.ExpirationTimestamp); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return "", time.Time{}, faults.Auth(
+				fmt.Sprintf("%s command %q returned an invalid expirationTimestamp", p.field, p.command),
+				err,
+			)
+		}
+		expiresAt = parsed
+	}
+
+	debugctx.Detailf(ctx, "exec credential obtained token command=%q expires_at=%s", p.command, formatExpiry(expiresAt))
+	return token, expiresAt, nil
+}
+
+func formatExpiry(value time.Time) string {
+	if value.IsZero() {
+		return "never"
+	}
+	return value.Format(time.RFC3339)
+}
+
+func truncate(value string) string {
+	if len(value) <= maxStderrLength {
+		return value
+	}
+	return value[:maxStderrLength] + "..."
+}
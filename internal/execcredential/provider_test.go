@@ -0,0 +1,175 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execcredential
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+)
+
+// shellHelper returns an exec config running script with /bin/sh; each run
+// appends a line to the returned counter file.
+func shellHelper(t *testing.T, script string) (config.ExecAuth, string) {
+	t.Helper()
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	return config.ExecAuth{
+		Command: "/bin/sh",
+		Args:    []string{"-c", "echo run >> \"$RUNS_FILE\"; " + script},
+		Env:     map[string]string{"RUNS_FILE": counter},
+	}, counter
+}
+
+func runCount(t *testing.T, counter string) int {
+	t.Helper()
+
+	content, err := os.ReadFile(counter)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0
+		}
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	return strings.Count(string(content), "run")
+}
+
+func mustProvider(t *testing.T, cfg config.ExecAuth) *Provider {
+	t.Helper()
+
+	provider, err := New("managed-service.http.auth.exec", cfg)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return provider
+}
+
+func TestTokenCachesUntilExpiry(t *testing.T) {
+	t.Parallel()
+
+	cfg, counter := shellHelper(t, `printf '{"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential","status":{"token":"tok-%s","expirationTimestamp":"2030-01-01T00:00:00Z"}}' "$TOKEN_SUFFIX"`)
+	cfg.Env["TOKEN_SUFFIX"] = "a"
+	provider := mustProvider(t, cfg)
+
+	now := time.Date(2029, 12, 31, 23, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+
+	for range 3 {
+		token, err := provider.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token returned error: %v", err)
+		}
+		if token != "tok-a" {
+			t.Fatalf("expected tok-a, got %q", token)
+		}
+	}
+	if got := runCount(t, counter); got != 1 {
+		t.Fatalf("expected the helper to run once while the token is valid, ran %d times", got)
+	}
+
+	now = time.Date(2029, 12, 31, 23, 59, 45, 0, time.UTC)
+	if _, err := provider.Token(context.Background()); err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got := runCount(t, counter); got != 2 {
+		t.Fatalf("expected the helper to run again near expiry, ran %d times", got)
+	}
+}
+
+func TestTokenWithoutExpiryIsKeptUntilInvalidated(t *testing.T) {
+	t.Parallel()
+
+	cfg, counter := shellHelper(t, `echo '{"token":"static-token"}'`)
+	provider := mustProvider(t, cfg)
+
+	for range 2 {
+		token, err := provider.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token returned error: %v", err)
+		}
+		if token != "static-token" {
+			t.Fatalf("expected static-token, got %q", token)
+		}
+	}
+	provider.Invalidate()
+	if _, err := provider.Token(context.Background()); err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got := runCount(t, counter); got != 2 {
+		t.Fatalf("expected one run before and one after Invalidate, ran %d times", got)
+	}
+}
+
+func TestTokenFailuresReturnAuthError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"non_zero_exit":     `echo 'vault sealed' >&2; exit 3`,
+		"invalid_json":      `echo 'not json'`,
+		"missing_token":     `echo '{"status":{}}'`,
+		"invalid_timestamp": `echo '{"status":{"token":"t","expirationTimestamp":"tomorrow"}}'`,
+	}
+	for name, script := range testCases {
+		script := script
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _ := shellHelper(t, script)
+			_, err := mustProvider(t, cfg).Token(context.Background())
+			if !faults.IsCategory(err, faults.AuthError) {
+				t.Fatalf("expected AuthError, got %v", err)
+			}
+		})
+	}
+
+	cfg, _ := shellHelper(t, `echo 'vault sealed' >&2; exit 3`)
+	_, err := mustProvider(t, cfg).Token(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "vault sealed") {
+		t.Fatalf("expected helper stderr in the error, got %v", err)
+	}
+}
+
+func TestTokenTimesOut(t *testing.T) {
+	t.Parallel()
+
+	provider := mustProvider(t, config.ExecAuth{Command: "/bin/sh", Args: []string{"-c", "exec sleep 5"}, Timeout: "100ms"})
+	_, err := provider.Token(context.Background())
+	if !faults.IsCategory(err, faults.AuthError) || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout AuthError, got %v", err)
+	}
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]config.ExecAuth{
+		"missing_command":  {},
+		"invalid_timeout":  {Command: "helper", Timeout: "soon"},
+		"negative_timeout": {Command: "helper", Timeout: "-1s"},
+		"empty_env_key":    {Command: "helper", Env: map[string]string{" ": "value"}},
+	}
+	for name, cfg := range testCases {
+		if _, err := New("repository.git.remote.auth.exec", cfg); !faults.IsCategory(err, faults.ValidationError) {
+			t.Fatalf("%s: expected ValidationError, got %v", name, err)
+		}
+	}
+}
@@ -49,6 +49,22 @@ func populateManagedServiceConfig(
 			ScopeKey:              fmt.Sprintf("%s/%s", managedService.Namespace, managedService.Name),
 		}
 	}
+	if managedService.Spec.HTTP.Retry != nil {
+		retry := managedService.Spec.HTTP.Retry
+		cfg.Retry = &config.HTTPRetry{
+			MaxAttempts: int(retry.MaxAttempts),
+			Jitter:      retry.Jitter,
+		}
+		if retry.InitialBackoff != nil {
+			cfg.Retry.InitialBackoff = retry.InitialBackoff.Duration.String()
+		}
+		if retry.MaxBackoff != nil {
+			cfg.Retry.MaxBackoff = retry.MaxBackoff.Duration.String()
+		}
+		for _, statusCode := range retry.RetryableStatusCodes {
+			cfg.Retry.RetryableStatusCodes = append(cfg.Retry.RetryableStatusCodes, int(statusCode))
+		}
+	}
 
 	if managedService.Spec.HTTP.TLS != nil {
 		tlsConfig := &config.TLS{InsecureSkipVerify: managedService.Spec.HTTP.TLS.InsecureSkipVerify}
@@ -103,17 +119,51 @@ func populateManagedServiceConfig(
 		if err != nil {
 			return err
 		}
-		clientSecret, err := readSecretValue(ctx, reader, namespace, oauth2.ClientSecretRef)
-		if err != nil {
-			return err
-		}
 		oauthConfig := &config.OAuth2{
-			TokenURL:     oauth2.TokenURL,
-			GrantType:    oauth2.GrantType,
-			ClientID:     clientID,
-			ClientSecret: clientSecret,
-			Scope:        oauth2.Scope,
-			Audience:     oauth2.Audience,
+			TokenURL:         oauth2.TokenURL,
+			GrantType:        oauth2.GrantType,
+			ClientID:         clientID,
+			SubjectTokenType: oauth2.SubjectTokenType,
+			ClientAuthMethod: oauth2.ClientAuthMethod,
+			Scope:            oauth2.Scope,
+			Audience:         oauth2.Audience,
+		}
+		if oauth2.ClientSecretRef != nil {
+			clientSecret, err := readSecretValue(ctx, reader, namespace, oauth2.ClientSecretRef)
+			if err != nil {
+				return err
+			}
+			oauthConfig.ClientSecret = clientSecret
+		}
+		if oauth2.RefreshTokenRef != nil {
+			refreshToken, err := readSecretValue(ctx, reader, namespace, oauth2.RefreshTokenRef)
+			if err != nil {
+				return err
+			}
+			oauthConfig.RefreshToken = refreshToken
+		}
+		if oauth2.ClientAssertionKeyRef != nil {
+			value, err := readSecretValue(ctx, reader, namespace, oauth2.ClientAssertionKeyRef)
+			if err != nil {
+				return err
+			}
+			path, err := writeSecretValueToFileWithCleanup(cleanup, filepath.Join(cacheDir, "managed-service-oauth2"), "client-assertion-key", value)
+			if err != nil {
+				return err
+			}
+			oauthConfig.ClientAssertionKeyFile = path
+			oauthConfig.ClientAssertionKeyID = oauth2.ClientAssertionKeyID
+		}
+		if oauth2.SubjectTokenRef != nil {
+			value, err := readSecretValue(ctx, reader, namespace, oauth2.SubjectTokenRef)
+			if err != nil {
+				return err
+			}
+			path, err := writeSecretValueToFileWithCleanup(cleanup, filepath.Join(cacheDir, "managed-service-oauth2"), "subject-token", value)
+			if err != nil {
+				return err
+			}
+			oauthConfig.SubjectTokenFile = path
 		}
 		if oauth2.UsernameRef != nil {
 			username, err := readSecretValue(ctx, reader, namespace, oauth2.UsernameRef)
@@ -156,6 +206,31 @@ func populateManagedServiceConfig(
 		}
 		auth.CustomHeaders = headers
 	}
+	if sigv4 := managedService.Spec.HTTP.Auth.SigV4; sigv4 != nil {
+		sigv4Config := &config.SigV4Auth{Region: sigv4.Region, Service: sigv4.Service}
+		if sigv4.AccessKeyIDRef != nil {
+			accessKeyID, err := readSecretValue(ctx, reader, namespace, sigv4.AccessKeyIDRef)
+			if err != nil {
+				return err
+			}
+			sigv4Config.AccessKeyID = accessKeyID
+		}
+		if sigv4.SecretAccessKeyRef != nil {
+			secretAccessKey, err := readSecretValue(ctx, reader, namespace, sigv4.SecretAccessKeyRef)
+			if err != nil {
+				return err
+			}
+			sigv4Config.SecretAccessKey = secretAccessKey
+		}
+		if sigv4.SessionTokenRef != nil {
+			sessionToken, err := readSecretValue(ctx, reader, namespace, sigv4.SessionTokenRef)
+			if err != nil {
+				return err
+			}
+			sigv4Config.SessionToken = sessionToken
+		}
+		auth.SigV4 = sigv4Config
+	}
 	cfg.Auth = auth
 	return nil
 }
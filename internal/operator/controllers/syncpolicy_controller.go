@@ -22,13 +22,16 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	declarestv1alpha1 "github.com/crmarques/declarest/api/v1alpha1"
 	"github.com/crmarques/declarest/faults"
 	mutateapp "github.com/crmarques/declarest/internal/app/resource/mutate"
 	"github.com/crmarques/declarest/internal/bootstrap"
+	"github.com/crmarques/declarest/managedservice"
 	orchestratordomain "github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -353,6 +356,13 @@ func (r *syncPolicyReconciliation) performSync() (ctrl.Result, error) {
 	}
 
 	targetedCount, appliedCount, err := r.applyChanges(session, executionPlan)
+	if err != nil && managedservice.IsPreconditionFailedError(err) {
+		// The remote object changed between read and write; report it
+		// distinctly and requeue so the next attempt re-reads the current version.
+		r.resultLabel = "error"
+		r.reasonLabel = conditionReasonRemoteConflict
+		return r.failWithStatus(r.ctx, r.policy, conditionReasonRemoteConflict, err.Error(), defaultTransientRequeueInterval, "RemoteConflict")
+	}
 	if err != nil {
 		r.resultLabel = "error"
 		r.reasonLabel = conditionReasonReconcileFailed
@@ -476,21 +486,25 @@ func (r *syncPolicyReconciliation) buildExecutionPlan(repoPath, repoRevision str
 }
 
 func (r *syncPolicyReconciliation) applyChanges(session bootstrap.Session, plan *syncExecutionPlan) (int32, int32, error) {
-	var targetedCount, appliedCount int32
-	conflicting := false
+	var targetedCount int32
+	var appliedCount, conflictCount atomic.Int32
 	for _, target := range plan.ApplyTargets {
 		targets, listErr := mutateapp.ListLocalTargets(r.ctx, session.Orchestrator, target.Path, target.Recursive)
 		if listErr != nil {
 			return 0, 0, listErr
 		}
 		targetedCount += int32(len(targets))
-		for _, item := range targets {
+		order, orderErr := session.Orchestrator.OrderByDependencies(r.ctx, targets)
+		if orderErr != nil {
+			return 0, 0, orderErr
+		}
+		runErr := mutateapp.RunTargets(r.ctx, order, int(r.policy.Spec.Sync.Parallelism), func(ctx context.Context, _ int, item resource.Resource) error {
 			if r.skipApplyOnConflict(item.CollectionPath, item.LogicalPath, "") {
-				conflicting = true
-				continue
+				conflictCount.Add(1)
+				return nil
 			}
 			skippedByConflict := false
-			_, mutateErr := session.Orchestrator.Apply(r.ctx, item.LogicalPath, orchestratordomain.ApplyPolicy{
+			_, mutateErr := session.Orchestrator.Apply(ctx, item.LogicalPath, orchestratordomain.ApplyPolicy{
 				Force: r.policy.Spec.Sync.Force,
 				Conflict: func(ctx context.Context, check orchestratordomain.ConflictCheck) (bool, string) {
 					if r.skipApplyOnConflict(check.CollectionPath, check.LogicalPath, check.RemoteID) {
@@ -501,17 +515,21 @@ func (r *syncPolicyReconciliation) applyChanges(session bootstrap.Session, plan
 				},
 			})
 			if mutateErr != nil {
-				return 0, 0, mutateErr
+				return mutateErr
 			}
 			if skippedByConflict {
-				conflicting = true
-				continue
+				conflictCount.Add(1)
+				return nil
 			}
-			appliedCount++
+			appliedCount.Add(1)
+			return nil
+		})
+		if runErr != nil {
+			return 0, 0, runErr
 		}
 	}
-	r.updateConflictingCondition(conflicting)
-	return targetedCount, appliedCount, nil
+	r.updateConflictingCondition(conflictCount.Load() > 0)
+	return targetedCount, appliedCount.Load(), nil
 }
 
 func (r *syncPolicyReconciliation) skipApplyOnConflict(collectionPath string, logicalPath string, remoteID string) bool {
@@ -640,6 +658,7 @@ func (r *syncPolicyReconciliation) pruneRemote(
 			LogicalPath:    remote.LogicalPath,
 			CollectionPath: remote.CollectionPath,
 			RemoteID:       remote.RemoteID,
+			Payload:        remote.Payload,
 		})
 	}
 	sort.Slice(candidates, func(i, j int) bool {
@@ -671,6 +690,7 @@ type resourcePruneCandidate struct {
 	LogicalPath    string
 	CollectionPath string
 	RemoteID       string
+	Payload        resource.Value
 }
 
 func (r *syncPolicyReconciliation) deletePruneCandidates(
@@ -678,9 +698,26 @@ func (r *syncPolicyReconciliation) deletePruneCandidates(
 	orchestratorService orchestratordomain.Orchestrator,
 	candidates []resourcePruneCandidate,
 ) (int, error) {
+	candidateByPath := make(map[string]resourcePruneCandidate, len(candidates))
+	targets := make([]resource.Resource, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateByPath[candidate.LogicalPath] = candidate
+		targets = append(targets, resource.Resource{
+			LogicalPath:    candidate.LogicalPath,
+			CollectionPath: candidate.CollectionPath,
+			RemoteID:       candidate.RemoteID,
+			Payload:        candidate.Payload,
+		})
+	}
+	order, err := orchestratorService.OrderByDependencies(ctx, targets)
+	if err != nil {
+		return 0, fmt.Errorf("order prune candidates: %w", err)
+	}
+
 	deleted := 0
 	var errs []error
-	for _, candidate := range candidates {
+	for _, target := range order.Reverse().Targets {
+		candidate := candidateByPath[target.LogicalPath]
 		if r.skipPruneOnConflict(candidate.CollectionPath, candidate.LogicalPath, candidate.RemoteID) {
 			continue
 		}
@@ -434,3 +434,7 @@ func (o *conflictAwareOrchestrator) Diff(context.Context, string) ([]resource.Di
 func (o *conflictAwareOrchestrator) Template(context.Context, string, resource.Content) (resource.Content, error) {
 	return resource.Content{}, nil
 }
+
+func (o *conflictAwareOrchestrator) OrderByDependencies(_ context.Context, targets []resource.Resource) (orchestratordomain.DependencyOrder, error) {
+	return orchestratordomain.OrderByAncestry(targets), nil
+}
@@ -56,6 +56,7 @@ const (
 	conditionReasonDependencyNotReady     = "DependencyNotReady"
 	conditionReasonRepositoryUnavailable  = "RepositoryUnavailable"
 	conditionReasonSessionBootstrapFailed = "SessionBootstrapFailed"
+	conditionReasonRemoteConflict         = "RemoteConflict"
 
 	// defaultTransientRequeueInterval is the requeue interval used when a
 	// transient error occurs and no explicit interval is provided. This
@@ -328,6 +329,9 @@ func collectSecretNames(
 		addRef(managedService.Spec.HTTP.Auth.OAuth2.ClientSecretRef)
 		addRef(managedService.Spec.HTTP.Auth.OAuth2.UsernameRef)
 		addRef(managedService.Spec.HTTP.Auth.OAuth2.PasswordRef)
+		addRef(managedService.Spec.HTTP.Auth.OAuth2.RefreshTokenRef)
+		addRef(managedService.Spec.HTTP.Auth.OAuth2.ClientAssertionKeyRef)
+		addRef(managedService.Spec.HTTP.Auth.OAuth2.SubjectTokenRef)
 	}
 	if managedService.Spec.HTTP.Auth.BasicAuth != nil {
 		addRef(managedService.Spec.HTTP.Auth.BasicAuth.UsernameRef)
@@ -336,6 +340,11 @@ func collectSecretNames(
 	for _, h := range managedService.Spec.HTTP.Auth.CustomHeaders {
 		addRef(h.ValueRef)
 	}
+	if managedService.Spec.HTTP.Auth.SigV4 != nil {
+		addRef(managedService.Spec.HTTP.Auth.SigV4.AccessKeyIDRef)
+		addRef(managedService.Spec.HTTP.Auth.SigV4.SecretAccessKeyRef)
+		addRef(managedService.Spec.HTTP.Auth.SigV4.SessionTokenRef)
+	}
 	if managedService.Spec.HTTP.TLS != nil {
 		addRef(managedService.Spec.HTTP.TLS.CACertRef)
 		addRef(managedService.Spec.HTTP.TLS.ClientCertRef)
@@ -179,6 +179,10 @@ func (r *Orchestrator) applyDesiredState(
 		return resolvedResource, nil
 	}
 
+	resolvedResource.Version = remoteVersionForMutation(resourceMd, remoteValue)
+	if err := attachUpdatePatch(&resolvedResource, resourceMd, localForCompare, remoteForCompare); err != nil {
+		return resource.Resource{}, err
+	}
 	return r.executeRemoteMutation(ctx, resolvedResource, resourceMd, metadata.OperationUpdate)
 }
 
@@ -210,6 +214,17 @@ func (r *Orchestrator) Delete(ctx context.Context, logicalPath string, _ orchest
 	if err != nil {
 		return err
 	}
+	// When metadata declares a version source, read the remote first, as
+	// updates do, so the delete carries its version as a precondition.
+	if resourceMd.ReadsVersionBeforeDelete() {
+		remoteValue, fetchErr := r.fetchRemoteValue(ctx, resolvedResource, resourceMd)
+		if fetchErr != nil && !faults.IsCategory(fetchErr, faults.NotFoundError) {
+			return fetchErr
+		}
+		if fetchErr == nil {
+			resolvedResource.Version = remoteVersionForMutation(resourceMd, remoteValue)
+		}
+	}
 
 	deleteErr := serverManager.Delete(ctx, resolvedResource, resourceMd)
 	if deleteErr == nil {
@@ -259,6 +274,7 @@ func (r *Orchestrator) Delete(ctx context.Context, logicalPath string, _ orchest
 	}
 	resolvedResource.LocalAlias = localAlias
 	resolvedResource.RemoteID = remoteID
+	resolvedResource.Version = remoteVersionForMutation(resourceMd, remoteValue)
 
 	return serverManager.Delete(ctx, resolvedResource, resourceMd)
 }
@@ -383,7 +399,7 @@ func (r *Orchestrator) DiffDocument(ctx context.Context, logicalPath string) (re
 		return resourcediffapp.Document{}, err
 	}
 
-	items := buildDiffEntries(resolvedResource.LogicalPath, localTransformed, remoteTransformed)
+	items := buildDiffEntries(resolvedResource.LogicalPath, localTransformed, remoteTransformed, resourceMd.ArrayAttributes)
 	sort.Slice(items, func(i int, j int) bool {
 		if items[i].ResourcePath == items[j].ResourcePath {
 			if items[i].Path == items[j].Path {
@@ -484,6 +500,12 @@ func (r *Orchestrator) resolveComparedPayloads(
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(resourceMd.ArrayAttributes) > 0 && remoteTransformed != nil {
+		localTransformed, err = metadata.AlignUnorderedArrays(localTransformed, remoteTransformed, resourceMd.ArrayAttributes)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
 	return localTransformed, remoteTransformed, nil
 }
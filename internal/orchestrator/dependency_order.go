@@ -0,0 +1,48 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/orchestrator"
+	"github.com/crmarques/declarest/resource"
+)
+
+// OrderByDependencies orders targets by path hierarchy and by the dependsOn
+// entries of their resolved metadata, rendered against each target payload.
+// Dependencies on paths outside targets are assumed to already exist.
+func (r *Orchestrator) OrderByDependencies(
+	ctx context.Context,
+	targets []resource.Resource,
+) (orchestrator.DependencyOrder, error) {
+	return orchestrator.NewDependencyOrder(targets, func(target resource.Resource) ([]string, error) {
+		resolvedMetadata, err := r.resolveMetadataForPath(ctx, target.LogicalPath, true)
+		if err != nil {
+			return nil, err
+		}
+		dependsOn, err := metadata.ResolveDependsOn(resolvedMetadata, target.Payload)
+		if err != nil {
+			return nil, faults.Invalid(
+				fmt.Sprintf("failed to resolve dependencies for %q", target.LogicalPath),
+				err,
+			)
+		}
+		return dependsOn, nil
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+	metadatadomain "github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+func dependencyOrderPaths(items []resource.Resource) []string {
+	paths := make([]string, 0, len(items))
+	for _, item := range items {
+		paths = append(paths, item.LogicalPath)
+	}
+	return paths
+}
+
+func TestOrchestratorOrderByDependenciesUsesMetadataDependsOn(t *testing.T) {
+	t.Parallel()
+
+	orchestrator := &Orchestrator{
+		metadata: &fakeMetadata{
+			resolveValues: map[string]metadatadomain.ResourceMetadata{
+				"/clients/app": {DependsOn: []string{"/realms/{{/realm}}"}},
+				"/roles/admin": {DependsOn: []string{"/clients/app", "/groups/missing"}},
+			},
+		},
+	}
+	targets := []resource.Resource{
+		{LogicalPath: "/clients/app", Payload: map[string]any{"realm": "master"}},
+		{LogicalPath: "/realms/master"},
+		{LogicalPath: "/realms/master/users/alice"},
+		{LogicalPath: "/roles/admin"},
+	}
+
+	order, err := orchestrator.OrderByDependencies(context.Background(), targets)
+	if err != nil {
+		t.Fatalf("OrderByDependencies returned error: %v", err)
+	}
+
+	expected := []string{"/realms/master", "/clients/app", "/realms/master/users/alice", "/roles/admin"}
+	if got := dependencyOrderPaths(order.Targets); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected order %#v, got %#v", expected, got)
+	}
+	if !reflect.DeepEqual(order.Levels, [][]int{{0}, {1, 2}, {3}}) {
+		t.Fatalf("unexpected dependency levels %#v", order.Levels)
+	}
+
+	reversed := order.Reverse()
+	expectedReverse := []string{"/roles/admin", "/clients/app", "/realms/master/users/alice", "/realms/master"}
+	if got := dependencyOrderPaths(reversed.Targets); !reflect.DeepEqual(got, expectedReverse) {
+		t.Fatalf("expected reverse order %#v, got %#v", expectedReverse, got)
+	}
+}
+
+func TestOrchestratorOrderByDependenciesReportsCycle(t *testing.T) {
+	t.Parallel()
+
+	orchestrator := &Orchestrator{
+		metadata: &fakeMetadata{
+			resolveValues: map[string]metadatadomain.ResourceMetadata{
+				"/a": {DependsOn: []string{"/b"}},
+				"/b": {DependsOn: []string{"/c"}},
+				"/c": {DependsOn: []string{"/a"}},
+			},
+		},
+	}
+	targets := []resource.Resource{{LogicalPath: "/a"}, {LogicalPath: "/b"}, {LogicalPath: "/c"}, {LogicalPath: "/d"}}
+
+	_, err := orchestrator.OrderByDependencies(context.Background(), targets)
+	assertTypedCategory(t, err, faults.ValidationError)
+	if !strings.Contains(err.Error(), "dependency cycle detected: /a -> /b -> /c -> /a") {
+		t.Fatalf("expected cycle path in error, got %v", err)
+	}
+}
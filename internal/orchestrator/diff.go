@@ -92,13 +92,35 @@ func applyCompareJQ(value resource.Value, expression string) (resource.Value, er
 	}
 }
 
-func buildDiffEntries(logicalPath string, local resource.Value, remote resource.Value) []resource.DiffEntry {
+// buildDiffEntries lists the differences between local and remote. Arrays
+// declared in arrays with merge keys, sortBy, or as unordered are compared
+// element by element by identity instead of by position.
+func buildDiffEntries(
+	logicalPath string,
+	local resource.Value,
+	remote resource.Value,
+	arrays []metadata.ArrayAttribute,
+) []resource.DiffEntry {
+	keyedArrays := make(map[string]metadata.ArrayAttribute, len(arrays))
+	for _, item := range arrays {
+		if item.MatchesElementsByKey() {
+			keyedArrays[strings.TrimSpace(item.Path)] = item
+		}
+	}
+
 	entries := make([]resource.DiffEntry, 0)
-	collectDiffEntries(&entries, logicalPath, "", local, remote)
+	collectDiffEntries(&entries, logicalPath, "", local, remote, keyedArrays)
 	return entries
 }
 
-func collectDiffEntries(entries *[]resource.DiffEntry, logicalPath string, pointer string, local any, remote any) {
+func collectDiffEntries(
+	entries *[]resource.DiffEntry,
+	logicalPath string,
+	pointer string,
+	local any,
+	remote any,
+	keyedArrays map[string]metadata.ArrayAttribute,
+) {
 	if reflect.DeepEqual(local, remote) {
 		return
 	}
@@ -135,7 +157,7 @@ func collectDiffEntries(entries *[]resource.DiffEntry, logicalPath string, point
 			case !remoteFound:
 				appendDiffEntry(entries, logicalPath, nextPointer, "remove", localValue, nil)
 			default:
-				collectDiffEntries(entries, logicalPath, nextPointer, localValue, remoteValue)
+				collectDiffEntries(entries, logicalPath, nextPointer, localValue, remoteValue, keyedArrays)
 			}
 		}
 		return
@@ -144,6 +166,11 @@ func collectDiffEntries(entries *[]resource.DiffEntry, logicalPath string, point
 	localArray, localIsArray := local.([]any)
 	remoteArray, remoteIsArray := remote.([]any)
 	if localIsArray && remoteIsArray {
+		if item, found := keyedArrays[pointer]; found &&
+			collectKeyedArrayDiffEntries(entries, logicalPath, pointer, localArray, remoteArray, item, keyedArrays) {
+			return
+		}
+
 		maxLength := len(localArray)
 		if len(remoteArray) > maxLength {
 			maxLength = len(remoteArray)
@@ -158,7 +185,7 @@ func collectDiffEntries(entries *[]resource.DiffEntry, logicalPath string, point
 			case idx >= len(remoteArray):
 				appendDiffEntry(entries, logicalPath, nextPointer, "remove", localArray[idx], nil)
 			default:
-				collectDiffEntries(entries, logicalPath, nextPointer, localArray[idx], remoteArray[idx])
+				collectDiffEntries(entries, logicalPath, nextPointer, localArray[idx], remoteArray[idx], keyedArrays)
 			}
 		}
 		return
@@ -171,6 +198,106 @@ func collectDiffEntries(entries *[]resource.DiffEntry, logicalPath string, point
 	appendDiffEntry(entries, logicalPath, pointer, "replace", local, remote)
 }
 
+// collectKeyedArrayDiffEntries matches array elements by identity key.
+// Entry paths use the local index space: local-only elements are removes and
+// matched elements are compared at their local index, while remote-only
+// elements are adds numbered after the last local element, as if appended.
+// Unless the array ignores order, matched elements that changed relative
+// order are reported as moves whose Local and Remote values hold the local
+// and remote indexes. It reports false, leaving the array to positional
+// comparison, when any element lacks a merge key.
+func collectKeyedArrayDiffEntries(
+	entries *[]resource.DiffEntry,
+	logicalPath string,
+	pointer string,
+	localArray []any,
+	remoteArray []any,
+	item metadata.ArrayAttribute,
+	keyedArrays map[string]metadata.ArrayAttribute,
+) bool {
+	localKeys, localKeyed := metadata.ArrayElementKeys(item, localArray)
+	remoteKeys, remoteKeyed := metadata.ArrayElementKeys(item, remoteArray)
+	if !localKeyed || !remoteKeyed {
+		return false
+	}
+
+	remoteIndexes := make(map[string]int, len(remoteKeys))
+	for idx, key := range remoteKeys {
+		remoteIndexes[key] = idx
+	}
+
+	matchedLocal := make([]int, 0, len(localArray))
+	matchedRemote := make([]int, 0, len(localArray))
+	remoteMatched := make([]bool, len(remoteArray))
+	for localIdx, key := range localKeys {
+		nextPointer := pointer + "/" + strconv.Itoa(localIdx)
+		remoteIdx, found := remoteIndexes[key]
+		if !found {
+			appendDiffEntry(entries, logicalPath, nextPointer, "remove", localArray[localIdx], nil)
+			continue
+		}
+		remoteMatched[remoteIdx] = true
+		matchedLocal = append(matchedLocal, localIdx)
+		matchedRemote = append(matchedRemote, remoteIdx)
+		collectDiffEntries(entries, logicalPath, nextPointer, localArray[localIdx], remoteArray[remoteIdx], keyedArrays)
+	}
+	appendIdx := len(localArray)
+	for remoteIdx, matched := range remoteMatched {
+		if !matched {
+			appendDiffEntry(entries, logicalPath, pointer+"/"+strconv.Itoa(appendIdx), "add", nil, remoteArray[remoteIdx])
+			appendIdx++
+		}
+	}
+
+	if item.IgnoresOrder() {
+		return true
+	}
+	inOrder := longestIncreasingSubsequence(matchedRemote)
+	for idx, localIdx := range matchedLocal {
+		if inOrder[idx] {
+			continue
+		}
+		appendDiffEntry(
+			entries,
+			logicalPath,
+			pointer+"/"+strconv.Itoa(localIdx),
+			"move",
+			int64(localIdx),
+			int64(matchedRemote[idx]),
+		)
+	}
+	return true
+}
+
+// longestIncreasingSubsequence marks the members of one longest strictly
+// increasing subsequence of values, preferring the one ending last, so the
+// unmarked ones are the fewest elements that must move to reproduce the
+// order of values.
+func longestIncreasingSubsequence(values []int) []bool {
+	lengths := make([]int, len(values))
+	previous := make([]int, len(values))
+	best := -1
+	for idx := range values {
+		lengths[idx] = 1
+		previous[idx] = -1
+		for candidate := range idx {
+			if values[candidate] < values[idx] && lengths[candidate]+1 > lengths[idx] {
+				lengths[idx] = lengths[candidate] + 1
+				previous[idx] = candidate
+			}
+		}
+		if best < 0 || lengths[idx] >= lengths[best] {
+			best = idx
+		}
+	}
+
+	members := make([]bool, len(values))
+	for idx := best; idx >= 0; idx = previous[idx] {
+		members[idx] = true
+	}
+	return members
+}
+
 // scalarsNumericallyEqual reports whether two normalized scalars represent the
 // same number across the int64/float64 split that resource.Normalize can
 // produce (for example a local YAML integer 1 stored as int64 versus a remote
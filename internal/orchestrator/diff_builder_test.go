@@ -14,7 +14,13 @@
 
 package orchestrator
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
 
 func TestBuildDiffEntriesUsesResourcePathAndJSONPointerPaths(t *testing.T) {
 	t.Parallel()
@@ -37,6 +43,7 @@ func TestBuildDiffEntriesUsesResourcePathAndJSONPointerPaths(t *testing.T) {
 				map[string]any{"name": "new"},
 			},
 		},
+		nil,
 	)
 
 	if len(items) != 2 {
@@ -75,6 +82,7 @@ func TestBuildDiffEntriesTreatsIntegerAndFloatScalarsAsEqual(t *testing.T) {
 			"count": float64(1),
 			"ratio": int64(2),
 		},
+		nil,
 	)
 
 	if len(items) != 0 {
@@ -89,6 +97,7 @@ func TestBuildDiffEntriesReportsRealNumericDifference(t *testing.T) {
 		"/customers/acme",
 		map[string]any{"count": int64(1)},
 		map[string]any{"count": float64(1.5)},
+		nil,
 	)
 
 	if len(items) != 1 {
@@ -102,7 +111,7 @@ func TestBuildDiffEntriesReportsRealNumericDifference(t *testing.T) {
 func TestBuildDiffEntriesRootReplaceUsesEmptyPointer(t *testing.T) {
 	t.Parallel()
 
-	items := buildDiffEntries("/customers/acme", map[string]any{"id": "42"}, nil)
+	items := buildDiffEntries("/customers/acme", map[string]any{"id": "42"}, nil, nil)
 	if len(items) != 1 {
 		t.Fatalf("expected one root replace entry, got %#v", items)
 	}
@@ -116,3 +125,76 @@ func TestBuildDiffEntriesRootReplaceUsesEmptyPointer(t *testing.T) {
 		t.Fatalf("expected replace operation, got %#v", items[0].Operation)
 	}
 }
+
+func TestBuildDiffEntriesMatchesKeyedArrayElements(t *testing.T) {
+	t.Parallel()
+
+	local := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"name": "email", "claim": "mail"},
+			map[string]any{"name": "audience"},
+			map[string]any{"name": "local-only"},
+		},
+	}
+	remote := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"name": "remote-only"},
+			map[string]any{"name": "audience"},
+			map[string]any{"name": "email", "claim": "email"},
+		},
+	}
+
+	items := buildDiffEntries("/clients/web", local, remote, []metadata.ArrayAttribute{
+		{Path: "/protocolMappers", MergeKeys: []string{"/name"}},
+	})
+	want := []resource.DiffEntry{
+		{ResourcePath: "/clients/web", Path: "/protocolMappers/0/claim", Operation: "replace", Local: "mail", Remote: "email"},
+		{ResourcePath: "/clients/web", Path: "/protocolMappers/2", Operation: "remove", Local: map[string]any{"name": "local-only"}},
+		{ResourcePath: "/clients/web", Path: "/protocolMappers/3", Operation: "add", Remote: map[string]any{"name": "remote-only"}},
+		{ResourcePath: "/clients/web", Path: "/protocolMappers/0", Operation: "move", Local: int64(0), Remote: int64(2)},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("unexpected keyed diff entries: got %#v want %#v", items, want)
+	}
+}
+
+func TestBuildDiffEntriesIgnoresReorderingOfUnorderedArrays(t *testing.T) {
+	t.Parallel()
+
+	arrays := []metadata.ArrayAttribute{
+		{Path: "/protocolMappers", MergeKeys: []string{"/name"}, Unordered: true},
+		{Path: "/redirectUris", Unordered: true},
+	}
+	items := buildDiffEntries(
+		"/clients/web",
+		map[string]any{
+			"protocolMappers": []any{map[string]any{"name": "email"}, map[string]any{"name": "audience"}},
+			"redirectUris":    []any{"https://a", "https://b"},
+		},
+		map[string]any{
+			"protocolMappers": []any{map[string]any{"name": "audience"}, map[string]any{"name": "email"}},
+			"redirectUris":    []any{"https://b", "https://a", "https://c"},
+		},
+		arrays,
+	)
+	want := []resource.DiffEntry{
+		{ResourcePath: "/clients/web", Path: "/redirectUris/2", Operation: "add", Remote: "https://c"},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("unexpected unordered diff entries: got %#v want %#v", items, want)
+	}
+}
+
+func TestBuildDiffEntriesFallsBackToPositionalWhenMergeKeyMissing(t *testing.T) {
+	t.Parallel()
+
+	items := buildDiffEntries(
+		"/clients/web",
+		map[string]any{"protocolMappers": []any{map[string]any{"id": "1"}}},
+		map[string]any{"protocolMappers": []any{map[string]any{"id": "2"}}},
+		[]metadata.ArrayAttribute{{Path: "/protocolMappers", MergeKeys: []string{"/name"}}},
+	)
+	if len(items) != 1 || items[0].Path != "/protocolMappers/0/id" || items[0].Operation != "replace" {
+		t.Fatalf("expected positional replace at /protocolMappers/0/id, got %#v", items)
+	}
+}
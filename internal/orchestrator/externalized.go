@@ -41,6 +41,12 @@ func (r *Orchestrator) saveLocalResource(
 	}
 
 	content = r.applyDefaultFormat(content, resolvedMetadata)
+	if len(resolvedMetadata.ArrayAttributes) > 0 {
+		content.Value, err = metadata.SortArrayAttributes(content.Value, resolvedMetadata.ArrayAttributes)
+		if err != nil {
+			return err
+		}
+	}
 
 	entries, err := metadata.ResolveExternalizedAttributes(resolvedMetadata)
 	if err != nil {
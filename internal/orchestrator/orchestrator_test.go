@@ -310,6 +310,54 @@ spec:
 	}
 }
 
+func TestOrchestratorSaveThenDiffIgnoresSortedArrayOrder(t *testing.T) {
+	t.Parallel()
+
+	remote := map[string]any{
+		"id": "acme",
+		"protocolMappers": []any{
+			map[string]any{"name": "email"},
+			map[string]any{"name": "audience"},
+		},
+	}
+	serverManager := &fakeServer{getValue: remote}
+	orchestrator := &Orchestrator{
+		repository: fsstore.NewLocalResourceRepository(t.TempDir()),
+		metadata: &fakeMetadata{resolveValue: metadatadomain.ResourceMetadata{
+			ArrayAttributes: []metadatadomain.ArrayAttribute{
+				{Path: "/protocolMappers", SortBy: []string{"/name"}},
+			},
+		}},
+		server: serverManager,
+	}
+
+	if err := orchestrator.Save(context.Background(), "/customers/acme", testContent(remote)); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	local, err := orchestrator.GetLocal(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("GetLocal returned error: %v", err)
+	}
+	if first := local.Value.(map[string]any)["protocolMappers"].([]any)[0]; !reflect.DeepEqual(first, map[string]any{"name": "audience"}) {
+		t.Fatalf("expected saved array sorted by name, got first element %#v", first)
+	}
+
+	items, err := orchestrator.Diff(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected no diff after save when only remote order differs, got %#v", items)
+	}
+
+	if _, err := orchestrator.Apply(context.Background(), "/customers/acme", orch.ApplyPolicy{}); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if serverManager.updateCalled {
+		t.Fatal("expected apply to skip the update when only remote order differs")
+	}
+}
+
 func TestOrchestratorDeleteDelegatesToServer(t *testing.T) {
 	t.Parallel()
 
@@ -1725,6 +1773,180 @@ func TestOrchestratorDeleteRetriesWithResolvedRemoteIdentityAfterNotFound(t *tes
 	}
 }
 
+func TestOrchestratorApplyReplaysRemoteVersionOnUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		md       metadatadomain.ResourceMetadata
+		remote   map[string]any
+		expected resource.Version
+	}{
+		{
+			name:     "version_attribute",
+			md:       metadatadomain.ResourceMetadata{VersionAttribute: "/version"},
+			remote:   map[string]any{"name": "old", "version": 7},
+			expected: resource.Version{ETag: `"7"`},
+		},
+		{
+			name:     "missing_version_attribute_keeps_response_version",
+			md:       metadatadomain.ResourceMetadata{VersionAttribute: "/meta/revision"},
+			remote:   map[string]any{"name": "old"},
+			expected: resource.Version{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := &fakeServer{getValue: tc.remote}
+			orchestrator := &Orchestrator{
+				repository: &fakeRepository{getValue: map[string]any{"name": "new"}},
+				metadata:   &fakeMetadata{resolveValue: tc.md},
+				server:     server,
+			}
+
+			if _, err := orchestrator.Apply(context.Background(), "/customers/acme", orch.ApplyPolicy{}); err != nil {
+				t.Fatalf("Apply returned error: %v", err)
+			}
+			if !server.updateCalled {
+				t.Fatal("expected update call")
+			}
+			if server.lastResource.Version != tc.expected {
+				t.Fatalf("expected update version %#v, got %#v", tc.expected, server.lastResource.Version)
+			}
+		})
+	}
+}
+
+func TestOrchestratorDeleteReadsVersionAttributeBeforeDelete(t *testing.T) {
+	t.Parallel()
+
+	serverManager := &fakeServer{
+		getValue: map[string]any{"id": "acme", "etag": `"abc"`},
+	}
+	orchestrator := &Orchestrator{
+		server: serverManager,
+		metadata: &fakeMetadata{
+			resolveValue: metadatadomain.ResourceMetadata{VersionAttribute: "/etag"},
+		},
+	}
+
+	if err := orchestrator.Delete(context.Background(), "/customers/acme", orch.DeletePolicy{}); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if !serverManager.getCalled {
+		t.Fatal("expected a remote read before delete")
+	}
+	if got := serverManager.lastResource.Version.ETag; got != `"abc"` {
+		t.Fatalf("expected delete to carry ETag %q, got %q", `"abc"`, got)
+	}
+}
+
+func TestOrchestratorDeleteSendsCapturedETagPreconditionWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	testCases := []struct {
+		name        string
+		md          metadatadomain.ResourceMetadata
+		wantMethods []string
+		wantIfMatch string
+	}{
+		{
+			name:        "conditional_delete",
+			md:          metadatadomain.ResourceMetadata{ConditionalDelete: &enabled},
+			wantMethods: []string{http.MethodGet, http.MethodDelete},
+			wantIfMatch: `"v7"`,
+		},
+		{
+			name:        "no_version_source",
+			wantMethods: []string{http.MethodDelete},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var methods []string
+			var ifMatch string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+				switch r.Method {
+				case http.MethodGet:
+					w.Header().Set("ETag", `"v7"`)
+					_, _ = fmt.Fprint(w, `{"id":"acme"}`)
+				case http.MethodDelete:
+					ifMatch = r.Header.Get("If-Match")
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			client, err := managedservicehttp.NewClient(config.HTTPServer{
+				BaseURL: server.URL,
+				Auth: &config.HTTPAuth{
+					CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			orchestrator := &Orchestrator{
+				metadata: &fakeMetadata{resolveValue: tc.md},
+				server:   client,
+			}
+
+			if err := orchestrator.Delete(context.Background(), "/customers/acme", orch.DeletePolicy{}); err != nil {
+				t.Fatalf("Delete returned error: %v", err)
+			}
+			if !reflect.DeepEqual(methods, tc.wantMethods) {
+				t.Fatalf("expected methods %#v, got %#v", tc.wantMethods, methods)
+			}
+			if ifMatch != tc.wantIfMatch {
+				t.Fatalf("expected delete If-Match %q, got %q", tc.wantIfMatch, ifMatch)
+			}
+		})
+	}
+}
+
+func TestOrchestratorCreateReadsFinalStateAfterAsyncMutation(t *testing.T) {
+	t.Parallel()
+
+	server := &fakeServer{
+		getValue: map[string]any{"id": "acme", "status": "provisioned"},
+	}
+	orchestrator := &Orchestrator{
+		metadata: &fakeMetadata{
+			resolveValue: metadatadomain.ResourceMetadata{
+				Operations: map[string]metadatadomain.OperationSpec{
+					string(metadatadomain.OperationCreate): {
+						Async: &metadatadomain.AsyncSpec{CompletedWhen: `.state == "done"`},
+					},
+				},
+			},
+		},
+		server: server,
+	}
+
+	item, err := orchestrator.Create(context.Background(), "/customers/acme", testContent(map[string]any{"id": "acme"}))
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if !server.getCalled {
+		t.Fatal("expected remote read after asynchronous create")
+	}
+	payload, ok := item.Payload.(map[string]any)
+	if !ok || payload["status"] != "provisioned" {
+		t.Fatalf("expected final remote state, got %#v", item.Payload)
+	}
+}
+
 func TestOrchestratorRequestDelegatesToServer(t *testing.T) {
 	t.Parallel()
 
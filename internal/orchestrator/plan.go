@@ -0,0 +1,147 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	resourceplanapp "github.com/crmarques/declarest/internal/app/resource/plan"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// PlanApply computes what Apply would do for logicalPath without mutating
+// remote state.
+func (r *Orchestrator) PlanApply(ctx context.Context, logicalPath string) (resourceplanapp.Item, error) {
+	localResource, err := r.resolveLocalResourceForRead(ctx, logicalPath)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+
+	resolvedResource, resourceMd, err := r.prepareResourceForRemote(ctx, localResource.LogicalPath, contentFromResource(localResource))
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+
+	action := resourceplanapp.ActionUpdate
+	operation := metadata.OperationUpdate
+	remoteValue, err := r.fetchRemoteValue(ctx, resolvedResource, resourceMd)
+	if err != nil {
+		if !faults.IsCategory(err, faults.NotFoundError) {
+			return resourceplanapp.Item{}, err
+		}
+		action = resourceplanapp.ActionCreate
+		operation = metadata.OperationCreate
+		remoteValue = resource.Content{}
+	}
+
+	localForCompare, remoteForCompare, err := r.resolveComparedPayloads(
+		ctx,
+		resolvedResource,
+		resourceMd,
+		contentFromResource(resolvedResource),
+		remoteValue,
+	)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	if resolvedRemoteID, ok := resolvedRemoteIDFromPayload(resourceMd, remoteValue.Value); ok {
+		resolvedResource.RemoteID = resolvedRemoteID
+	}
+
+	localDigest, err := resourceplanapp.LocalDigest(contentFromResource(resolvedResource))
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	remoteDigest, err := resourceplanapp.RemoteDigest(remoteValue)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	item := resourceplanapp.Item{
+		LogicalPath:  resolvedResource.LogicalPath,
+		Action:       action,
+		LocalDigest:  localDigest,
+		RemoteDigest: remoteDigest,
+	}
+	if action == resourceplanapp.ActionUpdate && reflect.DeepEqual(localForCompare, remoteForCompare) {
+		item.Action = resourceplanapp.ActionNoop
+		return item, nil
+	}
+
+	spec, err := r.renderOperationSpec(ctx, resolvedResource, resourceMd, operation, resolvedResource.Payload)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	if operation == metadata.OperationUpdate {
+		override := resourceMd.Operations[string(metadata.OperationUpdate)]
+		_, methodOverridden := metadata.OperationHTTPMethodOverride(ctx, operation)
+		metadata.ApplyUpdateStrategyDefaults(
+			&spec,
+			methodOverridden || strings.TrimSpace(override.Method) != "",
+			strings.TrimSpace(override.ContentType) != "",
+		)
+	}
+	item.Method = spec.Method
+	item.RemotePath = spec.Path
+	item.Diff = buildDiffEntries(resolvedResource.LogicalPath, localForCompare, remoteForCompare, resourceMd.ArrayAttributes)
+	sort.Slice(item.Diff, func(i int, j int) bool {
+		if item.Diff[i].Path == item.Diff[j].Path {
+			return item.Diff[i].Operation < item.Diff[j].Operation
+		}
+		return item.Diff[i].Path < item.Diff[j].Path
+	})
+	return item, nil
+}
+
+// PlanDelete computes the remote delete that pruning logicalPath would issue.
+// A resource that is already gone remotely is planned as a no-op.
+func (r *Orchestrator) PlanDelete(ctx context.Context, logicalPath string) (resourceplanapp.Item, error) {
+	resolvedResource, resourceMd, err := r.buildResourceInfoForRemoteRead(ctx, logicalPath)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+
+	item := resourceplanapp.Item{LogicalPath: resolvedResource.LogicalPath}
+	remoteValue, err := r.fetchRemoteValue(ctx, resolvedResource, resourceMd)
+	if err != nil {
+		if !faults.IsCategory(err, faults.NotFoundError) {
+			return resourceplanapp.Item{}, err
+		}
+		item.Action = resourceplanapp.ActionNoop
+		return item, nil
+	}
+	if resolvedRemoteID, ok := resolvedRemoteIDFromPayload(resourceMd, remoteValue.Value); ok {
+		resolvedResource.RemoteID = resolvedRemoteID
+	}
+
+	remoteDigest, err := resourceplanapp.RemoteDigest(remoteValue)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+	spec, err := r.renderOperationSpec(ctx, resolvedResource, resourceMd, metadata.OperationDelete, remoteValue.Value)
+	if err != nil {
+		return resourceplanapp.Item{}, err
+	}
+
+	item.Action = resourceplanapp.ActionDelete
+	item.Method = spec.Method
+	item.RemotePath = spec.Path
+	item.RemoteDigest = remoteDigest
+	return item, nil
+}
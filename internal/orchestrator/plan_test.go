@@ -0,0 +1,211 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+	resourceplanapp "github.com/crmarques/declarest/internal/app/resource/plan"
+	metadatadomain "github.com/crmarques/declarest/metadata"
+)
+
+func planTestMetadata() metadatadomain.ResourceMetadata {
+	return metadatadomain.ResourceMetadata{
+		ID:    "{{/id}}",
+		Alias: "{{/alias}}",
+		Operations: map[string]metadatadomain.OperationSpec{
+			string(metadatadomain.OperationGet):     {Path: "/api/customers/{{/id}}"},
+			string(metadatadomain.OperationCreate):  {Method: "POST", Path: "/api/customers"},
+			string(metadatadomain.OperationUpdate):  {Method: "PUT", Path: "/api/customers/{{/id}}"},
+			string(metadatadomain.OperationDelete):  {Method: "DELETE", Path: "/api/customers/{{/id}}"},
+			string(metadatadomain.OperationList):    {Path: "/api/customers"},
+			string(metadatadomain.OperationCompare): {Transforms: suppressMutation("/updatedAt")},
+		},
+	}
+}
+
+func TestOrchestratorPlanApplyClassifiesActions(t *testing.T) {
+	t.Parallel()
+
+	local := map[string]any{"id": "42", "alias": "acme", "tier": "gold"}
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		serverManager := &fakeServer{getErr: faults.NotFound("resource not found", nil)}
+		orchestrator := &Orchestrator{
+			repository: &fakeRepository{getValue: local},
+			metadata:   &fakeMetadata{resolveValue: planTestMetadata()},
+			server:     serverManager,
+		}
+
+		item, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+		if err != nil {
+			t.Fatalf("PlanApply returned error: %v", err)
+		}
+		if item.Action != resourceplanapp.ActionCreate || item.Method != "POST" || item.RemotePath != "/api/customers" {
+			t.Fatalf("unexpected create plan item: %#v", item)
+		}
+		if item.RemoteDigest != "" {
+			t.Fatalf("expected empty remote digest for missing resource, got %q", item.RemoteDigest)
+		}
+		if len(item.Diff) != 1 {
+			t.Fatalf("expected one whole-resource diff entry, got %#v", item.Diff)
+		}
+		if serverManager.createCalled || serverManager.updateCalled {
+			t.Fatal("expected PlanApply not to mutate remote state")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		serverManager := &fakeServer{getValue: map[string]any{"id": "42", "alias": "acme", "tier": "silver"}}
+		orchestrator := &Orchestrator{
+			repository: &fakeRepository{getValue: local},
+			metadata:   &fakeMetadata{resolveValue: planTestMetadata()},
+			server:     serverManager,
+		}
+
+		item, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+		if err != nil {
+			t.Fatalf("PlanApply returned error: %v", err)
+		}
+		if item.Action != resourceplanapp.ActionUpdate || item.Method != "PUT" || item.RemotePath != "/api/customers/42" {
+			t.Fatalf("unexpected update plan item: %#v", item)
+		}
+		if item.RemoteDigest == "" {
+			t.Fatal("expected remote digest for existing resource")
+		}
+		if len(item.Diff) != 1 || item.Diff[0].Path != "/tier" {
+			t.Fatalf("expected /tier diff entry, got %#v", item.Diff)
+		}
+		if serverManager.updateCalled {
+			t.Fatal("expected PlanApply not to mutate remote state")
+		}
+	})
+
+	t.Run("no-op", func(t *testing.T) {
+		t.Parallel()
+
+		orchestrator := &Orchestrator{
+			repository: &fakeRepository{getValue: local},
+			metadata:   &fakeMetadata{resolveValue: planTestMetadata()},
+			server: &fakeServer{getValue: map[string]any{
+				"id":        "42",
+				"alias":     "acme",
+				"tier":      "gold",
+				"updatedAt": "2026-03-02T18:15:00Z",
+			}},
+		}
+
+		item, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+		if err != nil {
+			t.Fatalf("PlanApply returned error: %v", err)
+		}
+		if item.Action != resourceplanapp.ActionNoop || item.Method != "" || len(item.Diff) != 0 {
+			t.Fatalf("unexpected no-op plan item: %#v", item)
+		}
+	})
+}
+
+func TestOrchestratorPlanApplyDigestTracksRemoteChanges(t *testing.T) {
+	t.Parallel()
+
+	serverManager := &fakeServer{getValue: map[string]any{"id": "42", "alias": "acme", "tier": "silver"}}
+	orchestrator := &Orchestrator{
+		repository: &fakeRepository{getValue: map[string]any{"id": "42", "alias": "acme", "tier": "gold"}},
+		metadata:   &fakeMetadata{resolveValue: planTestMetadata()},
+		server:     serverManager,
+	}
+
+	first, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("PlanApply returned error: %v", err)
+	}
+	second, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("PlanApply returned error: %v", err)
+	}
+	if first.RemoteDigest != second.RemoteDigest {
+		t.Fatalf("expected stable digest, got %q and %q", first.RemoteDigest, second.RemoteDigest)
+	}
+
+	serverManager.getValue = map[string]any{"id": "42", "alias": "acme", "tier": "bronze"}
+	changed, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("PlanApply returned error: %v", err)
+	}
+	if changed.RemoteDigest == first.RemoteDigest {
+		t.Fatal("expected digest to change with remote state")
+	}
+}
+
+func TestOrchestratorPlanApplyDigestTracksLocalChanges(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{getValue: map[string]any{"id": "42", "alias": "acme", "tier": "gold"}}
+	orchestrator := &Orchestrator{
+		repository: repo,
+		metadata:   &fakeMetadata{resolveValue: planTestMetadata()},
+		server:     &fakeServer{getValue: map[string]any{"id": "42", "alias": "acme", "tier": "gold"}},
+	}
+
+	first, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("PlanApply returned error: %v", err)
+	}
+	if first.Action != resourceplanapp.ActionNoop || first.LocalDigest == "" {
+		t.Fatalf("expected no-op item with local digest, got %#v", first)
+	}
+
+	repo.getValue = map[string]any{"id": "42", "alias": "acme", "tier": "platinum"}
+	changed, err := orchestrator.PlanApply(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("PlanApply returned error: %v", err)
+	}
+	if changed.LocalDigest == first.LocalDigest {
+		t.Fatal("expected local digest to change with the repository payload")
+	}
+	if changed.RemoteDigest != first.RemoteDigest {
+		t.Fatal("expected remote digest to stay unchanged")
+	}
+}
+
+func TestOrchestratorPlanDeleteRendersDeleteOperation(t *testing.T) {
+	t.Parallel()
+
+	serverManager := &fakeServer{getValue: map[string]any{"id": "42", "alias": "acme"}}
+	orchestrator := &Orchestrator{
+		metadata: &fakeMetadata{resolveValue: planTestMetadata()},
+		server:   serverManager,
+	}
+
+	item, err := orchestrator.PlanDelete(context.Background(), "/customers/acme")
+	if err != nil {
+		t.Fatalf("PlanDelete returned error: %v", err)
+	}
+	if item.Action != resourceplanapp.ActionDelete || item.Method != "DELETE" || item.RemotePath != "/api/customers/42" {
+		t.Fatalf("unexpected delete plan item: %#v", item)
+	}
+	if item.RemoteDigest == "" {
+		t.Fatal("expected remote digest for planned delete")
+	}
+	if serverManager.deleteCalled {
+		t.Fatal("expected PlanDelete not to mutate remote state")
+	}
+}
@@ -17,6 +17,7 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/crmarques/declarest/faults"
 	"github.com/crmarques/declarest/metadata"
@@ -40,6 +41,12 @@ func (r *Orchestrator) executeRemoteMutation(
 	case metadata.OperationCreate:
 		remotePayload, err = serverManager.Create(ctx, resolvedResource, md)
 	case metadata.OperationUpdate:
+		if resolvedResource.Patch == nil {
+			resolvedResource, err = r.resolveUpdatePatch(ctx, resolvedResource, md)
+			if err != nil {
+				return resource.Resource{}, err
+			}
+		}
 		remotePayload, err = serverManager.Update(ctx, resolvedResource, md)
 	default:
 		return resource.Resource{}, faults.NewTypedError(
@@ -51,6 +58,14 @@ func (r *Orchestrator) executeRemoteMutation(
 	if err != nil {
 		return resource.Resource{}, err
 	}
+	if remotePayload.Value == nil && md.Operations[string(operation)].Async != nil {
+		// A completed asynchronous mutation returns no resource payload; read
+		// the final remote state so refresh and dependents see what was applied.
+		remotePayload, err = r.fetchRemoteValue(ctx, resolvedResource, md)
+		if err != nil {
+			return resource.Resource{}, err
+		}
+	}
 
 	payload := resolvedResource.Payload
 	descriptor := resolvedResource.PayloadDescriptor
@@ -65,9 +80,34 @@ func (r *Orchestrator) executeRemoteMutation(
 
 	resolvedResource.Payload = normalizedPayload
 	resolvedResource.PayloadDescriptor = descriptor
+	resolvedResource.Patch = nil
 	return resolvedResource, nil
 }
 
+// remoteVersionForMutation selects the concurrency token replayed on an
+// update or delete of a resource whose remote state was just read. A metadata
+// versionAttribute found in the payload takes precedence over the response
+// ETag/Last-Modified headers.
+func remoteVersionForMutation(md metadata.ResourceMetadata, remoteValue resource.Content) resource.Version {
+	pointer := strings.TrimSpace(md.VersionAttribute)
+	if pointer == "" {
+		return remoteValue.Version
+	}
+	value, found, err := resource.LookupJSONPointerString(remoteValue.Value, pointer)
+	if err != nil || !found || strings.TrimSpace(value) == "" {
+		return remoteValue.Version
+	}
+	return resource.Version{ETag: quoteEntityTag(value)}
+}
+
+func quoteEntityTag(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, `"`) {
+		return trimmed
+	}
+	return `"` + trimmed + `"`
+}
+
 func (r *Orchestrator) resolvePayloadForRemote(
 	ctx context.Context,
 	logicalPath string,
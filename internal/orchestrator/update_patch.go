@@ -0,0 +1,163 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// resolveUpdatePatch reads the remote state of resolvedResource and attaches
+// the patch document its update strategy sends. Resources whose update
+// strategy is replace are returned unchanged.
+func (r *Orchestrator) resolveUpdatePatch(
+	ctx context.Context,
+	resolvedResource resource.Resource,
+	md metadata.ResourceMetadata,
+) (resource.Resource, error) {
+	if !metadata.IsPatchUpdateStrategy(metadata.ResolveUpdateStrategy(md)) {
+		return resolvedResource, nil
+	}
+
+	remoteValue, err := r.fetchRemoteValue(ctx, resolvedResource, md)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	desired, remote, err := r.resolveComparedPayloads(
+		ctx,
+		resolvedResource,
+		md,
+		contentFromResource(resolvedResource),
+		remoteValue,
+	)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	if resolvedRemoteID, ok := resolvedRemoteIDFromPayload(md, remoteValue.Value); ok {
+		resolvedResource.RemoteID = resolvedRemoteID
+	}
+	if resolvedResource.Version.IsZero() {
+		resolvedResource.Version = remoteVersionForMutation(md, remoteValue)
+	}
+
+	if err := attachUpdatePatch(&resolvedResource, md, desired, remote); err != nil {
+		return resource.Resource{}, err
+	}
+	return resolvedResource, nil
+}
+
+// attachUpdatePatch sets resolvedResource.Patch to the delta between the
+// compare-transformed remote and desired payloads, so that fields hidden by
+// compare transforms (typically server-managed ones) are never sent.
+func attachUpdatePatch(
+	resolvedResource *resource.Resource,
+	md metadata.ResourceMetadata,
+	desired resource.Value,
+	remote resource.Value,
+) error {
+	entries := buildDiffEntries(resolvedResource.LogicalPath, desired, remote, nil)
+
+	switch metadata.ResolveUpdateStrategy(md) {
+	case metadata.UpdateStrategyJSONPatch:
+		resolvedResource.Patch = buildJSONPatch(entries)
+	case metadata.UpdateStrategyMergePatch:
+		patch, err := buildMergePatch(entries, desired)
+		if err != nil {
+			return err
+		}
+		resolvedResource.Patch = patch
+	}
+	return nil
+}
+
+// buildJSONPatch converts diff entries into RFC 6902 operations. Diff entries
+// describe the remote side relative to the desired payload, so an "add" entry
+// (remote-only value) becomes a remove and a "remove" entry becomes an add.
+// Removals run last and in reverse order so array indexes stay valid.
+func buildJSONPatch(entries []resource.DiffEntry) []any {
+	operations := make([]any, 0, len(entries))
+	removals := make([]any, 0)
+	for _, entry := range entries {
+		switch entry.Operation {
+		case "add":
+			removals = append(removals, map[string]any{"op": "remove", "path": entry.Path})
+		case "remove":
+			operations = append(operations, map[string]any{"op": "add", "path": entry.Path, "value": entry.Local})
+		default:
+			operations = append(operations, map[string]any{"op": "replace", "path": entry.Path, "value": entry.Local})
+		}
+	}
+	for idx := len(removals) - 1; idx >= 0; idx-- {
+		operations = append(operations, removals[idx])
+	}
+	return operations
+}
+
+// buildMergePatch converts diff entries into an RFC 7386 merge patch.
+// Remote-only keys are set to null. Merge patches cannot address array
+// items, so any change inside an array replaces the whole desired array.
+func buildMergePatch(entries []resource.DiffEntry, desired resource.Value) (resource.Value, error) {
+	desiredObject, ok := desired.(map[string]any)
+	if !ok {
+		return desired, nil
+	}
+
+	patch := map[string]any{}
+	for _, entry := range entries {
+		tokens, err := resource.ParseJSONPointer(entry.Path)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) == 0 {
+			return desired, nil
+		}
+
+		target := tokens
+		value := entry.Local
+		if entry.Operation == "add" {
+			value = nil
+		}
+		current := any(desiredObject)
+		for idx, token := range tokens {
+			object, isObject := current.(map[string]any)
+			if !isObject {
+				target = tokens[:idx]
+				value = current
+				break
+			}
+			current = object[token]
+		}
+		setMergePatchValue(patch, target, value)
+	}
+	return patch, nil
+}
+
+func setMergePatchValue(patch map[string]any, tokens []string, value any) {
+	current := patch
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := current[token].(map[string]any)
+		if !ok {
+			if _, exists := current[token]; exists {
+				return
+			}
+			next = map[string]any{}
+			current[token] = next
+		}
+		current = next
+	}
+	current[tokens[len(tokens)-1]] = value
+}
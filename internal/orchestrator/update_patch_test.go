@@ -0,0 +1,161 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metadatadomain "github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/orchestrator"
+)
+
+func TestBuildJSONPatchFromDiffEntries(t *testing.T) {
+	t.Parallel()
+
+	desired := map[string]any{
+		"name":  "acme",
+		"tier":  "gold",
+		"tags":  []any{"a"},
+		"owner": map[string]any{"email": "ops@acme.test"},
+	}
+	remote := map[string]any{
+		"name":   "acme",
+		"tier":   "silver",
+		"tags":   []any{"a", "b", "c"},
+		"legacy": true,
+	}
+
+	patch := buildJSONPatch(buildDiffEntries("/customers/acme", desired, remote, nil))
+	expected := []any{
+		map[string]any{"op": "add", "path": "/owner", "value": map[string]any{"email": "ops@acme.test"}},
+		map[string]any{"op": "replace", "path": "/tier", "value": "gold"},
+		map[string]any{"op": "remove", "path": "/tags/2"},
+		map[string]any{"op": "remove", "path": "/tags/1"},
+		map[string]any{"op": "remove", "path": "/legacy"},
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Fatalf("unexpected JSON patch:\n got: %#v\nwant: %#v", patch, expected)
+	}
+}
+
+func TestBuildMergePatchFromDiffEntries(t *testing.T) {
+	t.Parallel()
+
+	desired := map[string]any{
+		"name": "acme",
+		"spec": map[string]any{"tier": "gold", "limits": map[string]any{"cpu": "2"}},
+		"tags": []any{"a", "z"},
+	}
+	remote := map[string]any{
+		"name":   "acme",
+		"spec":   map[string]any{"tier": "silver", "limits": map[string]any{"cpu": "2", "memory": "1Gi"}},
+		"tags":   []any{"a", "b"},
+		"legacy": true,
+	}
+
+	patch, err := buildMergePatch(buildDiffEntries("/customers/acme", desired, remote, nil), desired)
+	if err != nil {
+		t.Fatalf("buildMergePatch returned error: %v", err)
+	}
+	expected := map[string]any{
+		"legacy": nil,
+		"spec": map[string]any{
+			"tier":   "gold",
+			"limits": map[string]any{"memory": nil},
+		},
+		"tags": []any{"a", "z"},
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Fatalf("unexpected merge patch:\n got: %#v\nwant: %#v", patch, expected)
+	}
+}
+
+func TestOrchestratorApplySendsPatchForPatchUpdateStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategyMetadata := func(strategy string) metadatadomain.ResourceMetadata {
+		md := planTestMetadata()
+		update := md.Operations[string(metadatadomain.OperationUpdate)]
+		update.Strategy = strategy
+		md.Operations[string(metadatadomain.OperationUpdate)] = update
+		return md
+	}
+	local := map[string]any{"id": "42", "alias": "acme", "tier": "gold"}
+	remote := map[string]any{"id": "42", "alias": "acme", "tier": "silver", "updatedAt": "2026-03-02T18:15:00Z"}
+
+	t.Run("apply_uses_compare_state", func(t *testing.T) {
+		t.Parallel()
+
+		serverManager := &fakeServer{getValue: remote}
+		orchestratorService := &Orchestrator{
+			repository: &fakeRepository{getValue: local},
+			metadata:   &fakeMetadata{resolveValue: strategyMetadata(metadatadomain.UpdateStrategyMergePatch)},
+			server:     serverManager,
+		}
+
+		if _, err := orchestratorService.Apply(context.Background(), "/customers/acme", orchestrator.ApplyPolicy{}); err != nil {
+			t.Fatalf("Apply returned error: %v", err)
+		}
+		if !serverManager.updateCalled {
+			t.Fatal("expected update call")
+		}
+		expected := map[string]any{"tier": "gold"}
+		if !reflect.DeepEqual(serverManager.lastResource.Patch, expected) {
+			t.Fatalf("expected merge patch without server-managed fields, got %#v", serverManager.lastResource.Patch)
+		}
+	})
+
+	t.Run("update_reads_remote_state", func(t *testing.T) {
+		t.Parallel()
+
+		serverManager := &fakeServer{getValue: remote}
+		orchestratorService := &Orchestrator{
+			repository: &fakeRepository{getValue: local},
+			metadata:   &fakeMetadata{resolveValue: strategyMetadata(metadatadomain.UpdateStrategyJSONPatch)},
+			server:     serverManager,
+		}
+
+		if _, err := orchestratorService.Update(context.Background(), "/customers/acme", testContent(local)); err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		if !serverManager.getCalled {
+			t.Fatal("expected remote read before patch update")
+		}
+		expected := []any{map[string]any{"op": "replace", "path": "/tier", "value": "gold"}}
+		if !reflect.DeepEqual(serverManager.lastResource.Patch, expected) {
+			t.Fatalf("unexpected JSON patch, got %#v", serverManager.lastResource.Patch)
+		}
+	})
+
+	t.Run("replace_sends_full_payload", func(t *testing.T) {
+		t.Parallel()
+
+		serverManager := &fakeServer{getValue: remote}
+		orchestratorService := &Orchestrator{
+			repository: &fakeRepository{getValue: local},
+			metadata:   &fakeMetadata{resolveValue: strategyMetadata("")},
+			server:     serverManager,
+		}
+
+		if _, err := orchestratorService.Update(context.Background(), "/customers/acme", testContent(local)); err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		if serverManager.getCalled || serverManager.lastResource.Patch != nil {
+			t.Fatalf("expected replace update without remote read or patch, got %#v", serverManager.lastResource.Patch)
+		}
+	})
+}
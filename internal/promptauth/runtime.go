@@ -189,6 +189,28 @@ func ResolveCredentials(
 	return runtime.Resolve(ctx, credentialName, username, password)
 }
 
+// SessionValue returns a non-credential value, such as a cached OAuth2 token,
+// previously stored with StoreSessionValue in this shell session.
+func (r *Runtime) SessionValue(key string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	if err := r.ensureSessionLoaded(); err != nil {
+		return "", false
+	}
+	return r.sessionValueFromMemory(strings.TrimSpace(key))
+}
+
+// StoreSessionValue caches value under key for the rest of this process and,
+// when a persistent session store is active, for later commands in the same
+// shell session. An empty value removes the key.
+func (r *Runtime) StoreSessionValue(key string, value string) error {
+	if r == nil {
+		return nil
+	}
+	return r.persistField(strings.TrimSpace(key), strings.TrimSpace(value))
+}
+
 func ClearSessionCredentials() (int, error) {
 	removed := 0
 
@@ -300,7 +322,11 @@ func (r *Runtime) persistField(key string, value string) error {
 	if r.sessionValues == nil {
 		r.sessionValues = map[string]string{}
 	}
-	r.sessionValues[key] = value
+	if value == "" {
+		delete(r.sessionValues, key)
+	} else {
+		r.sessionValues[key] = value
+	}
 	values := maps.Clone(r.sessionValues)
 	store := r.store
 	r.mu.Unlock()
@@ -505,6 +505,35 @@ func (s *stubPrompter) PromptValue(
 	return value, nil
 }
 
+func TestRuntimeSessionValuesPersistAcrossRuntimes(t *testing.T) {
+	isolatePromptAuthEnv(t)
+
+	store := &memorySessionStore{}
+	first, err := New(WithSessionStore(store))
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	if err := first.StoreSessionValue("DECLAREST_OAUTH2_TOKEN_TEST", `{"accessToken":"abc"}`); err != nil {
+		t.Fatalf("StoreSessionValue() returned error: %v", err)
+	}
+
+	second, err := New(WithSessionStore(store))
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	value, ok := second.SessionValue("DECLAREST_OAUTH2_TOKEN_TEST")
+	if !ok || value != `{"accessToken":"abc"}` {
+		t.Fatalf("expected stored session value, got %q (found=%t)", value, ok)
+	}
+
+	if err := second.StoreSessionValue("DECLAREST_OAUTH2_TOKEN_TEST", ""); err != nil {
+		t.Fatalf("StoreSessionValue(empty) returned error: %v", err)
+	}
+	if _, exists := store.values["DECLAREST_OAUTH2_TOKEN_TEST"]; exists {
+		t.Fatalf("expected empty value to remove the session key, got %#v", store.values)
+	}
+}
+
 type memorySessionStore struct {
 	values map[string]string
 }
@@ -52,11 +52,48 @@ func injectContextCredentials(
 				return config.Context{}, err
 			}
 		}
+		if cfg.ManagedService.HTTP.Auth != nil &&
+			cfg.ManagedService.HTTP.Auth.Session != nil &&
+			cfg.ManagedService.HTTP.Auth.Session.Credentials != nil {
+			if err := injectBasicCredentials(
+				"managedService.http.auth.session.credentials.credentialsRef",
+				cfg.ManagedService.HTTP.Auth.Session.Credentials,
+				credentials,
+			); err != nil {
+				return config.Context{}, err
+			}
+		}
 		if err := injectProxyCredentials(cfg.ManagedService.HTTP.Proxy, "managedService.http.proxy.auth.basic.credentialsRef", credentials); err != nil {
 			return config.Context{}, err
 		}
 	}
 
+	if cfg.ManagedService != nil && cfg.ManagedService.GraphQL != nil {
+		if cfg.ManagedService.GraphQL.Auth != nil && cfg.ManagedService.GraphQL.Auth.Basic != nil {
+			if err := injectBasicCredentials(
+				"managedService.graphql.auth.basic.credentialsRef",
+				cfg.ManagedService.GraphQL.Auth.Basic,
+				credentials,
+			); err != nil {
+				return config.Context{}, err
+			}
+		}
+		if cfg.ManagedService.GraphQL.Auth != nil &&
+			cfg.ManagedService.GraphQL.Auth.Session != nil &&
+			cfg.ManagedService.GraphQL.Auth.Session.Credentials != nil {
+			if err := injectBasicCredentials(
+				"managedService.graphql.auth.session.credentials.credentialsRef",
+				cfg.ManagedService.GraphQL.Auth.Session.Credentials,
+				credentials,
+			); err != nil {
+				return config.Context{}, err
+			}
+		}
+		if err := injectProxyCredentials(cfg.ManagedService.GraphQL.Proxy, "managedService.graphql.proxy.auth.basic.credentialsRef", credentials); err != nil {
+			return config.Context{}, err
+		}
+	}
+
 	if cfg.SecretStore != nil && cfg.SecretStore.Vault != nil {
 		if cfg.SecretStore.Vault.Auth != nil && cfg.SecretStore.Vault.Auth.Password != nil {
 			if err := injectVaultUserPasswordCredentials(
@@ -76,3 +76,40 @@ func TestInjectContextCredentialsReusesNamedCredentialAcrossReferences(t *testin
 		t.Fatalf("expected managed-service auth to receive injected credential values, got %#v", serverAuth)
 	}
 }
+
+func TestInjectContextCredentialsResolvesSessionLoginCredentials(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Context{
+		Name: "rundeck",
+		ManagedService: &config.ManagedService{
+			HTTP: &config.HTTPServer{
+				BaseURL: "https://rundeck.example.com/api/45",
+				Auth: &config.HTTPAuth{
+					Session: &config.SessionAuth{
+						LoginURL: "https://rundeck.example.com/j_security_check",
+						Credentials: &config.BasicAuth{
+							CredentialsRef: &config.CredentialsRef{Name: "rundeck-admin"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resolved, err := injectContextCredentials(cfg, map[string]config.Credential{
+		"rundeck-admin": {
+			Name:     "rundeck-admin",
+			Username: config.LiteralCredential("admin"),
+			Password: config.LiteralCredential("admin-pass"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("injectContextCredentials() returned error: %v", err)
+	}
+
+	login := resolved.ManagedService.HTTP.Auth.Session.Credentials
+	if login.Username.Literal() != "admin" || login.Password.Literal() != "admin-pass" {
+		t.Fatalf("expected session login credentials to be injected, got %#v", login)
+	}
+}
@@ -15,6 +15,7 @@
 package file
 
 import (
+	"maps"
 	"sort"
 	"strings"
 
@@ -35,8 +36,26 @@ func normalizeConfig(cfg config.Context) config.Context {
 		if cfg.ManagedService.HTTP.Auth != nil && cfg.ManagedService.HTTP.Auth.Basic != nil {
 			cfg.ManagedService.HTTP.Auth.Basic.CredentialsRef = normalizeCredentialsRef(cfg.ManagedService.HTTP.Auth.Basic.CredentialsRef)
 		}
+		if cfg.ManagedService.HTTP.Auth != nil &&
+			cfg.ManagedService.HTTP.Auth.Session != nil &&
+			cfg.ManagedService.HTTP.Auth.Session.Credentials != nil {
+			credentials := cfg.ManagedService.HTTP.Auth.Session.Credentials
+			credentials.CredentialsRef = normalizeCredentialsRef(credentials.CredentialsRef)
+		}
 		cfg.ManagedService.HTTP.Proxy = normalizeProxy(cfg.ManagedService.HTTP.Proxy)
 	}
+	if cfg.ManagedService != nil && cfg.ManagedService.GraphQL != nil {
+		if cfg.ManagedService.GraphQL.Auth != nil && cfg.ManagedService.GraphQL.Auth.Basic != nil {
+			cfg.ManagedService.GraphQL.Auth.Basic.CredentialsRef = normalizeCredentialsRef(cfg.ManagedService.GraphQL.Auth.Basic.CredentialsRef)
+		}
+		if cfg.ManagedService.GraphQL.Auth != nil &&
+			cfg.ManagedService.GraphQL.Auth.Session != nil &&
+			cfg.ManagedService.GraphQL.Auth.Session.Credentials != nil {
+			credentials := cfg.ManagedService.GraphQL.Auth.Session.Credentials
+			credentials.CredentialsRef = normalizeCredentialsRef(credentials.CredentialsRef)
+		}
+		cfg.ManagedService.GraphQL.Proxy = normalizeProxy(cfg.ManagedService.GraphQL.Proxy)
+	}
 	if cfg.SecretStore != nil && cfg.SecretStore.Vault != nil {
 		if cfg.SecretStore.Vault.Auth != nil && cfg.SecretStore.Vault.Auth.Password != nil {
 			cfg.SecretStore.Vault.Auth.Password.CredentialsRef = normalizeCredentialsRef(cfg.SecretStore.Vault.Auth.Password.CredentialsRef)
@@ -150,6 +169,12 @@ func buildProxyTargets(cfg *config.Context) []proxyTarget {
 			proxy: &cfg.ManagedService.HTTP.Proxy,
 		})
 	}
+	if cfg.ManagedService != nil && cfg.ManagedService.GraphQL != nil {
+		targets = append(targets, proxyTarget{
+			name:  "managedService.graphql.proxy",
+			proxy: &cfg.ManagedService.GraphQL.Proxy,
+		})
+	}
 	if cfg.Repository.Git != nil && cfg.Repository.Git.Remote != nil {
 		targets = append(targets, proxyTarget{
 			name:  "repository.git.remote.proxy",
@@ -269,6 +294,13 @@ func applyOverrides(cfg config.Context, overrides map[string]string) (config.Con
 				cfg.Metadata.BaseDir = ""
 				cfg.Metadata.Bundle = ""
 			}
+		case "preferences." + config.PreferenceRecordHTTP, "preferences." + config.PreferenceReplayHTTP:
+			preferences := maps.Clone(cfg.Preferences)
+			if preferences == nil {
+				preferences = map[string]string{}
+			}
+			preferences[strings.TrimPrefix(key, "preferences.")] = value
+			cfg.Preferences = preferences
 		default:
 			return config.Context{}, unknownOverrideError(key)
 		}
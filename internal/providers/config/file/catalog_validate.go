@@ -19,6 +19,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/crmarques/declarest/config"
 	"github.com/crmarques/declarest/envref"
@@ -128,8 +129,12 @@ func validateRepository(
 					repository.Git.Remote.Auth.Basic != nil,
 					repository.Git.Remote.Auth.SSH != nil,
 					repository.Git.Remote.Auth.AccessKey != nil,
+					repository.Git.Remote.Auth.Exec != nil,
 				) != 1 {
-					return faults.Invalid("repository.git.remote.auth must define exactly one of basic, ssh, accessKey", nil)
+					return faults.Invalid("repository.git.remote.auth must define exactly one of basic, ssh, accessKey, exec", nil)
+				}
+				if err := validateExecAuth("repository.git.remote.auth.exec", repository.Git.Remote.Auth.Exec); err != nil {
+					return err
 				}
 				if repository.Git.Remote.Auth.Basic != nil {
 					if err := validateCredentialRef(
@@ -152,6 +157,33 @@ func validateRepository(
 		return faults.Invalid("repository.filesystem.baseDir is required", nil)
 	}
 
+	return validateRepositorySerialization(repository.Serialization)
+}
+
+func validateRepositorySerialization(serialization *config.RepositorySerialization) error {
+	if serialization == nil {
+		return nil
+	}
+	for idx, key := range serialization.KeyOrder {
+		if strings.TrimSpace(key) == "" {
+			return faults.Invalid(fmt.Sprintf("repository.serialization.keyOrder[%d] must not be empty", idx), nil)
+		}
+	}
+	if serialization.YAMLIndent != 0 && (serialization.YAMLIndent < 2 || serialization.YAMLIndent > 9) {
+		return faults.Invalid("repository.serialization.yamlIndent must be between 2 and 9", nil)
+	}
+	switch serialization.YAMLStyle {
+	case "", config.YAMLStyleIndented, config.YAMLStyleCompact:
+	default:
+		return faults.Invalid(
+			fmt.Sprintf(
+				"repository.serialization.yamlStyle must be one of %s, %s",
+				config.YAMLStyleIndented,
+				config.YAMLStyleCompact,
+			),
+			nil,
+		)
+	}
 	return nil
 }
 
@@ -163,35 +195,98 @@ func validateManagedService(
 	if resourceServer == nil {
 		return nil
 	}
-	if resourceServer.HTTP == nil {
-		return faults.Invalid("managedService must define http", nil)
+	if countSet(resourceServer.HTTP != nil, resourceServer.GraphQL != nil) != 1 {
+		return faults.Invalid("managedService must define exactly one of http, graphql", nil)
+	}
+	if graphql := resourceServer.GraphQL; graphql != nil {
+		if graphql.URL == "" {
+			return faults.Invalid("managedService.graphql.url is required", nil)
+		}
+		// The GraphQL endpoint shares the HTTP transport settings.
+		return validateManagedServiceHTTP("managedService.graphql", &config.HTTPServer{
+			BaseURL:           graphql.URL,
+			DefaultHeaders:    graphql.DefaultHeaders,
+			Auth:              graphql.Auth,
+			Proxy:             graphql.Proxy,
+			TLS:               graphql.TLS,
+			RequestThrottling: graphql.RequestThrottling,
+			Retry:             graphql.Retry,
+		}, credentials, strictCredentialRefs)
 	}
 	if resourceServer.HTTP.BaseURL == "" {
 		return faults.Invalid("managedService.http.url is required", nil)
 	}
-	if resourceServer.HTTP.Auth == nil {
-		return faults.Invalid("managedService.http.auth is required", nil)
+	return validateManagedServiceHTTP("managedService.http", resourceServer.HTTP, credentials, strictCredentialRefs)
+}
+
+func validateManagedServiceHTTP(
+	prefix string,
+	server *config.HTTPServer,
+	credentials map[string]config.Credential,
+	strictCredentialRefs bool,
+) error {
+	if server.Auth == nil {
+		return faults.Invalid(prefix+".auth is required", nil)
 	}
 
 	if countSet(
-		resourceServer.HTTP.Auth.OAuth2 != nil,
-		resourceServer.HTTP.Auth.Basic != nil,
-		len(resourceServer.HTTP.Auth.CustomHeaders) > 0,
+		server.Auth.OAuth2 != nil,
+		server.Auth.Basic != nil,
+		len(server.Auth.CustomHeaders) > 0,
+		server.Auth.SigV4 != nil,
+		server.Auth.Session != nil,
+		server.Auth.Exec != nil,
 	) != 1 {
-		return faults.Invalid("managedService.http.auth must define exactly one of oauth2, basic, customHeaders", nil)
+		return faults.Invalid(prefix+".auth must define exactly one of oauth2, basic, customHeaders, sigv4, session, exec", nil)
 	}
 
-	if resourceServer.HTTP.Auth.OAuth2 != nil {
-		oauth := resourceServer.HTTP.Auth.OAuth2
-		if oauth.TokenURL == "" || oauth.GrantType == "" || oauth.ClientID == "" || oauth.ClientSecret == "" {
-			return faults.Invalid("managedService.http.auth.oauth2 requires tokenURL, grantType, clientID, clientSecret", nil)
+	if server.Auth.OAuth2 != nil {
+		oauth := server.Auth.OAuth2
+		if oauth.TokenURL == "" || oauth.GrantType == "" || oauth.ClientID == "" {
+			return faults.Invalid(prefix+".auth.oauth2 requires tokenURL, grantType, clientID", nil)
+		}
+		switch oauth.GrantType {
+		case config.OAuthAuthorizationCode:
+			if oauth.AuthorizationURL == "" {
+				return faults.Invalid(prefix+".auth.oauth2.authorizationURL is required for grantType authorization_code", nil)
+			}
+		case config.OAuthDeviceCode:
+			if oauth.DeviceAuthorizationURL == "" {
+				return faults.Invalid(prefix+".auth.oauth2.deviceAuthorizationURL is required for grantType device_code", nil)
+			}
+		case config.OAuthRefreshToken:
+			if oauth.RefreshToken == "" {
+				return faults.Invalid(prefix+".auth.oauth2.refreshToken is required for grantType refresh_token", nil)
+			}
+		case config.OAuthTokenExchange:
+			if oauth.SubjectTokenFile == "" {
+				return faults.Invalid(prefix+".auth.oauth2.subjectTokenFile is required for grantType token_exchange", nil)
+			}
+		default:
+			if oauth.RequiresClientSecret() && oauth.ClientSecret == "" {
+				return faults.Invalid(prefix+".auth.oauth2 requires tokenURL, grantType, clientID, clientSecret", nil)
+			}
+		}
+		switch oauth.ClientAuthMethod {
+		case "", config.OAuthClientSecretPost:
+		case config.OAuthPrivateKeyJWT:
+			if oauth.ClientAssertionKeyFile == "" {
+				return faults.Invalid(prefix+".auth.oauth2.clientAssertionKeyFile is required for clientAuthMethod private_key_jwt", nil)
+			}
+		case config.OAuthTLSClientAuth:
+			tls := server.TLS
+			if tls == nil || tls.ClientCertFile == "" || tls.ClientKeyFile == "" {
+				return faults.Invalid(prefix+".auth.oauth2.clientAuthMethod tls_client_auth requires "+prefix+".tls clientCertFile and clientKeyFile", nil)
+			}
+		default:
+			return faults.Invalid(prefix+".auth.oauth2.clientAuthMethod must be one of client_secret_post, private_key_jwt, tls_client_auth", nil)
 		}
 	}
 
-	if resourceServer.HTTP.Auth.Basic != nil {
-		basic := resourceServer.HTTP.Auth.Basic
+	if server.Auth.Basic != nil {
+		basic := server.Auth.Basic
 		if err := validateCredentialRef(
-			"managedService.http.auth.basic.credentialsRef",
+			prefix+".auth.basic.credentialsRef",
 			basic.CredentialsRef,
 			credentials,
 			strictCredentialRefs,
@@ -200,22 +295,67 @@ func validateManagedService(
 		}
 	}
 
-	for idx, head := range resourceServer.HTTP.Auth.CustomHeaders {
+	for idx, head := range server.Auth.CustomHeaders {
 		if head.Header == "" || head.Value == "" {
 			return faults.Invalid(
-				fmt.Sprintf("managedService.http.auth.customHeaders[%d] requires header and value", idx),
+				fmt.Sprintf(prefix+".auth.customHeaders[%d] requires header and value", idx),
 				nil,
 			)
 		}
 	}
 
-	if err := validateManagedServiceProxy(resourceServer.HTTP.Proxy, credentials, strictCredentialRefs); err != nil {
+	if sigv4 := server.Auth.SigV4; sigv4 != nil {
+		if sigv4.Region == "" || sigv4.Service == "" {
+			return faults.Invalid(prefix+".auth.sigv4 requires region and service", nil)
+		}
+		if (sigv4.AccessKeyID == "") != (sigv4.SecretAccessKey == "") {
+			return faults.Invalid(prefix+".auth.sigv4 accessKeyID and secretAccessKey must be set together", nil)
+		}
+	}
+
+	if session := server.Auth.Session; session != nil {
+		if session.LoginURL == "" {
+			return faults.Invalid(prefix+".auth.session.loginURL is required", nil)
+		}
+		switch session.BodyFormat {
+		case "", config.SessionBodyForm, config.SessionBodyJSON:
+		default:
+			return faults.Invalid(prefix+".auth.session.bodyFormat must be one of form, json", nil)
+		}
+		if session.Credentials != nil {
+			if err := validateCredentialRef(
+				prefix+".auth.session.credentials.credentialsRef",
+				session.Credentials.CredentialsRef,
+				credentials,
+				strictCredentialRefs,
+			); err != nil {
+				return err
+			}
+		}
+		if csrf := session.CSRF; csrf != nil {
+			if csrf.Header == "" {
+				return faults.Invalid(prefix+".auth.session.csrf.header is required", nil)
+			}
+			if countSet(csrf.ResponseHeader != "", csrf.JSONPointer != "") != 1 {
+				return faults.Invalid(prefix+".auth.session.csrf must define exactly one of responseHeader, jsonPointer", nil)
+			}
+		}
+	}
+
+	if err := validateExecAuth(prefix+".auth.exec", server.Auth.Exec); err != nil {
+		return err
+	}
+
+	if err := validateManagedServiceProxy(prefix, server.Proxy, credentials, strictCredentialRefs); err != nil {
+		return err
+	}
+	if err := validateManagedServiceRequestThrottling(prefix, server.RequestThrottling); err != nil {
 		return err
 	}
-	if err := validateManagedServiceRequestThrottling(resourceServer.HTTP.RequestThrottling); err != nil {
+	if err := validateManagedServiceRetry(prefix, server.Retry); err != nil {
 		return err
 	}
-	if err := validateManagedServiceHealthCheck(resourceServer.HTTP.HealthCheck); err != nil {
+	if err := validateManagedServiceHealthCheck(prefix, server.HealthCheck); err != nil {
 		return err
 	}
 
@@ -223,42 +363,81 @@ func validateManagedService(
 }
 
 func validateManagedServiceProxy(
+	prefix string,
 	proxy *config.HTTPProxy,
 	credentials map[string]config.Credential,
 	strictCredentialRefs bool,
 ) error {
-	return validateProxy("managedService.http.proxy", proxy, credentials, strictCredentialRefs)
+	return validateProxy(prefix+".proxy", proxy, credentials, strictCredentialRefs)
 }
 
-func validateManagedServiceRequestThrottling(throttling *config.HTTPRequestThrottling) error {
+func validateManagedServiceRequestThrottling(prefix string, throttling *config.HTTPRequestThrottling) error {
 	if throttling == nil {
 		return nil
 	}
 	if throttling.MaxConcurrentRequests <= 0 && throttling.RequestsPerSecond <= 0 {
-		return faults.Invalid("managedService.http.requestThrottling must define at least one of maxConcurrentRequests or requestsPerSecond", nil)
+		return faults.Invalid(prefix+".requestThrottling must define at least one of maxConcurrentRequests or requestsPerSecond", nil)
 	}
 	if throttling.MaxConcurrentRequests < 0 {
-		return faults.Invalid("managedService.http.requestThrottling.maxConcurrentRequests must be greater than zero when set", nil)
+		return faults.Invalid(prefix+".requestThrottling.maxConcurrentRequests must be greater than zero when set", nil)
 	}
 	if throttling.QueueSize < 0 {
-		return faults.Invalid("managedService.http.requestThrottling.queueSize must be greater than or equal to zero", nil)
+		return faults.Invalid(prefix+".requestThrottling.queueSize must be greater than or equal to zero", nil)
 	}
 	if throttling.QueueSize > 0 && throttling.MaxConcurrentRequests <= 0 {
-		return faults.Invalid("managedService.http.requestThrottling.queueSize requires maxConcurrentRequests", nil)
+		return faults.Invalid(prefix+".requestThrottling.queueSize requires maxConcurrentRequests", nil)
 	}
 	if throttling.RequestsPerSecond < 0 {
-		return faults.Invalid("managedService.http.requestThrottling.requestsPerSecond must be greater than zero when set", nil)
+		return faults.Invalid(prefix+".requestThrottling.requestsPerSecond must be greater than zero when set", nil)
 	}
 	if throttling.Burst < 0 {
-		return faults.Invalid("managedService.http.requestThrottling.burst must be greater than zero when set", nil)
+		return faults.Invalid(prefix+".requestThrottling.burst must be greater than zero when set", nil)
 	}
 	if throttling.Burst > 0 && throttling.RequestsPerSecond <= 0 {
-		return faults.Invalid("managedService.http.requestThrottling.burst requires requestsPerSecond", nil)
+		return faults.Invalid(prefix+".requestThrottling.burst requires requestsPerSecond", nil)
+	}
+	return nil
+}
+
+func validateManagedServiceRetry(prefix string, retry *config.HTTPRetry) error {
+	if retry == nil {
+		return nil
+	}
+	if retry.MaxAttempts < 0 {
+		return faults.Invalid(prefix+".retry.maxAttempts must be greater than zero when set", nil)
+	}
+
+	var initialBackoff, maxBackoff time.Duration
+	if value := strings.TrimSpace(retry.InitialBackoff); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return faults.Invalid(prefix+".retry.initialBackoff must be a positive duration", err)
+		}
+		initialBackoff = parsed
+	}
+	if value := strings.TrimSpace(retry.MaxBackoff); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return faults.Invalid(prefix+".retry.maxBackoff must be a positive duration", err)
+		}
+		maxBackoff = parsed
+	}
+	if initialBackoff > 0 && maxBackoff > 0 && maxBackoff < initialBackoff {
+		return faults.Invalid(prefix+".retry.maxBackoff must be greater than or equal to initialBackoff", nil)
+	}
+
+	for idx, statusCode := range retry.RetryableStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return faults.Invalid(
+				fmt.Sprintf(prefix+".retry.retryableStatusCodes[%d] must be a valid HTTP status code", idx),
+				nil,
+			)
+		}
 	}
 	return nil
 }
 
-func validateManagedServiceHealthCheck(value string) error {
+func validateManagedServiceHealthCheck(prefix string, value string) error {
 	healthCheck := strings.TrimSpace(value)
 	if healthCheck == "" {
 		return nil
@@ -266,30 +445,30 @@ func validateManagedServiceHealthCheck(value string) error {
 
 	parsed, err := url.Parse(healthCheck)
 	if err != nil {
-		return faults.Invalid("managedService.http.healthCheck is invalid", err)
+		return faults.Invalid(prefix+".healthCheck is invalid", err)
 	}
 	if strings.TrimSpace(parsed.RawQuery) != "" {
-		return faults.Invalid("managedService.http.healthCheck must not include query parameters", nil)
+		return faults.Invalid(prefix+".healthCheck must not include query parameters", nil)
 	}
 
 	// Relative paths are interpreted against managedService.http.url.
 	if parsed.Scheme == "" && parsed.Host == "" {
 		if strings.TrimSpace(parsed.Path) == "" {
-			return faults.Invalid("managedService.http.healthCheck is invalid", nil)
+			return faults.Invalid(prefix+".healthCheck is invalid", nil)
 		}
 		return nil
 	}
 
 	if parsed.Scheme != "http" && parsed.Scheme != "https" {
-		return faults.Invalid("managedService.http.healthCheck URL must use http or https", nil)
+		return faults.Invalid(prefix+".healthCheck URL must use http or https", nil)
 	}
 	if parsed.Host == "" {
-		return faults.Invalid("managedService.http.healthCheck URL host is required", nil)
+		return faults.Invalid(prefix+".healthCheck URL host is required", nil)
 	}
 
 	_, err = filepath.Rel("/", parsed.Path)
 	if err != nil {
-		return faults.Invalid("managedService.http.healthCheck URL path is invalid", err)
+		return faults.Invalid(prefix+".healthCheck URL path is invalid", err)
 	}
 
 	return nil
@@ -458,6 +637,22 @@ func validateCredentialRef(
 	return nil
 }
 
+func validateExecAuth(field string, exec *config.ExecAuth) error {
+	if exec == nil {
+		return nil
+	}
+	if strings.TrimSpace(exec.Command) == "" {
+		return faults.Invalid(field+".command is required", nil)
+	}
+	if value := strings.TrimSpace(exec.Timeout); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return faults.Invalid(field+".timeout must be a positive duration", err)
+		}
+	}
+	return nil
+}
+
 func countSet(values ...bool) int {
 	count := 0
 	for _, value := range values {
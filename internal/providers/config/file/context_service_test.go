@@ -292,6 +292,31 @@ func TestValidateConfigOneOfRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "managed_service_http_and_graphql",
+			cfg: config.Context{
+				Name:       "dev",
+				Repository: validFilesystemRepository(),
+				ManagedService: &config.ManagedService{
+					HTTP:    validManagedService().HTTP,
+					GraphQL: &config.GraphQLServer{URL: "https://example.com/graphql", Auth: validManagedService().HTTP.Auth},
+				},
+			},
+		},
+		{
+			name: "managed_service_graphql_invalid_retry",
+			cfg: config.Context{
+				Name:       "dev",
+				Repository: validFilesystemRepository(),
+				ManagedService: &config.ManagedService{
+					GraphQL: &config.GraphQLServer{
+						URL:   "https://example.com/graphql",
+						Auth:  validManagedService().HTTP.Auth,
+						Retry: &config.HTTPRetry{InitialBackoff: "soon"},
+					},
+				},
+			},
+		},
 		{
 			name: "secret_store_multiple_backends",
 			cfg: config.Context{
@@ -304,6 +329,26 @@ func TestValidateConfigOneOfRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "repository_serialization_unknown_yaml_style",
+			cfg: config.Context{
+				Name: "dev",
+				Repository: config.Repository{
+					Filesystem:    &config.FilesystemRepository{BaseDir: "/tmp/repo"},
+					Serialization: &config.RepositorySerialization{YAMLStyle: "flow"},
+				},
+			},
+		},
+		{
+			name: "repository_serialization_yaml_indent_out_of_range",
+			cfg: config.Context{
+				Name: "dev",
+				Repository: config.Repository{
+					Filesystem:    &config.FilesystemRepository{BaseDir: "/tmp/repo"},
+					Serialization: &config.RepositorySerialization{YAMLIndent: 12},
+				},
+			},
+		},
 		{
 			name: "metadata_multiple_sources",
 			cfg: config.Context{
@@ -425,6 +470,29 @@ func TestValidateConfigAllowsSparseProxyOverrideWithoutURLs(t *testing.T) {
 	}
 }
 
+func TestValidateConfigAllowsGraphQLManagedService(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Context{
+		Name: "dev",
+		ManagedService: &config.ManagedService{
+			GraphQL: &config.GraphQLServer{
+				URL:  "https://example.com/graphql",
+				Auth: validManagedService().HTTP.Auth,
+			},
+		},
+	}
+	if err := validateConfig(cfg, nil, false); err != nil {
+		t.Fatalf("expected graphql managed service to validate, got %v", err)
+	}
+
+	cfg.ManagedService.GraphQL.URL = ""
+	err := validateConfig(cfg, nil, false)
+	if err == nil || !strings.Contains(err.Error(), "managedService.graphql.url is required") {
+		t.Fatalf("expected missing graphql url error, got %v", err)
+	}
+}
+
 func TestValidateConfigAllowsMissingRepositoryWhenManagedServiceIsConfigured(t *testing.T) {
 	t.Parallel()
 
@@ -1175,6 +1243,27 @@ func TestResolveContextOverrideSupportsManagedServiceHealthCheckWhenConfigured(t
 	}
 }
 
+func TestResolveContextOverrideSupportsHTTPCassettePreferences(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "contexts.yaml")
+	if err := os.WriteFile(path, []byte(providerSelectionContextCatalogYAML), 0o600); err != nil {
+		t.Fatalf("failed to write test contextCatalog: %v", err)
+	}
+
+	contextService := NewService(path)
+	resolved, err := contextService.ResolveContext(context.Background(), config.ContextSelection{
+		Name:      "fs",
+		Overrides: map[string]string{"preferences.replayHTTP": "/tmp/cassettes"},
+	})
+	if err != nil {
+		t.Fatalf("expected preferences override to succeed, got %v", err)
+	}
+	if resolved.Preferences[config.PreferenceReplayHTTP] != "/tmp/cassettes" {
+		t.Fatalf("expected replayHTTP preference override, got %#v", resolved.Preferences)
+	}
+}
+
 func TestResolveContextOverrideSupportsManagedServiceProxyWhenConfigured(t *testing.T) {
 	t.Parallel()
 
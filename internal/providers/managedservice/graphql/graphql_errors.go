@@ -0,0 +1,110 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+// errorCategories maps the extensions.code (Apollo and most servers) or
+// extensions.classification (graphql-java) of a GraphQL error to a fault
+// category. Unknown codes are treated as server failures.
+var errorCategories = map[string]faults.ErrorCategory{
+	"UNAUTHENTICATED":           faults.AuthError,
+	"UNAUTHORIZED":              faults.AuthError,
+	"FORBIDDEN":                 faults.AuthError,
+	"NOT_FOUND":                 faults.NotFoundError,
+	"CONFLICT":                  faults.ConflictError,
+	"ALREADY_EXISTS":            faults.ConflictError,
+	"BAD_USER_INPUT":            faults.ValidationError,
+	"BAD_REQUEST":               faults.ValidationError,
+	"GRAPHQL_PARSE_FAILED":      faults.ValidationError,
+	"GRAPHQL_VALIDATION_FAILED": faults.ValidationError,
+	"VALIDATIONERROR":           faults.ValidationError,
+	"INVALIDSYNTAX":             faults.ValidationError,
+}
+
+// decodeResponse returns the "data" member of a GraphQL response, or a typed
+// error built from its "errors" array.
+func decodeResponse(body []byte) (any, error) {
+	decoded, err := resource.DecodePayload(body, resource.PayloadTypeJSON)
+	if err != nil {
+		return nil, faults.Transport("graphql response is not valid JSON", err)
+	}
+	response, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, faults.Transport("graphql response must be a JSON object", nil)
+	}
+
+	if errorsValue, exists := response["errors"]; exists && errorsValue != nil {
+		entries, ok := errorsValue.([]any)
+		if !ok {
+			return nil, faults.Transport("graphql response errors must be an array", nil)
+		}
+		if len(entries) > 0 {
+			return nil, responseError(entries)
+		}
+	}
+	return response["data"], nil
+}
+
+func responseError(entries []any) error {
+	category := faults.TransportError
+	messages := make([]string, 0, len(entries))
+	for idx, entry := range entries {
+		item, _ := entry.(map[string]any)
+		message, _ := item["message"].(string)
+		if strings.TrimSpace(message) == "" {
+			message = "unknown error"
+		}
+		if path := errorPath(item["path"]); path != "" {
+			message = fmt.Sprintf("%s (path %s)", message, path)
+		}
+		messages = append(messages, message)
+
+		if idx == 0 {
+			if mapped, ok := errorCategories[errorCode(item)]; ok {
+				category = mapped
+			}
+		}
+	}
+	return faults.NewTypedError(category, "graphql request failed: "+strings.Join(messages, "; "), nil)
+}
+
+func errorCode(item map[string]any) string {
+	extensions, _ := item["extensions"].(map[string]any)
+	for _, key := range []string{"code", "classification"} {
+		if code, ok := extensions[key].(string); ok && strings.TrimSpace(code) != "" {
+			return strings.ToUpper(strings.TrimSpace(code))
+		}
+	}
+	return ""
+}
+
+func errorPath(value any) string {
+	segments, ok := value.([]any)
+	if !ok || len(segments) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		parts = append(parts, fmt.Sprint(segment))
+	}
+	return strings.Join(parts, ".")
+}
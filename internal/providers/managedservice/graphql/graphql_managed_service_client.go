@@ -0,0 +1,334 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphql implements a managed-service client for APIs that expose
+// only a GraphQL endpoint. Metadata operations map to the queries and
+// mutations declared in their graphql block; requests travel over the HTTP
+// client, so auth, TLS, proxy, throttling, retry, and cassettes are shared.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+	httpmanagedservice "github.com/crmarques/declarest/internal/providers/managedservice/http"
+	"github.com/crmarques/declarest/managedservice"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/metadata/templatescope"
+	"github.com/crmarques/declarest/resource"
+)
+
+var (
+	_ managedservice.ManagedServiceClient = (*Client)(nil)
+	_ managedservice.AccessTokenProvider  = (*Client)(nil)
+)
+
+type Client struct {
+	transport        *httpmanagedservice.Client
+	metadataRenderer metadata.ResourceOperationSpecRenderer
+	httpOptions      []httpmanagedservice.ClientOption
+}
+
+type ClientOption func(*Client)
+
+func WithMetadataRenderer(renderer metadata.ResourceOperationSpecRenderer) ClientOption {
+	return func(g *Client) {
+		if g == nil {
+			return
+		}
+		g.metadataRenderer = renderer
+	}
+}
+
+// WithHTTPOptions passes options, such as the prompt runtime or a cassette,
+// to the underlying HTTP transport.
+func WithHTTPOptions(opts ...httpmanagedservice.ClientOption) ClientOption {
+	return func(g *Client) {
+		if g == nil {
+			return
+		}
+		g.httpOptions = append(g.httpOptions, opts...)
+	}
+}
+
+func NewClient(cfg config.GraphQLServer, opts ...ClientOption) (*Client, error) {
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, faults.Invalid("managed-service.graphql.url is required", nil)
+	}
+
+	client := &Client{}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(client)
+	}
+
+	transport, err := httpmanagedservice.NewClient(config.HTTPServer{
+		BaseURL:           cfg.URL,
+		DefaultHeaders:    cfg.DefaultHeaders,
+		Auth:              cfg.Auth,
+		Proxy:             cfg.Proxy,
+		TLS:               cfg.TLS,
+		RequestThrottling: cfg.RequestThrottling,
+		Retry:             cfg.Retry,
+	}, client.httpOptions...)
+	if err != nil {
+		return nil, err
+	}
+	client.transport = transport
+	client.httpOptions = nil
+	return client, nil
+}
+
+func (g *Client) Get(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (resource.Content, error) {
+	spec, err := g.operationSpec(ctx, resolvedResource, md, metadata.OperationGet)
+	if err != nil {
+		return resource.Content{}, err
+	}
+
+	result, found, err := g.execute(ctx, resolvedResource, metadata.OperationGet, spec)
+	if err != nil {
+		return resource.Content{}, err
+	}
+	if !found {
+		return resource.Content{}, faults.NotFound(
+			fmt.Sprintf("graphql get returned no resource for %q", resolvedResource.LogicalPath),
+			nil,
+		)
+	}
+
+	value, err := g.transport.TransformPayload(ctx, result, spec)
+	if err != nil {
+		return resource.Content{}, err
+	}
+	return jsonContent(value), nil
+}
+
+func (g *Client) Create(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (resource.Content, error) {
+	return g.mutate(ctx, resolvedResource, md, metadata.OperationCreate)
+}
+
+func (g *Client) Update(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (resource.Content, error) {
+	return g.mutate(ctx, resolvedResource, md, metadata.OperationUpdate)
+}
+
+func (g *Client) Delete(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) error {
+	_, err := g.mutate(ctx, resolvedResource, md, metadata.OperationDelete)
+	return err
+}
+
+func (g *Client) List(ctx context.Context, collectionPath string, md metadata.ResourceMetadata) ([]resource.Resource, error) {
+	collection := resource.Resource{
+		LogicalPath:    collectionPath,
+		CollectionPath: collectionPath,
+	}
+	spec, err := g.operationSpec(ctx, collection, md, metadata.OperationList)
+	if err != nil {
+		return nil, err
+	}
+
+	result, found, err := g.execute(ctx, collection, metadata.OperationList, spec)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []resource.Resource{}, nil
+	}
+
+	value, err := g.transport.TransformPayload(ctx, result, spec)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, managedservice.NewListPayloadShapeError(
+			fmt.Sprintf("graphql list result at %q must be an array", spec.GraphQL.ResultPointer),
+			nil,
+		)
+	}
+	return managedservice.BuildListResources(collectionPath, md, items, jsonDescriptor())
+}
+
+func (g *Client) Exists(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (bool, error) {
+	_, err := g.Get(ctx, resolvedResource, md)
+	if err == nil {
+		return true, nil
+	}
+	if faults.IsCategory(err, faults.NotFoundError) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Request sends a raw HTTP request to the GraphQL endpoint.
+func (g *Client) Request(ctx context.Context, spec managedservice.RequestSpec) (resource.Content, error) {
+	return g.transport.Request(ctx, spec)
+}
+
+func (g *Client) GetAccessToken(ctx context.Context) (string, error) {
+	return g.transport.GetAccessToken(ctx)
+}
+
+func (g *Client) GetOpenAPISpec(context.Context) (resource.Content, error) {
+	return resource.Content{}, faults.Invalid("managed-service.graphql has no OpenAPI document", nil)
+}
+
+func (g *Client) mutate(
+	ctx context.Context,
+	resolvedResource resource.Resource,
+	md metadata.ResourceMetadata,
+	operation metadata.Operation,
+) (resource.Content, error) {
+	spec, err := g.operationSpec(ctx, resolvedResource, md, operation)
+	if err != nil {
+		return resource.Content{}, err
+	}
+
+	result, found, err := g.execute(ctx, resolvedResource, operation, spec)
+	if err != nil || !found {
+		return resource.Content{}, err
+	}
+	return jsonContent(result), nil
+}
+
+// operationSpec renders the metadata operation and checks that it declares a
+// GraphQL document.
+func (g *Client) operationSpec(
+	ctx context.Context,
+	resolvedResource resource.Resource,
+	md metadata.ResourceMetadata,
+	operation metadata.Operation,
+) (metadata.OperationSpec, error) {
+	var spec metadata.OperationSpec
+	var err error
+	if g.metadataRenderer != nil {
+		spec, err = g.metadataRenderer.RenderOperationSpecForResource(ctx, metadata.ResourceOperationSpecInput{
+			LogicalPath:       resolvedResource.LogicalPath,
+			CollectionPath:    resolvedResource.CollectionPath,
+			LocalAlias:        resolvedResource.LocalAlias,
+			RemoteID:          resolvedResource.RemoteID,
+			PayloadDescriptor: resolvedResource.PayloadDescriptor,
+			Metadata:          md,
+			Payload:           resolvedResource.Payload,
+		}, operation)
+	} else {
+		spec, err = resolveOperationSpec(ctx, resolvedResource, md, operation)
+	}
+	if err != nil {
+		return metadata.OperationSpec{}, err
+	}
+
+	if spec.GraphQL == nil || strings.TrimSpace(spec.GraphQL.Query) == "" {
+		return metadata.OperationSpec{}, faults.Invalid(
+			fmt.Sprintf("metadata operation %q has no graphql query for %q", operation, resolvedResource.LogicalPath),
+			nil,
+		)
+	}
+	return spec, nil
+}
+
+func resolveOperationSpec(
+	ctx context.Context,
+	resolvedResource resource.Resource,
+	md metadata.ResourceMetadata,
+	operation metadata.Operation,
+) (metadata.OperationSpec, error) {
+	scope, err := templatescope.BuildResourceScope(resolvedResource, md)
+	if err != nil {
+		return metadata.OperationSpec{}, err
+	}
+	metadata.ApplyPayloadTemplateScope(scope, md, resolvedResource.Payload, resolvedResource.PayloadDescriptor)
+	return metadata.ResolveOperationSpecWithScope(ctx, md, operation, scope)
+}
+
+// execute sends the operation document and returns the value at
+// resultPointer inside "data"; found is false when that value is absent or
+// null.
+func (g *Client) execute(
+	ctx context.Context,
+	resolvedResource resource.Resource,
+	operation metadata.Operation,
+	spec metadata.OperationSpec,
+) (any, bool, error) {
+	document := spec.GraphQL
+	variables := make(map[string]any, len(document.Variables)+1)
+	for key, value := range document.Variables {
+		variables[key] = value
+	}
+	if name := strings.TrimSpace(document.PayloadVariable); name != "" {
+		payload, err := g.transport.TransformPayload(ctx, resolvedResource.Payload, spec)
+		if err != nil {
+			return nil, false, err
+		}
+		variables[name] = payload
+	}
+
+	body := map[string]any{"query": document.Query}
+	if strings.TrimSpace(document.OperationName) != "" {
+		body["operationName"] = document.OperationName
+	}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+
+	debugctx.Detailf(
+		ctx,
+		"graphql request operation=%q logical_path=%q operation_name=%q",
+		operation,
+		resolvedResource.LogicalPath,
+		document.OperationName,
+	)
+	responseBody, err := g.transport.PostJSON(ctx, "/", spec.Headers, body, isIdempotent(operation, spec))
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := decodeResponse(responseBody)
+	if err != nil {
+		return nil, false, err
+	}
+	if strings.TrimSpace(document.ResultPointer) == "" {
+		return data, data != nil, nil
+	}
+	result, found, err := resource.LookupJSONPointer(data, document.ResultPointer)
+	if err != nil {
+		return nil, false, faults.Invalid(
+			fmt.Sprintf("graphql resultPointer %q does not match the response", document.ResultPointer),
+			err,
+		)
+	}
+	return result, found && result != nil, nil
+}
+
+// isIdempotent treats queries as safe to retry and mutations as unsafe,
+// unless the operation sets idempotent explicitly.
+func isIdempotent(operation metadata.Operation, spec metadata.OperationSpec) bool {
+	if spec.Idempotent != nil {
+		return *spec.Idempotent
+	}
+	return operation == metadata.OperationGet || operation == metadata.OperationList
+}
+
+func jsonDescriptor() resource.PayloadDescriptor {
+	return resource.NormalizePayloadDescriptor(resource.PayloadDescriptor{PayloadType: resource.PayloadTypeJSON})
+}
+
+func jsonContent(value any) resource.Content {
+	return resource.Content{Value: value, Descriptor: jsonDescriptor()}
+}
@@ -0,0 +1,307 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// graphQLServer answers every request with response and records the decoded
+// request bodies.
+type graphQLServer struct {
+	mu       sync.Mutex
+	requests []graphQLRequest
+	response string
+}
+
+func (s *graphQLServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request graphQLRequest
+	if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+		http.Error(w, "unexpected request", http.StatusNotFound)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	response := s.response
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(response))
+}
+
+func (s *graphQLServer) lastRequest(t *testing.T) graphQLRequest {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		t.Fatal("expected a graphql request")
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+func newTestClient(t *testing.T, response string) (*Client, *graphQLServer) {
+	t.Helper()
+
+	handler := &graphQLServer{response: response}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(config.GraphQLServer{
+		URL: server.URL + "/graphql",
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return client, handler
+}
+
+func projectMetadata() metadata.ResourceMetadata {
+	return metadata.ResourceMetadata{
+		ID:    "{{/id}}",
+		Alias: "{{/name}}",
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationGet): {
+				GraphQL: &metadata.GraphQLSpec{
+					Query:         "query Get($id: ID!, $port: Int) { project(id: $id, port: $port) { id name } }",
+					OperationName: "Get",
+					Variables: map[string]any{
+						"id":    "{{/id}}",
+						"port":  "{{/port}}",
+						"label": "project-{{/name}}",
+					},
+					ResultPointer: "/project",
+				},
+			},
+			string(metadata.OperationCreate): {
+				GraphQL: &metadata.GraphQLSpec{
+					Query:           "mutation Create($input: ProjectInput!) { createProject(input: $input) { id } }",
+					PayloadVariable: "input",
+				},
+				Transforms: []metadata.TransformStep{{ExcludeAttributes: []string{"/port"}}},
+			},
+			string(metadata.OperationList): {
+				GraphQL: &metadata.GraphQLSpec{
+					Query:         "query { projects { nodes { id name } } }",
+					ResultPointer: "/projects/nodes",
+				},
+			},
+		},
+	}
+}
+
+func projectResource() resource.Resource {
+	return resource.Resource{
+		LogicalPath:    "/projects/alpha",
+		CollectionPath: "/projects",
+		LocalAlias:     "alpha",
+		RemoteID:       "p1",
+		Payload:        map[string]any{"id": "p1", "name": "alpha", "port": 8080},
+	}
+}
+
+func TestNewClientRequiresURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient(config.GraphQLServer{})
+	assertTypedCategory(t, err, faults.ValidationError)
+}
+
+func TestGetRendersTypedVariables(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestClient(t, `{"data":{"project":{"id":"p1","name":"alpha"}}}`)
+
+	content, err := client.Get(context.Background(), projectResource(), projectMetadata())
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if want := map[string]any{"id": "p1", "name": "alpha"}; !reflect.DeepEqual(content.Value, want) {
+		t.Fatalf("unexpected value: %#v", content.Value)
+	}
+
+	request := server.lastRequest(t)
+	if request.OperationName != "Get" {
+		t.Fatalf("expected operationName Get, got %q", request.OperationName)
+	}
+	wantVariables := map[string]any{"id": "p1", "port": float64(8080), "label": "project-alpha"}
+	if !reflect.DeepEqual(request.Variables, wantVariables) {
+		t.Fatalf("unexpected variables: %#v", request.Variables)
+	}
+}
+
+func TestGetNullResultIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestClient(t, `{"data":{"project":null}}`)
+
+	_, err := client.Get(context.Background(), projectResource(), projectMetadata())
+	assertTypedCategory(t, err, faults.NotFoundError)
+
+	exists, err := client.Exists(context.Background(), projectResource(), projectMetadata())
+	if err != nil {
+		t.Fatalf("Exists returned error: %v", err)
+	}
+	if exists {
+		t.Fatal("expected resource to be reported missing")
+	}
+}
+
+func TestCreateSendsTransformedPayloadVariable(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestClient(t, `{"data":{"createProject":{"id":"p1"}}}`)
+
+	if _, err := client.Create(context.Background(), projectResource(), projectMetadata()); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	request := server.lastRequest(t)
+	want := map[string]any{"input": map[string]any{"id": "p1", "name": "alpha"}}
+	if !reflect.DeepEqual(request.Variables, want) {
+		t.Fatalf("unexpected variables: %#v", request.Variables)
+	}
+}
+
+func TestListExtractsResultPointer(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestClient(t, `{"data":{"projects":{"nodes":[{"id":"p1","name":"alpha"},{"id":"p2","name":"beta"}]}}}`)
+
+	items, err := client.List(context.Background(), "/projects", projectMetadata())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].LogicalPath != "/projects/alpha" || items[0].RemoteID != "p1" {
+		t.Fatalf("unexpected first item: %#v", items[0])
+	}
+	if items[1].LogicalPath != "/projects/beta" || items[1].RemoteID != "p2" {
+		t.Fatalf("unexpected second item: %#v", items[1])
+	}
+}
+
+func TestListRequiresArrayResult(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestClient(t, `{"data":{"projects":{"nodes":{"id":"p1"}}}}`)
+
+	_, err := client.List(context.Background(), "/projects", projectMetadata())
+	assertTypedCategory(t, err, faults.ValidationError)
+}
+
+func TestErrorsMapToFaultCategories(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response string
+		category faults.ErrorCategory
+	}{
+		{
+			name:     "unauthenticated",
+			response: `{"errors":[{"message":"login required","extensions":{"code":"UNAUTHENTICATED"}}]}`,
+			category: faults.AuthError,
+		},
+		{
+			name:     "not_found",
+			response: `{"errors":[{"message":"no project","path":["project"],"extensions":{"code":"NOT_FOUND"}}],"data":{"project":null}}`,
+			category: faults.NotFoundError,
+		},
+		{
+			name:     "conflict",
+			response: `{"errors":[{"message":"exists","extensions":{"code":"CONFLICT"}}]}`,
+			category: faults.ConflictError,
+		},
+		{
+			name:     "graphql_java_classification",
+			response: `{"errors":[{"message":"bad field","extensions":{"classification":"ValidationError"}}]}`,
+			category: faults.ValidationError,
+		},
+		{
+			name:     "unknown_code",
+			response: `{"errors":[{"message":"boom"}]}`,
+			category: faults.TransportError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, _ := newTestClient(t, tt.response)
+			_, err := client.Get(context.Background(), projectResource(), projectMetadata())
+			assertTypedCategory(t, err, tt.category)
+		})
+	}
+}
+
+func TestOperationWithoutGraphQLQueryFails(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestClient(t, `{"data":{}}`)
+
+	err := client.Delete(context.Background(), projectResource(), projectMetadata())
+	assertTypedCategory(t, err, faults.ValidationError)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(server.requests))
+	}
+}
+
+func assertTypedCategory(t *testing.T, err error, category faults.ErrorCategory) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	var typedErr *faults.TypedError
+	if !errors.As(err, &typedErr) {
+		t.Fatalf("expected typed error, got %T", err)
+	}
+	if typedErr.Category != category {
+		t.Fatalf("expected %q category, got %q: %v", category, typedErr.Category, err)
+	}
+}
@@ -17,19 +17,28 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/crmarques/declarest/config"
 	"github.com/crmarques/declarest/debugctx"
 	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/internal/execcredential"
 	"github.com/crmarques/declarest/internal/promptauth"
 )
 
+const (
+	oauthTokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oauthJWTTokenType           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
 type authMode int
 
 const (
@@ -37,6 +46,9 @@ const (
 	authModeOAuth2
 	authModeBasic
 	authModeCustomHeaders
+	authModeSigV4
+	authModeSession
+	authModeExec
 )
 
 type authConfig struct {
@@ -44,7 +56,13 @@ type authConfig struct {
 	oauth2        config.OAuth2
 	basicAuth     config.BasicAuth
 	customHeaders []config.HeaderTokenAuth
+	sigv4         config.SigV4Auth
+	session       config.SessionAuth
+	exec          config.ExecAuth
+	execProvider  *execcredential.Provider
 	runtime       *promptauth.Runtime
+
+	clientAssertion *oauthClientAssertionSigner
 }
 
 func (g *Client) InvalidateAuthCache() {
@@ -55,12 +73,36 @@ func (g *Client) InvalidateAuthCache() {
 	defer g.oauthMu.Unlock()
 	g.oauthAccessToken = ""
 	g.oauthExpiresAt = time.Time{}
+	g.oauthSessionStale = true
+	switch g.auth.mode {
+	case authModeSession:
+		g.invalidateSession()
+	case authModeExec:
+		g.auth.execProvider.Invalidate()
+	}
 }
 
 func (c authConfig) shouldRedactHeader(name string) bool {
-	if strings.EqualFold(strings.TrimSpace(name), "Authorization") {
+	// Cookies are redacted in every mode: servers may issue session cookies
+	// whatever authentication the client itself uses.
+	switch {
+	case strings.EqualFold(strings.TrimSpace(name), "Authorization"),
+		strings.EqualFold(strings.TrimSpace(name), "Cookie"),
+		strings.EqualFold(strings.TrimSpace(name), "Set-Cookie"):
+		return true
+	}
+	if c.mode == authModeSigV4 && strings.EqualFold(strings.TrimSpace(name), sigV4SecurityTokenHeader) {
 		return true
 	}
+	if c.mode == authModeSession {
+		if c.session.CSRF != nil {
+			return strings.EqualFold(strings.TrimSpace(name), c.session.CSRF.Header)
+		}
+		return false
+	}
+	if c.mode == authModeExec {
+		return strings.EqualFold(strings.TrimSpace(name), c.exec.Header)
+	}
 	if c.mode != authModeCustomHeaders {
 		return false
 	}
@@ -72,7 +114,7 @@ func (c authConfig) shouldRedactHeader(name string) bool {
 	return false
 }
 
-func buildAuthConfig(cfg *config.HTTPAuth, runtime *promptauth.Runtime) (authConfig, error) {
+func buildAuthConfig(cfg *config.HTTPAuth, tlsSettings *config.TLS, runtime *promptauth.Runtime) (authConfig, error) {
 	if cfg == nil {
 		return authConfig{}, faults.Invalid("managed-service.http.auth is required", nil)
 	}
@@ -87,6 +129,15 @@ func buildAuthConfig(cfg *config.HTTPAuth, runtime *promptauth.Runtime) (authCon
 	if len(cfg.CustomHeaders) > 0 {
 		setCount++
 	}
+	if cfg.SigV4 != nil {
+		setCount++
+	}
+	if cfg.Session != nil {
+		setCount++
+	}
+	if cfg.Exec != nil {
+		setCount++
+	}
 	if setCount != 1 {
 		return authConfig{}, faults.Invalid("managed-service.http.auth must define exactly one auth mode", nil)
 	}
@@ -94,21 +145,51 @@ func buildAuthConfig(cfg *config.HTTPAuth, runtime *promptauth.Runtime) (authCon
 	switch {
 	case cfg.OAuth2 != nil:
 		oauth := *cfg.OAuth2
+		oauth.GrantType = strings.TrimSpace(oauth.GrantType)
+		oauth.ClientAuthMethod = strings.TrimSpace(oauth.ClientAuthMethod)
 		if strings.TrimSpace(oauth.TokenURL) == "" ||
-			strings.TrimSpace(oauth.GrantType) == "" ||
+			oauth.GrantType == "" ||
 			strings.TrimSpace(oauth.ClientID) == "" ||
-			strings.TrimSpace(oauth.ClientSecret) == "" {
+			(oauth.RequiresClientSecret() && strings.TrimSpace(oauth.ClientSecret) == "") {
 			return authConfig{}, faults.Invalid("managed-service.http.auth.oauth2 requires token-url, grant-type, client-id, client-secret", nil)
 		}
-		if strings.TrimSpace(oauth.GrantType) != config.OAuthClientCreds {
-			return authConfig{}, faults.Invalid("managed-service.http.auth.oauth2.grant-type supports only client_credentials", nil)
+		if err := validateOAuthURL("token-url", oauth.TokenURL); err != nil {
+			return authConfig{}, err
+		}
+		switch oauth.GrantType {
+		case config.OAuthClientCreds:
+		case config.OAuthAuthorizationCode:
+			if err := validateOAuthURL("authorization-url", oauth.AuthorizationURL); err != nil {
+				return authConfig{}, err
+			}
+			if _, err := parseOAuthRedirectURL(oauth.RedirectURL); err != nil {
+				return authConfig{}, err
+			}
+		case config.OAuthDeviceCode:
+			if err := validateOAuthURL("device-authorization-url", oauth.DeviceAuthorizationURL); err != nil {
+				return authConfig{}, err
+			}
+		case config.OAuthRefreshToken:
+			if strings.TrimSpace(oauth.RefreshToken) == "" {
+				return authConfig{}, faults.Invalid("managed-service.http.auth.oauth2.refresh-token is required for grant-type refresh_token", nil)
+			}
+		case config.OAuthTokenExchange:
+			if strings.TrimSpace(oauth.SubjectTokenFile) == "" {
+				return authConfig{}, faults.Invalid("managed-service.http.auth.oauth2.subject-token-file is required for grant-type token_exchange", nil)
+			}
+		default:
+			return authConfig{}, faults.Invalid(
+				"managed-service.http.auth.oauth2.grant-type supports only client_credentials, authorization_code, device_code, refresh_token, token_exchange",
+				nil,
+			)
 		}
-		tokenURL, err := url.Parse(oauth.TokenURL)
-		if err != nil || tokenURL.Scheme == "" || tokenURL.Host == "" {
-			return authConfig{}, faults.Invalid("managed-service.http.auth.oauth2.token-url is invalid", err)
+
+		clientAssertion, err := validateOAuthClientAuth(oauth, tlsSettings)
+		if err != nil {
+			return authConfig{}, err
 		}
 
-		return authConfig{mode: authModeOAuth2, oauth2: oauth}, nil
+		return authConfig{mode: authModeOAuth2, oauth2: oauth, runtime: runtime, clientAssertion: clientAssertion}, nil
 	case cfg.Basic != nil:
 		basic := *cfg.Basic
 		if basic.CredentialName() == "" && !basic.HasResolvedCredentials() {
@@ -130,12 +211,44 @@ func buildAuthConfig(cfg *config.HTTPAuth, runtime *promptauth.Runtime) (authCon
 			customHeaders = append(customHeaders, custom)
 		}
 		return authConfig{mode: authModeCustomHeaders, customHeaders: customHeaders}, nil
+	case cfg.SigV4 != nil:
+		sigv4, err := validateSigV4Auth(*cfg.SigV4)
+		if err != nil {
+			return authConfig{}, err
+		}
+		return authConfig{mode: authModeSigV4, sigv4: sigv4}, nil
+	case cfg.Session != nil:
+		session, err := validateSessionAuth(*cfg.Session)
+		if err != nil {
+			return authConfig{}, err
+		}
+		return authConfig{mode: authModeSession, session: session, runtime: runtime}, nil
+	case cfg.Exec != nil:
+		provider, err := execcredential.New("managed-service.http.auth.exec", *cfg.Exec)
+		if err != nil {
+			return authConfig{}, err
+		}
+		exec := *cfg.Exec
+		exec.Header = strings.TrimSpace(exec.Header)
+		exec.Prefix = strings.TrimSpace(exec.Prefix)
+		if exec.Header == "" {
+			exec.Header = "Authorization"
+			if exec.Prefix == "" {
+				exec.Prefix = "Bearer"
+			}
+		}
+		return authConfig{mode: authModeExec, exec: exec, execProvider: provider}, nil
 	default:
 		return authConfig{}, faults.Invalid("managed-service.http.auth is invalid", nil)
 	}
 }
 
 func (g *Client) applyAuth(ctx context.Context, request *http.Request) error {
+	if g.cassette.replaying() {
+		debugctx.Detailf(ctx, "auth skipped while replaying recorded HTTP interactions")
+		return nil
+	}
+
 	switch g.auth.mode {
 	case authModeOAuth2:
 		debugctx.Detailf(ctx, "auth mode=oauth2 token_url=%q client_id=%q", g.auth.oauth2.TokenURL, g.auth.oauth2.ClientID)
@@ -178,6 +291,25 @@ func (g *Client) applyAuth(ctx context.Context, request *http.Request) error {
 			}
 			request.Header.Set(customHeader.Header, value)
 		}
+	case authModeSigV4:
+		if err := g.applySigV4(ctx, request); err != nil {
+			return err
+		}
+	case authModeSession:
+		if err := g.applySession(ctx, request); err != nil {
+			return err
+		}
+	case authModeExec:
+		debugctx.Detailf(ctx, "auth mode=exec command=%q header=%q", g.auth.exec.Command, g.auth.exec.Header)
+		token, err := g.auth.execProvider.Token(ctx)
+		if err != nil {
+			return err
+		}
+		value := token
+		if g.auth.exec.Prefix != "" {
+			value = g.auth.exec.Prefix + " " + token
+		}
+		request.Header.Set(g.auth.exec.Header, value)
 	default:
 		return faults.Invalid("managed-service.http.auth mode is not configured", nil)
 	}
@@ -193,34 +325,193 @@ func (g *Client) oauthToken(ctx context.Context) (string, error) {
 		return g.oauthAccessToken, nil
 	}
 
+	if g.auth.oauth2.IsInteractiveGrant() {
+		token, err := g.interactiveOAuthToken(ctx)
+		if err != nil {
+			return "", err
+		}
+		g.cacheOAuthToken(ctx, token.AccessToken, token.ExpiresAt)
+		return g.oauthAccessToken, nil
+	}
+
+	if g.oauthRefreshToken != "" {
+		token, err := g.refreshOAuthToken(ctx, g.oauthRefreshToken)
+		if err == nil {
+			g.cacheOAuthToken(ctx, token.AccessToken, token.ExpiresAt)
+			g.oauthRefreshToken = token.RefreshToken
+			return g.oauthAccessToken, nil
+		}
+		debugctx.Detailf(ctx, "oauth2 refresh failed, requesting a new token: %v", err)
+		g.oauthRefreshToken = ""
+	}
+
 	debugctx.Detailf(ctx, "oauth2 requesting new token token_url=%q grant_type=%q", g.auth.oauth2.TokenURL, g.auth.oauth2.GrantType)
 
+	formValues, err := g.oauthGrantForm()
+	if err != nil {
+		return "", err
+	}
+	g.setOAuthScope(formValues)
+
+	token, err := g.requestOAuthToken(ctx, formValues)
+	if err != nil {
+		return "", err
+	}
+	g.cacheOAuthToken(ctx, token.AccessToken, token.expiresAt())
+	g.oauthRefreshToken = token.RefreshToken
+	return g.oauthAccessToken, nil
+}
+
+// oauthGrantForm builds the token request for the non-interactive grants.
+func (g *Client) oauthGrantForm() (url.Values, error) {
 	formValues := url.Values{}
-	formValues.Set("grant_type", g.auth.oauth2.GrantType)
-	formValues.Set("client_id", g.auth.oauth2.ClientID)
-	formValues.Set("client_secret", g.auth.oauth2.ClientSecret)
+	switch g.auth.oauth2.GrantType {
+	case config.OAuthRefreshToken:
+		formValues.Set("grant_type", oauthRefreshTokenGrantType)
+		formValues.Set("refresh_token", strings.TrimSpace(g.auth.oauth2.RefreshToken))
+	case config.OAuthTokenExchange:
+		subjectToken, err := readOAuthSubjectToken(g.auth.oauth2.SubjectTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		subjectTokenType := strings.TrimSpace(g.auth.oauth2.SubjectTokenType)
+		if subjectTokenType == "" {
+			subjectTokenType = oauthJWTTokenType
+		}
+		formValues.Set("grant_type", oauthTokenExchangeGrantType)
+		formValues.Set("subject_token", subjectToken)
+		formValues.Set("subject_token_type", subjectTokenType)
+	default:
+		formValues.Set("grant_type", g.auth.oauth2.GrantType)
+	}
+	return formValues, nil
+}
+
+// readOAuthSubjectToken reads the token-exchange subject token on every
+// exchange, because projected service-account tokens rotate on disk.
+func readOAuthSubjectToken(path string) (string, error) {
+	content, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		return "", faults.Auth("failed to read oauth2 subject token file", err)
+	}
+	subjectToken := strings.TrimSpace(string(content))
+	if subjectToken == "" {
+		return "", faults.Auth("oauth2 subject token file is empty", nil)
+	}
+	return subjectToken, nil
+}
+
+type oauthTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (t oauthTokenResponse) expiresAt() time.Time {
+	if t.ExpiresIn > 0 {
+		return time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return time.Now().Add(time.Hour)
+}
+
+// oauthTokenRequestError carries the RFC 6749 error code of a rejected token
+// request so grant flows can react to codes such as authorization_pending.
+type oauthTokenRequestError struct {
+	code string
+	err  error
+}
+
+func (e *oauthTokenRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *oauthTokenRequestError) Unwrap() error {
+	return e.err
+}
+
+func oauthErrorCode(err error) string {
+	var tokenErr *oauthTokenRequestError
+	if errors.As(err, &tokenErr) {
+		return tokenErr.code
+	}
+	return ""
+}
+
+func (g *Client) setOAuthScope(formValues url.Values) {
 	if strings.TrimSpace(g.auth.oauth2.Scope) != "" {
 		formValues.Set("scope", g.auth.oauth2.Scope)
 	}
 	if strings.TrimSpace(g.auth.oauth2.Audience) != "" {
 		formValues.Set("audience", g.auth.oauth2.Audience)
 	}
+}
+
+func (g *Client) cacheOAuthToken(ctx context.Context, accessToken string, expiresAt time.Time) {
+	g.oauthAccessToken = accessToken
+	g.oauthExpiresAt = expiresAt
+
+	debugctx.Detailf(ctx, "oauth2 token acquired expires_at=%s", expiresAt.Format(time.RFC3339))
+}
+
+// requestOAuthToken posts formValues to the token endpoint and returns the
+// decoded token response. Rejections keep their OAuth2 error code.
+func (g *Client) requestOAuthToken(ctx context.Context, formValues url.Values) (oauthTokenResponse, error) {
+	statusCode, body, err := g.postOAuthForm(ctx, "oauth2-token", g.auth.oauth2.TokenURL, formValues)
+	if err != nil {
+		return oauthTokenResponse{}, err
+	}
+
+	var tokenResponse oauthTokenResponse
+	decodeErr := json.Unmarshal(body, &tokenResponse)
+	if statusCode >= http.StatusBadRequest {
+		debugctx.Infof(
+			ctx,
+			"oauth2 token error status=%d response_body=%s",
+			statusCode,
+			summarizeBodyForLevel(body, debugctx.Level(ctx)),
+		)
+		return oauthTokenResponse{}, &oauthTokenRequestError{
+			code: strings.TrimSpace(tokenResponse.Error),
+			err: faults.Auth(
+				fmt.Sprintf("oauth2 token request failed with status %d: %s", statusCode, summarizeBody(body)),
+				nil,
+			),
+		}
+	}
+	if decodeErr != nil {
+		return oauthTokenResponse{}, faults.Auth("oauth2 token response is not valid JSON", decodeErr)
+	}
+	if strings.TrimSpace(tokenResponse.AccessToken) == "" {
+		return oauthTokenResponse{}, faults.Auth("oauth2 token response does not include access_token", nil)
+	}
+	return tokenResponse, nil
+}
+
+// postOAuthForm sends formValues, plus the configured client authentication,
+// to an OAuth2 endpoint and returns the status code and body.
+func (g *Client) postOAuthForm(ctx context.Context, purpose string, endpoint string, formValues url.Values) (int, []byte, error) {
+	formValues = maps.Clone(formValues)
+	if err := g.setOAuthClient(formValues); err != nil {
+		return 0, nil, err
+	}
 
 	request, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		g.auth.oauth2.TokenURL,
+		endpoint,
 		strings.NewReader(formValues.Encode()),
 	)
 	if err != nil {
-		return "", faults.Internal("failed to create oauth2 token request", err)
+		return 0, nil, faults.Internal("failed to create "+purpose+" request", err)
 	}
 	request.Header.Set("Accept", defaultMediaType)
 	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	response, err := g.doRequest(ctx, "oauth2-token", request)
+	response, err := g.doRequest(ctx, purpose, request)
 	if err != nil {
-		return "", faults.Transport("oauth2 token request failed", err)
+		return 0, nil, faults.Transport(strings.ReplaceAll(purpose, "-", " ")+" request failed", err)
 	}
 	defer func() {
 		_ = response.Body.Close()
@@ -228,42 +519,15 @@ func (g *Client) oauthToken(ctx context.Context) (string, error) {
 
 	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
 	if err != nil {
-		return "", faults.Transport("failed to read oauth2 token response", err)
-	}
-
-	if response.StatusCode >= http.StatusBadRequest {
-		debugctx.Infof(
-			ctx,
-			"oauth2 token error status=%d response_body=%s",
-			response.StatusCode,
-			summarizeBodyForLevel(body, debugctx.Level(ctx)),
-		)
-		return "", faults.Auth(
-			fmt.Sprintf("oauth2 token request failed with status %d: %s", response.StatusCode, summarizeBody(body)),
-			nil,
-		)
-	}
-
-	var tokenResponse struct {
-		AccessToken string `json:"access_token"`
-		ExpiresIn   int64  `json:"expires_in"`
-	}
-	if err := json.Unmarshal(body, &tokenResponse); err != nil {
-		return "", faults.Auth("oauth2 token response is not valid JSON", err)
-	}
-	if strings.TrimSpace(tokenResponse.AccessToken) == "" {
-		return "", faults.Auth("oauth2 token response does not include access_token", nil)
+		return 0, nil, faults.Transport("failed to read "+strings.ReplaceAll(purpose, "-", " ")+" response", err)
 	}
+	return response.StatusCode, body, nil
+}
 
-	expiresAt := time.Now().Add(time.Hour)
-	if tokenResponse.ExpiresIn > 0 {
-		expiresAt = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+func validateOAuthURL(field string, value string) error {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return faults.Invalid("managed-service.http.auth.oauth2."+field+" is invalid", err)
 	}
-
-	g.oauthAccessToken = tokenResponse.AccessToken
-	g.oauthExpiresAt = expiresAt
-
-	debugctx.Detailf(ctx, "oauth2 token acquired expires_in=%ds expires_at=%s", tokenResponse.ExpiresIn, expiresAt.Format(time.RFC3339))
-
-	return g.oauthAccessToken, nil
+	return nil
 }
@@ -0,0 +1,111 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// rotatingExecHelper prints token-1, token-2, ... on successive runs.
+func rotatingExecHelper(t *testing.T) config.ExecAuth {
+	t.Helper()
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	return config.ExecAuth{
+		Command: "/bin/sh",
+		Args: []string{"-c", `echo run >> "$RUNS_FILE"; ` +
+			`printf '{"kind":"ExecCredential","status":{"token":"token-%s"}}' "$(wc -l < "$RUNS_FILE" | tr -d ' ')"`},
+		Env: map[string]string{"RUNS_FILE": counter},
+	}
+}
+
+func TestExecAuthInjectsBearerTokenAndRenewsOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	accepted := "token-1"
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer "+accepted {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"a"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	exec := rotatingExecHelper(t)
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth:    &config.HTTPAuth{Exec: &exec},
+	})
+	md := metadata.ResourceMetadata{
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationGet): {Path: "/items/a"},
+		},
+	}
+	get := func() {
+		t.Helper()
+		if _, err := client.Get(context.Background(), resource.Resource{LogicalPath: "/items/a"}, md); err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+	}
+
+	get()
+	get()
+	mu.Lock()
+	accepted = "token-2"
+	mu.Unlock()
+	get()
+
+	expected := []string{"Bearer token-1", "Bearer token-1", "Bearer token-1", "Bearer token-2"}
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(seen) != fmt.Sprint(expected) {
+		t.Fatalf("expected Authorization headers %v, got %v", expected, seen)
+	}
+}
+
+func TestExecAuthCustomHeaderIsRedacted(t *testing.T) {
+	t.Parallel()
+
+	auth, err := buildAuthConfig(&config.HTTPAuth{Exec: &config.ExecAuth{
+		Command: "company-token",
+		Header:  "X-Api-Token",
+	}}, nil, nil)
+	if err != nil {
+		t.Fatalf("buildAuthConfig returned error: %v", err)
+	}
+	if auth.exec.Prefix != "" {
+		t.Fatalf("expected no default prefix for a custom header, got %q", auth.exec.Prefix)
+	}
+	if !auth.shouldRedactHeader("x-api-token") || !auth.shouldRedactHeader("Authorization") {
+		t.Fatal("expected exec token header and Authorization to be redacted")
+	}
+}
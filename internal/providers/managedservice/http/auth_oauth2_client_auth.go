@@ -0,0 +1,235 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+)
+
+const (
+	oauthClientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	oauthClientAssertionLifetime = 5 * time.Minute
+)
+
+// oauthClientAssertionSigner signs the RFC 7523 client assertions sent by the
+// private_key_jwt client authentication method.
+type oauthClientAssertionSigner struct {
+	key       crypto.Signer
+	algorithm string
+	hash      crypto.Hash
+	keyID     string
+}
+
+// setOAuthClient authenticates the client on every token-endpoint request.
+// tls_client_auth relies on the certificate presented by the transport, and
+// public clients have no secret, so client_secret is only sent when set.
+func (g *Client) setOAuthClient(formValues url.Values) error {
+	formValues.Set("client_id", g.auth.oauth2.ClientID)
+
+	switch g.auth.oauth2.ClientAuthMethod {
+	case config.OAuthPrivateKeyJWT:
+		assertion, err := g.auth.clientAssertion.sign(g.auth.oauth2.ClientID, g.auth.oauth2.TokenURL)
+		if err != nil {
+			return err
+		}
+		formValues.Set("client_assertion_type", oauthClientAssertionType)
+		formValues.Set("client_assertion", assertion)
+	case config.OAuthTLSClientAuth:
+	default:
+		if strings.TrimSpace(g.auth.oauth2.ClientSecret) != "" {
+			formValues.Set("client_secret", g.auth.oauth2.ClientSecret)
+		}
+	}
+	return nil
+}
+
+func validateOAuthClientAuth(oauth config.OAuth2, tlsSettings *config.TLS) (*oauthClientAssertionSigner, error) {
+	switch oauth.ClientAuthMethod {
+	case "", config.OAuthClientSecretPost:
+		return nil, nil
+	case config.OAuthPrivateKeyJWT:
+		if strings.TrimSpace(oauth.ClientAssertionKeyFile) == "" {
+			return nil, faults.Invalid(
+				"managed-service.http.auth.oauth2.client-assertion-key-file is required for client-auth-method private_key_jwt",
+				nil,
+			)
+		}
+		return loadOAuthClientAssertionSigner(oauth.ClientAssertionKeyFile, oauth.ClientAssertionKeyID)
+	case config.OAuthTLSClientAuth:
+		if tlsSettings == nil ||
+			strings.TrimSpace(tlsSettings.ClientCertFile) == "" ||
+			strings.TrimSpace(tlsSettings.ClientKeyFile) == "" {
+			return nil, faults.Invalid(
+				"managed-service.http.auth.oauth2.client-auth-method tls_client_auth requires managed-service.http.tls client-cert-file and client-key-file",
+				nil,
+			)
+		}
+		return nil, nil
+	default:
+		return nil, faults.Invalid(
+			"managed-service.http.auth.oauth2.client-auth-method supports only client_secret_post, private_key_jwt, tls_client_auth",
+			nil,
+		)
+	}
+}
+
+// loadOAuthClientAssertionSigner reads an RSA or EC private key in PEM form
+// (PKCS#8, PKCS#1, or SEC 1) and picks the matching JWS algorithm.
+func loadOAuthClientAssertionSigner(path string, keyID string) (*oauthClientAssertionSigner, error) {
+	content, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		return nil, faults.Invalid("failed to read managed-service.http.auth.oauth2.client-assertion-key-file", err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, faults.Invalid("managed-service.http.auth.oauth2.client-assertion-key-file is not a PEM private key", nil)
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, faults.Invalid("managed-service.http.auth.oauth2.client-assertion-key-file is not a valid private key", err)
+	}
+
+	signer := &oauthClientAssertionSigner{keyID: strings.TrimSpace(keyID)}
+	switch typed := key.(type) {
+	case *rsa.PrivateKey:
+		signer.key, signer.algorithm, signer.hash = typed, "RS256", crypto.SHA256
+	case *ecdsa.PrivateKey:
+		signer.key = typed
+		switch typed.Curve {
+		case elliptic.P256():
+			signer.algorithm, signer.hash = "ES256", crypto.SHA256
+		case elliptic.P384():
+			signer.algorithm, signer.hash = "ES384", crypto.SHA384
+		case elliptic.P521():
+			signer.algorithm, signer.hash = "ES512", crypto.SHA512
+		default:
+			return nil, faults.Invalid("managed-service.http.auth.oauth2.client-assertion-key-file uses an unsupported EC curve", nil)
+		}
+	default:
+		return nil, faults.Invalid(
+			fmt.Sprintf("managed-service.http.auth.oauth2.client-assertion-key-file key type %T is not supported; use RSA or EC", key),
+			nil,
+		)
+	}
+	return signer, nil
+}
+
+// sign builds a short-lived client assertion with a unique jti, so every
+// token request carries a fresh one.
+func (s *oauthClientAssertionSigner) sign(clientID string, audience string) (string, error) {
+	if s == nil {
+		return "", faults.Invalid("managed-service.http.auth.oauth2 client assertion key is not configured", nil)
+	}
+	jti, err := randomOAuthValue()
+	if err != nil {
+		return "", err
+	}
+
+	header := map[string]string{"alg": s.algorithm, "typ": "JWT"}
+	if s.keyID != "" {
+		header["kid"] = s.keyID
+	}
+	now := time.Now()
+	claims := map[string]any{
+		"iss": clientID,
+		"sub": clientID,
+		"aud": audience,
+		"jti": jti,
+		"iat": now.Unix(),
+		"exp": now.Add(oauthClientAssertionLifetime).Unix(),
+	}
+
+	encodedHeader, err := encodeJWTSegment(header)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeJWTSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encodedHeader + "." + encodedClaims
+
+	signature, err := s.signDigest(signingInput)
+	if err != nil {
+		return "", faults.Internal("failed to sign oauth2 client assertion", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (s *oauthClientAssertionSigner) signDigest(signingInput string) ([]byte, error) {
+	var digest []byte
+	switch s.hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(signingInput))
+		digest = sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(signingInput))
+		digest = sum[:]
+	default:
+		sum := sha256.Sum256([]byte(signingInput))
+		digest = sum[:]
+	}
+
+	ecKey, ok := s.key.(*ecdsa.PrivateKey)
+	if !ok {
+		return s.key.Sign(rand.Reader, digest, s.hash)
+	}
+
+	// JWS encodes ECDSA signatures as fixed-size R || S rather than ASN.1.
+	r, sig, err := ecdsa.Sign(rand.Reader, ecKey, digest)
+	if err != nil {
+		return nil, err
+	}
+	size := (ecKey.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	sig.FillBytes(signature[size:])
+	return signature, nil
+}
+
+func encodeJWTSegment(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", faults.Internal("failed to encode oauth2 client assertion", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+)
+
+func writePrivateKeyPEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "client-assertion.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	return path
+}
+
+// verifyClientAssertion checks the JWS signature and returns the header and
+// claims of a private_key_jwt client assertion.
+func verifyClientAssertion(t *testing.T, assertion string, publicKey crypto.PublicKey) (map[string]any, map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected compact JWS, got %q", assertion)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			t.Fatalf("RS256 signature does not verify: %v", err)
+		}
+	case *ecdsa.PublicKey:
+		if len(signature) != 64 {
+			t.Fatalf("expected 64-byte ES256 signature, got %d bytes", len(signature))
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, digest[:], r, s) {
+			t.Fatal("ES256 signature does not verify")
+		}
+	default:
+		t.Fatalf("unsupported public key %T", publicKey)
+	}
+
+	decode := func(segment string) map[string]any {
+		raw, err := base64.RawURLEncoding.DecodeString(segment)
+		if err != nil {
+			t.Fatalf("failed to decode JWS segment: %v", err)
+		}
+		value := map[string]any{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			t.Fatalf("failed to decode JWS segment JSON: %v", err)
+		}
+		return value
+	}
+	return decode(parts[0]), decode(parts[1])
+}
+
+func TestOAuthPrivateKeyJWTClientAuthentication(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey returned error: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	rsaDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey returned error: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		keyFile   string
+		publicKey crypto.PublicKey
+		algorithm string
+	}{
+		{name: "ec_sec1", keyFile: writePrivateKeyPEM(t, "EC PRIVATE KEY", ecDER), publicKey: &ecKey.PublicKey, algorithm: "ES256"},
+		{name: "rsa_pkcs8", keyFile: writePrivateKeyPEM(t, "PRIVATE KEY", rsaDER), publicKey: &rsaKey.PublicKey, algorithm: "RS256"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tokenURL string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("ParseForm returned error: %v", err)
+				}
+				if r.PostForm.Has("client_secret") {
+					t.Errorf("expected no client_secret with private_key_jwt, got %v", r.PostForm)
+				}
+				if got := r.PostForm.Get("client_assertion_type"); got != oauthClientAssertionType {
+					t.Errorf("unexpected client_assertion_type %q", got)
+				}
+				header, claims := verifyClientAssertion(t, r.PostForm.Get("client_assertion"), tc.publicKey)
+				if header["alg"] != tc.algorithm || header["kid"] != "key-1" {
+					t.Errorf("unexpected assertion header %v", header)
+				}
+				if claims["iss"] != "svc-client" || claims["sub"] != "svc-client" || claims["aud"] != tokenURL || claims["jti"] == "" {
+					t.Errorf("unexpected assertion claims %v", claims)
+				}
+				_, _ = fmt.Fprint(w, `{"access_token":"jwt-client-token","expires_in":3600}`)
+			}))
+			t.Cleanup(server.Close)
+			tokenURL = server.URL + "/token"
+
+			client := mustOAuthClient(t, config.OAuth2{
+				TokenURL:               tokenURL,
+				GrantType:              config.OAuthClientCreds,
+				ClientID:               "svc-client",
+				ClientAuthMethod:       config.OAuthPrivateKeyJWT,
+				ClientAssertionKeyFile: tc.keyFile,
+				ClientAssertionKeyID:   "key-1",
+			})
+
+			token, err := client.GetAccessToken(context.Background())
+			if err != nil {
+				t.Fatalf("GetAccessToken returned error: %v", err)
+			}
+			if token != "jwt-client-token" {
+				t.Fatalf("expected jwt-client-token, got %q", token)
+			}
+		})
+	}
+}
+
+func TestOAuthTLSClientAuthentication(t *testing.T) {
+	t.Parallel()
+
+	_, clientCertFile, clientKeyFile := writeTLSClientPairFiles(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			t.Errorf("expected token request to present a client certificate")
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		if r.PostForm.Get("client_id") != "mtls-client" || r.PostForm.Has("client_secret") || r.PostForm.Has("client_assertion") {
+			t.Errorf("unexpected tls_client_auth form %v", r.PostForm)
+		}
+		_, _ = fmt.Fprint(w, `{"access_token":"mtls-token","expires_in":3600}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{OAuth2: &config.OAuth2{
+			TokenURL:         server.URL + "/token",
+			GrantType:        config.OAuthClientCreds,
+			ClientID:         "mtls-client",
+			ClientAuthMethod: config.OAuthTLSClientAuth,
+		}},
+		TLS: &config.TLS{
+			ClientCertFile:     clientCertFile,
+			ClientKeyFile:      clientKeyFile,
+			InsecureSkipVerify: true,
+		},
+	})
+
+	token, err := client.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if token != "mtls-token" {
+		t.Fatalf("expected mtls-token, got %q", token)
+	}
+}
+
+func TestOAuthClientAuthMethodValidation(t *testing.T) {
+	t.Parallel()
+
+	notAKey := filepath.Join(t.TempDir(), "not-a-key.pem")
+	if err := os.WriteFile(notAKey, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	testCases := []struct {
+		name  string
+		oauth config.OAuth2
+	}{
+		{
+			name:  "private_key_jwt_requires_key_file",
+			oauth: config.OAuth2{ClientAuthMethod: config.OAuthPrivateKeyJWT},
+		},
+		{
+			name:  "private_key_jwt_rejects_invalid_key",
+			oauth: config.OAuth2{ClientAuthMethod: config.OAuthPrivateKeyJWT, ClientAssertionKeyFile: notAKey},
+		},
+		{
+			name:  "tls_client_auth_requires_client_certificate",
+			oauth: config.OAuth2{ClientAuthMethod: config.OAuthTLSClientAuth},
+		},
+		{
+			name:  "unsupported_method",
+			oauth: config.OAuth2{ClientAuthMethod: "client_secret_jwt", ClientSecret: "secret"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			oauth := tc.oauth
+			oauth.TokenURL = "https://sso.example.test/token"
+			oauth.GrantType = config.OAuthClientCreds
+			oauth.ClientID = "svc-client"
+			_, err := NewClient(config.HTTPServer{
+				BaseURL: "https://api.example.test",
+				Auth:    &config.HTTPAuth{OAuth2: &oauth},
+			})
+			assertTypedCategory(t, err, faults.ValidationError)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+)
+
+// recordingTokenServer answers token requests with the given responses in
+// order and records each request form.
+func recordingTokenServer(t *testing.T, responses ...string) (*httptest.Server, func() []url.Values) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		mu.Lock()
+		index := len(forms)
+		forms = append(forms, r.PostForm)
+		mu.Unlock()
+		if index >= len(responses) {
+			t.Errorf("unexpected token request %d: %v", index+1, r.PostForm)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if responses[index] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, responses[index])
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []url.Values {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]url.Values(nil), forms...)
+	}
+}
+
+func mustOAuthClient(t *testing.T, oauth config.OAuth2) *Client {
+	t.Helper()
+	return mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: "https://api.example.test",
+		Auth:    &config.HTTPAuth{OAuth2: &oauth},
+	})
+}
+
+func TestOAuthClientCredentialsUsesReturnedRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	server, forms := recordingTokenServer(t,
+		`{"access_token":"token-1","refresh_token":"refresh-1","expires_in":1}`,
+		`{"access_token":"token-2","refresh_token":"refresh-2","expires_in":1}`,
+		"",
+		`{"access_token":"token-3","expires_in":3600}`,
+	)
+	client := mustOAuthClient(t, config.OAuth2{
+		TokenURL:     server.URL,
+		GrantType:    config.OAuthClientCreds,
+		ClientID:     "client",
+		ClientSecret: "secret",
+	})
+
+	for _, expected := range []string{"token-1", "token-2", "token-3"} {
+		token, err := client.GetAccessToken(context.Background())
+		if err != nil {
+			t.Fatalf("GetAccessToken returned error: %v", err)
+		}
+		if token != expected {
+			t.Fatalf("expected %q, got %q", expected, token)
+		}
+	}
+
+	requests := forms()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 token requests, got %d", len(requests))
+	}
+	expectedGrants := []string{"client_credentials", "refresh_token", "refresh_token", "client_credentials"}
+	for idx, expected := range expectedGrants {
+		if got := requests[idx].Get("grant_type"); got != expected {
+			t.Fatalf("request %d: expected grant_type %q, got %q", idx+1, expected, got)
+		}
+	}
+	if requests[1].Get("refresh_token") != "refresh-1" || requests[2].Get("refresh_token") != "refresh-2" {
+		t.Fatalf("expected rotated refresh tokens, got %q and %q", requests[1].Get("refresh_token"), requests[2].Get("refresh_token"))
+	}
+	if requests[1].Get("client_secret") != "secret" {
+		t.Fatalf("expected refresh request to authenticate the client, got %v", requests[1])
+	}
+}
+
+func TestOAuthRefreshTokenGrantUsesConfiguredToken(t *testing.T) {
+	t.Parallel()
+
+	server, forms := recordingTokenServer(t, `{"access_token":"offline-access","expires_in":3600}`)
+	client := mustOAuthClient(t, config.OAuth2{
+		TokenURL:     server.URL,
+		GrantType:    config.OAuthRefreshToken,
+		ClientID:     "cli",
+		RefreshToken: "offline-token",
+		Scope:        "api",
+	})
+
+	token, err := client.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if token != "offline-access" {
+		t.Fatalf("expected offline-access, got %q", token)
+	}
+	request := forms()[0]
+	if request.Get("grant_type") != "refresh_token" ||
+		request.Get("refresh_token") != "offline-token" ||
+		request.Get("scope") != "api" ||
+		request.Has("client_secret") {
+		t.Fatalf("unexpected refresh_token grant request %v", request)
+	}
+}
+
+func TestOAuthTokenExchangeReadsSubjectTokenFile(t *testing.T) {
+	t.Parallel()
+
+	subjectTokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(subjectTokenFile, []byte("projected-jwt\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	server, forms := recordingTokenServer(t,
+		`{"access_token":"exchanged-1","expires_in":1}`,
+		`{"access_token":"exchanged-2","expires_in":3600}`,
+	)
+	client := mustOAuthClient(t, config.OAuth2{
+		TokenURL:         server.URL,
+		GrantType:        config.OAuthTokenExchange,
+		ClientID:         "operator",
+		SubjectTokenFile: subjectTokenFile,
+		Audience:         "https://api.example.test",
+	})
+
+	if _, err := client.GetAccessToken(context.Background()); err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if err := os.WriteFile(subjectTokenFile, []byte("rotated-jwt"), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	token, err := client.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if token != "exchanged-2" {
+		t.Fatalf("expected exchanged-2, got %q", token)
+	}
+
+	requests := forms()
+	first := requests[0]
+	if first.Get("grant_type") != oauthTokenExchangeGrantType ||
+		first.Get("subject_token") != "projected-jwt" ||
+		first.Get("subject_token_type") != oauthJWTTokenType ||
+		first.Get("audience") != "https://api.example.test" ||
+		first.Get("client_id") != "operator" {
+		t.Fatalf("unexpected token exchange request %v", first)
+	}
+	if got := requests[1].Get("subject_token"); got != "rotated-jwt" {
+		t.Fatalf("expected the rotated subject token to be re-read, got %q", got)
+	}
+}
+
+func TestOAuthTokenExchangeMissingSubjectTokenFileFailsWithAuthError(t *testing.T) {
+	t.Parallel()
+
+	client := mustOAuthClient(t, config.OAuth2{
+		TokenURL:         "https://sso.example.test/token",
+		GrantType:        config.OAuthTokenExchange,
+		ClientID:         "operator",
+		SubjectTokenFile: filepath.Join(t.TempDir(), "missing"),
+	})
+
+	_, err := client.GetAccessToken(context.Background())
+	assertTypedCategory(t, err, faults.AuthError)
+}
+
+func TestOAuthNonInteractiveGrantValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		oauth config.OAuth2
+	}{
+		{
+			name: "client_credentials_requires_client_secret",
+			oauth: config.OAuth2{
+				TokenURL:  "https://sso.example.test/token",
+				GrantType: config.OAuthClientCreds,
+				ClientID:  "cli",
+			},
+		},
+		{
+			name: "refresh_token_requires_refresh_token",
+			oauth: config.OAuth2{
+				TokenURL:  "https://sso.example.test/token",
+				GrantType: config.OAuthRefreshToken,
+				ClientID:  "cli",
+			},
+		},
+		{
+			name: "token_exchange_requires_subject_token_file",
+			oauth: config.OAuth2{
+				TokenURL:  "https://sso.example.test/token",
+				GrantType: config.OAuthTokenExchange,
+				ClientID:  "cli",
+			},
+		},
+		{
+			name: "unsupported_grant_type",
+			oauth: config.OAuth2{
+				TokenURL:     "https://sso.example.test/token",
+				GrantType:    "password",
+				ClientID:     "cli",
+				ClientSecret: "secret",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			oauth := tc.oauth
+			_, err := NewClient(config.HTTPServer{
+				BaseURL: "https://api.example.test",
+				Auth:    &config.HTTPAuth{OAuth2: &oauth},
+			})
+			assertTypedCategory(t, err, faults.ValidationError)
+		})
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+)
+
+const (
+	oauthDeviceCodeGrantType     = "urn:ietf:params:oauth:grant-type:device_code"
+	oauthRefreshTokenGrantType   = "refresh_token"
+	oauthDefaultRedirectPath     = "/callback"
+	oauthLoginTimeout            = 5 * time.Minute
+	oauthDefaultDevicePollPeriod = 5 * time.Second
+	oauthSessionKeyPrefix        = "DECLAREST_OAUTH2_TOKEN_"
+)
+
+// oauthSessionToken is the interactive-login token cached in the prompt-auth
+// session store so one login lasts across commands in the same shell.
+type oauthSessionToken struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+type oauthDeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+type oauthCallbackResult struct {
+	code string
+	err  error
+}
+
+// interactiveOAuthToken returns a token for the authorization_code and
+// device_code grants. A valid session token is reused, an expired one is
+// refreshed, and a new login starts only when no refresh token works.
+func (g *Client) interactiveOAuthToken(ctx context.Context) (oauthSessionToken, error) {
+	key := g.oauthSessionKey()
+	cached, found := g.loadOAuthSessionToken(key)
+	stale := g.oauthSessionStale
+	g.oauthSessionStale = false
+
+	if found && !stale && time.Now().Before(cached.ExpiresAt.Add(-30*time.Second)) {
+		debugctx.Detailf(ctx, "oauth2 using session token expires_at=%s", cached.ExpiresAt.Format(time.RFC3339))
+		return cached, nil
+	}
+
+	if found && cached.RefreshToken != "" {
+		token, err := g.refreshOAuthToken(ctx, cached.RefreshToken)
+		if err == nil {
+			return token, g.storeOAuthSessionToken(key, token)
+		}
+		debugctx.Detailf(ctx, "oauth2 refresh failed, starting a new login: %v", err)
+	}
+
+	var token oauthSessionToken
+	var err error
+	switch g.auth.oauth2.GrantType {
+	case config.OAuthDeviceCode:
+		token, err = g.loginWithDeviceCode(ctx)
+	default:
+		token, err = g.loginWithAuthorizationCode(ctx)
+	}
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	return token, g.storeOAuthSessionToken(key, token)
+}
+
+func (g *Client) refreshOAuthToken(ctx context.Context, refreshToken string) (oauthSessionToken, error) {
+	debugctx.Detailf(ctx, "oauth2 refreshing token token_url=%q", g.auth.oauth2.TokenURL)
+
+	formValues := url.Values{}
+	formValues.Set("grant_type", oauthRefreshTokenGrantType)
+	formValues.Set("refresh_token", refreshToken)
+
+	token, err := g.requestOAuthToken(ctx, formValues)
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	return newOAuthSessionToken(token, refreshToken), nil
+}
+
+// loginWithAuthorizationCode runs the authorization-code grant with PKCE
+// (RFC 7636), receiving the code on a loopback redirect (RFC 8252).
+func (g *Client) loginWithAuthorizationCode(ctx context.Context) (oauthSessionToken, error) {
+	redirectURL, err := parseOAuthRedirectURL(g.auth.oauth2.RedirectURL)
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	listener, err := net.Listen("tcp", redirectURL.Host)
+	if err != nil {
+		return oauthSessionToken{}, faults.Invalid(
+			fmt.Sprintf("failed to listen for the oauth2 redirect on %s", redirectURL.Host),
+			err,
+		)
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+	if _, port, splitErr := net.SplitHostPort(listener.Addr().String()); splitErr == nil {
+		redirectURL.Host = net.JoinHostPort(redirectURL.Hostname(), port)
+	}
+
+	verifier, err := randomOAuthValue()
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	state, err := randomOAuthValue()
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authorizationURL, err := url.Parse(g.auth.oauth2.AuthorizationURL)
+	if err != nil {
+		return oauthSessionToken{}, faults.Invalid("managed-service.http.auth.oauth2.authorization-url is invalid", err)
+	}
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", g.auth.oauth2.ClientID)
+	query.Set("redirect_uri", redirectURL.String())
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	g.setOAuthScope(query)
+	authorizationURL.RawQuery = query.Encode()
+
+	results := make(chan oauthCallbackResult, 1)
+	server := &http.Server{
+		Handler:           oauthCallbackHandler(redirectURL.Path, state, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer func() {
+		_ = server.Close()
+	}()
+
+	g.printOAuthLogin(fmt.Sprintf("Open the following URL in a browser to log in:\n\n  %s\n", authorizationURL.String()))
+	if g.oauthOpenBrowser != nil {
+		if err := g.oauthOpenBrowser(authorizationURL.String()); err != nil {
+			debugctx.Detailf(ctx, "oauth2 could not open a browser: %v", err)
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, oauthLoginTimeout)
+	defer cancel()
+
+	var result oauthCallbackResult
+	select {
+	case result = <-results:
+	case <-waitCtx.Done():
+		return oauthSessionToken{}, faults.Auth("oauth2 login was not completed in time", waitCtx.Err())
+	}
+	if result.err != nil {
+		return oauthSessionToken{}, result.err
+	}
+
+	formValues := url.Values{}
+	formValues.Set("grant_type", config.OAuthAuthorizationCode)
+	formValues.Set("code", result.code)
+	formValues.Set("redirect_uri", redirectURL.String())
+	formValues.Set("code_verifier", verifier)
+
+	token, err := g.requestOAuthToken(ctx, formValues)
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	return newOAuthSessionToken(token, ""), nil
+}
+
+func oauthCallbackHandler(path string, state string, results chan<- oauthCallbackResult) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		var result oauthCallbackResult
+		switch {
+		case query.Get("state") != state:
+			result.err = faults.Auth("oauth2 login redirect state does not match", nil)
+		case query.Get("error") != "":
+			result.err = faults.Auth(
+				fmt.Sprintf("oauth2 login failed: %s %s", query.Get("error"), query.Get("error_description")),
+				nil,
+			)
+		case query.Get("code") == "":
+			result.err = faults.Auth("oauth2 login redirect does not include a code", nil)
+		default:
+			result.code = query.Get("code")
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if result.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintln(w, "Login failed. Return to the terminal for details.")
+		} else {
+			_, _ = fmt.Fprintln(w, "Login complete. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+}
+
+// loginWithDeviceCode runs the device authorization grant (RFC 8628).
+func (g *Client) loginWithDeviceCode(ctx context.Context) (oauthSessionToken, error) {
+	formValues := url.Values{}
+	g.setOAuthScope(formValues)
+
+	statusCode, body, err := g.postOAuthForm(ctx, "oauth2-device-authorization", g.auth.oauth2.DeviceAuthorizationURL, formValues)
+	if err != nil {
+		return oauthSessionToken{}, err
+	}
+	if statusCode >= http.StatusBadRequest {
+		return oauthSessionToken{}, faults.Auth(
+			fmt.Sprintf("oauth2 device authorization failed with status %d: %s", statusCode, summarizeBody(body)),
+			nil,
+		)
+	}
+
+	var authorization oauthDeviceAuthorization
+	if err := json.Unmarshal(body, &authorization); err != nil {
+		return oauthSessionToken{}, faults.Auth("oauth2 device authorization response is not valid JSON", err)
+	}
+	if authorization.DeviceCode == "" || authorization.UserCode == "" || authorization.VerificationURI == "" {
+		return oauthSessionToken{}, faults.Auth(
+			"oauth2 device authorization response requires device_code, user_code, verification_uri",
+			nil,
+		)
+	}
+
+	g.printOAuthLogin(fmt.Sprintf(
+		"To log in, open %s in a browser and enter the code %s\n",
+		authorization.VerificationURI,
+		authorization.UserCode,
+	))
+	if g.oauthOpenBrowser != nil && authorization.VerificationURIComplete != "" {
+		if err := g.oauthOpenBrowser(authorization.VerificationURIComplete); err != nil {
+			debugctx.Detailf(ctx, "oauth2 could not open a browser: %v", err)
+		}
+	}
+
+	interval := oauthDefaultDevicePollPeriod
+	if authorization.Interval > 0 {
+		interval = time.Duration(authorization.Interval) * time.Second
+	}
+	timeout := oauthLoginTimeout
+	if authorization.ExpiresIn > 0 {
+		timeout = time.Duration(authorization.ExpiresIn) * time.Second
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pollValues := url.Values{}
+	pollValues.Set("grant_type", oauthDeviceCodeGrantType)
+	pollValues.Set("device_code", authorization.DeviceCode)
+
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return oauthSessionToken{}, faults.Auth("oauth2 device login was not completed in time", waitCtx.Err())
+		case <-timer.C:
+		}
+
+		token, err := g.requestOAuthToken(ctx, pollValues)
+		switch oauthErrorCode(err) {
+		case "":
+			if err != nil {
+				return oauthSessionToken{}, err
+			}
+			return newOAuthSessionToken(token, ""), nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return oauthSessionToken{}, err
+		}
+	}
+}
+
+func (g *Client) printOAuthLogin(message string) {
+	if g.oauthLoginOutput == nil {
+		return
+	}
+	_, _ = fmt.Fprint(g.oauthLoginOutput, message)
+}
+
+// oauthSessionKey scopes the cached token to the identity provider, client,
+// and requested scope so contexts sharing a shell session never mix tokens.
+func (g *Client) oauthSessionKey() string {
+	digest := sha256.Sum256([]byte(strings.Join([]string{
+		g.auth.oauth2.TokenURL,
+		g.auth.oauth2.ClientID,
+		g.auth.oauth2.GrantType,
+		g.auth.oauth2.Scope,
+		g.auth.oauth2.Audience,
+	}, "\n")))
+	return oauthSessionKeyPrefix + strings.ToUpper(hex.EncodeToString(digest[:8]))
+}
+
+func (g *Client) loadOAuthSessionToken(key string) (oauthSessionToken, bool) {
+	if g.auth.runtime == nil {
+		return oauthSessionToken{}, false
+	}
+	raw, ok := g.auth.runtime.SessionValue(key)
+	if !ok {
+		return oauthSessionToken{}, false
+	}
+	var token oauthSessionToken
+	if err := json.Unmarshal([]byte(raw), &token); err != nil || token.AccessToken == "" {
+		return oauthSessionToken{}, false
+	}
+	return token, true
+}
+
+func (g *Client) storeOAuthSessionToken(key string, token oauthSessionToken) error {
+	if g.auth.runtime == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(token)
+	if err != nil {
+		return faults.Internal("failed to encode oauth2 session token", err)
+	}
+	return g.auth.runtime.StoreSessionValue(key, string(encoded))
+}
+
+func newOAuthSessionToken(token oauthTokenResponse, previousRefreshToken string) oauthSessionToken {
+	refreshToken := token.RefreshToken
+	if refreshToken == "" {
+		refreshToken = previousRefreshToken
+	}
+	return oauthSessionToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    token.expiresAt(),
+	}
+}
+
+// parseOAuthRedirectURL returns the loopback redirect for the
+// authorization-code grant. An empty value picks a free port on 127.0.0.1.
+func parseOAuthRedirectURL(value string) (*url.URL, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return &url.URL{Scheme: "http", Host: "127.0.0.1:0", Path: oauthDefaultRedirectPath}, nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme != "http" || parsed.Host == "" {
+		return nil, faults.Invalid("managed-service.http.auth.oauth2.redirect-url must be an http loopback URL", err)
+	}
+	host := parsed.Hostname()
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, faults.Invalid("managed-service.http.auth.oauth2.redirect-url must be an http loopback URL", nil)
+	}
+	if parsed.Port() == "" {
+		parsed.Host = net.JoinHostPort(host, "0")
+	}
+	if parsed.Path == "" {
+		parsed.Path = oauthDefaultRedirectPath
+	}
+	return parsed, nil
+}
+
+func randomOAuthValue() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", faults.Internal("failed to generate oauth2 login secret", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+func openBrowserURL(target string) error {
+	var command *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		command = exec.Command("open", target)
+	case "windows":
+		command = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		command = exec.Command("xdg-open", target)
+	}
+	if err := command.Start(); err != nil {
+		return err
+	}
+	go func() {
+		_ = command.Wait()
+	}()
+	return nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/internal/promptauth"
+)
+
+type memoryPromptSessionStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *memoryPromptSessionStore) Load() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.values), nil
+}
+
+func (s *memoryPromptSessionStore) Save(values map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = maps.Clone(values)
+	return nil
+}
+
+func newInteractiveOAuthClient(t *testing.T, oauth config.OAuth2, store promptauth.SessionStore) *Client {
+	t.Helper()
+
+	runtime, err := promptauth.New(promptauth.WithSessionStore(store))
+	if err != nil {
+		t.Fatalf("promptauth.New returned error: %v", err)
+	}
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: "https://api.example.test",
+		Auth:    &config.HTTPAuth{OAuth2: &oauth},
+	}, WithPromptRuntime(runtime))
+	client.oauthLoginOutput = &bytes.Buffer{}
+	client.oauthOpenBrowser = func(string) error {
+		t.Error("unexpected interactive login")
+		return nil
+	}
+	return client
+}
+
+func TestOAuthAuthorizationCodeLoginUsesPKCEAndSessionCache(t *testing.T) {
+	t.Parallel()
+
+	var tokenRequests atomic.Int32
+	var challenge string
+	var challengeMu sync.Mutex
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token" {
+			http.NotFound(w, r)
+			return
+		}
+		tokenRequests.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		if r.Form.Get("grant_type") != config.OAuthAuthorizationCode || r.Form.Get("code") != "login-code" {
+			t.Errorf("unexpected token request form %v", r.Form)
+		}
+		if r.Form.Get("client_secret") != "" {
+			t.Errorf("expected public client without client_secret, got %q", r.Form.Get("client_secret"))
+		}
+		digest := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		challengeMu.Lock()
+		expectedChallenge := challenge
+		challengeMu.Unlock()
+		if base64.RawURLEncoding.EncodeToString(digest[:]) != expectedChallenge {
+			t.Errorf("code_verifier does not match the PKCE challenge")
+		}
+		_, _ = fmt.Fprint(w, `{"access_token":"user-token","refresh_token":"refresh-1","expires_in":3600}`)
+	}))
+	t.Cleanup(idp.Close)
+
+	oauth := config.OAuth2{
+		TokenURL:         idp.URL + "/token",
+		GrantType:        config.OAuthAuthorizationCode,
+		ClientID:         "declarest-cli",
+		AuthorizationURL: idp.URL + "/authorize",
+		Scope:            "openid",
+	}
+	store := &memoryPromptSessionStore{}
+
+	client := newInteractiveOAuthClient(t, oauth, store)
+	client.oauthOpenBrowser = func(target string) error {
+		authorizationURL, err := url.Parse(target)
+		if err != nil {
+			return err
+		}
+		query := authorizationURL.Query()
+		if query.Get("code_challenge_method") != "S256" || query.Get("scope") != "openid" {
+			t.Errorf("unexpected authorization query %v", query)
+		}
+		challengeMu.Lock()
+		challenge = query.Get("code_challenge")
+		challengeMu.Unlock()
+
+		go func() {
+			redirect := query.Get("redirect_uri") + "?code=login-code&state=" + url.QueryEscape(query.Get("state"))
+			response, err := http.Get(redirect)
+			if err != nil {
+				t.Errorf("redirect request returned error: %v", err)
+				return
+			}
+			_ = response.Body.Close()
+		}()
+		return nil
+	}
+
+	token, err := client.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if token != "user-token" {
+		t.Fatalf("expected user-token, got %q", token)
+	}
+
+	nextCommand := newInteractiveOAuthClient(t, oauth, store)
+	token, err = nextCommand.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken from session returned error: %v", err)
+	}
+	if token != "user-token" || tokenRequests.Load() != 1 {
+		t.Fatalf("expected cached session token without new requests, got %q after %d requests", token, tokenRequests.Load())
+	}
+}
+
+func TestOAuthInteractiveGrantRefreshesExpiredSessionToken(t *testing.T) {
+	t.Parallel()
+
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "refresh-1" {
+			t.Errorf("expected refresh_token grant, got %v", r.Form)
+		}
+		_, _ = fmt.Fprint(w, `{"access_token":"refreshed-token","expires_in":3600}`)
+	}))
+	t.Cleanup(idp.Close)
+
+	oauth := config.OAuth2{
+		TokenURL:               idp.URL + "/token",
+		GrantType:              config.OAuthDeviceCode,
+		ClientID:               "declarest-cli",
+		DeviceAuthorizationURL: idp.URL + "/device",
+	}
+	store := &memoryPromptSessionStore{}
+	client := newInteractiveOAuthClient(t, oauth, store)
+
+	expired, err := json.Marshal(oauthSessionToken{
+		AccessToken:  "expired-token",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if err := client.auth.runtime.StoreSessionValue(client.oauthSessionKey(), string(expired)); err != nil {
+		t.Fatalf("StoreSessionValue returned error: %v", err)
+	}
+
+	token, err := client.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if token != "refreshed-token" {
+		t.Fatalf("expected refreshed-token, got %q", token)
+	}
+	cached, ok := client.loadOAuthSessionToken(client.oauthSessionKey())
+	if !ok || cached.AccessToken != "refreshed-token" || cached.RefreshToken != "refresh-1" {
+		t.Fatalf("expected refreshed session token keeping the refresh token, got %#v", cached)
+	}
+}
+
+func TestOAuthDeviceCodeLoginPollsUntilApproved(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		switch r.URL.Path {
+		case "/device":
+			_, _ = fmt.Fprint(w, `{"device_code":"device-1","user_code":"ABCD-EFGH","verification_uri":"https://sso.example.test/device","interval":1}`)
+		case "/token":
+			if r.Form.Get("grant_type") != oauthDeviceCodeGrantType || r.Form.Get("device_code") != "device-1" {
+				t.Errorf("unexpected device token request %v", r.Form)
+			}
+			if polls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"error":"authorization_pending"}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"access_token":"device-token","expires_in":3600}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(idp.Close)
+
+	client := newInteractiveOAuthClient(t, config.OAuth2{
+		TokenURL:               idp.URL + "/token",
+		GrantType:              config.OAuthDeviceCode,
+		ClientID:               "declarest-cli",
+		DeviceAuthorizationURL: idp.URL + "/device",
+	}, &memoryPromptSessionStore{})
+	output := &bytes.Buffer{}
+	client.oauthLoginOutput = output
+
+	token, err := client.GetAccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccessToken returned error: %v", err)
+	}
+	if token != "device-token" || polls.Load() != 2 {
+		t.Fatalf("expected device-token after two polls, got %q after %d polls", token, polls.Load())
+	}
+	if !strings.Contains(output.String(), "ABCD-EFGH") {
+		t.Fatalf("expected login instructions with the user code, got %q", output.String())
+	}
+}
+
+func TestOAuthInteractiveGrantValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		oauth config.OAuth2
+	}{
+		{
+			name: "authorization_code_requires_authorization_url",
+			oauth: config.OAuth2{
+				TokenURL:  "https://sso.example.test/token",
+				GrantType: config.OAuthAuthorizationCode,
+				ClientID:  "cli",
+			},
+		},
+		{
+			name: "redirect_url_must_be_loopback",
+			oauth: config.OAuth2{
+				TokenURL:         "https://sso.example.test/token",
+				GrantType:        config.OAuthAuthorizationCode,
+				ClientID:         "cli",
+				AuthorizationURL: "https://sso.example.test/authorize",
+				RedirectURL:      "http://example.test/callback",
+			},
+		},
+		{
+			name: "device_code_requires_device_authorization_url",
+			oauth: config.OAuth2{
+				TokenURL:  "https://sso.example.test/token",
+				GrantType: config.OAuthDeviceCode,
+				ClientID:  "cli",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			oauth := tc.oauth
+			_, err := NewClient(config.HTTPServer{
+				BaseURL: "https://api.example.test",
+				Auth:    &config.HTTPAuth{OAuth2: &oauth},
+			})
+			assertTypedCategory(t, err, faults.ValidationError)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/internal/promptauth"
+	"github.com/crmarques/declarest/resource"
+)
+
+const (
+	defaultSessionUsernameField = "username"
+	defaultSessionPasswordField = "password"
+)
+
+// sessionCookieJar is the per-client cookie jar of the session auth mode. It
+// can be reset so a new login never sends the cookies of a rejected session.
+type sessionCookieJar struct {
+	mu  sync.Mutex
+	jar *cookiejar.Jar
+}
+
+func newSessionCookieJar() *sessionCookieJar {
+	jar, _ := cookiejar.New(nil)
+	return &sessionCookieJar{jar: jar}
+}
+
+func (j *sessionCookieJar) SetCookies(target *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar.SetCookies(target, cookies)
+}
+
+func (j *sessionCookieJar) Cookies(target *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.jar.Cookies(target)
+}
+
+func (j *sessionCookieJar) reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar, _ = cookiejar.New(nil)
+}
+
+func validateSessionAuth(cfg config.SessionAuth) (config.SessionAuth, error) {
+	session := cfg
+	session.LoginURL = strings.TrimSpace(session.LoginURL)
+	session.Method = strings.ToUpper(strings.TrimSpace(session.Method))
+	session.BodyFormat = strings.TrimSpace(session.BodyFormat)
+	session.UsernameField = strings.TrimSpace(session.UsernameField)
+	session.PasswordField = strings.TrimSpace(session.PasswordField)
+
+	if session.LoginURL == "" {
+		return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.login-url is required", nil)
+	}
+	if _, err := url.Parse(session.LoginURL); err != nil {
+		return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.login-url is invalid", err)
+	}
+	switch session.Method {
+	case "":
+		session.Method = http.MethodPost
+	case http.MethodPost, http.MethodPut:
+	default:
+		return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.method supports only POST, PUT", nil)
+	}
+	switch session.BodyFormat {
+	case "":
+		session.BodyFormat = config.SessionBodyForm
+	case config.SessionBodyForm, config.SessionBodyJSON:
+	default:
+		return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.body-format supports only form, json", nil)
+	}
+	if session.UsernameField == "" {
+		session.UsernameField = defaultSessionUsernameField
+	}
+	if session.PasswordField == "" {
+		session.PasswordField = defaultSessionPasswordField
+	}
+	if session.Credentials != nil &&
+		session.Credentials.CredentialName() == "" &&
+		!session.Credentials.HasResolvedCredentials() {
+		return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.credentials.credentials-ref is required", nil)
+	}
+
+	if session.CSRF != nil {
+		csrf := *session.CSRF
+		csrf.Header = strings.TrimSpace(csrf.Header)
+		csrf.ResponseHeader = strings.TrimSpace(csrf.ResponseHeader)
+		csrf.JSONPointer = strings.TrimSpace(csrf.JSONPointer)
+		if csrf.Header == "" {
+			return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.csrf.header is required", nil)
+		}
+		if (csrf.ResponseHeader == "") == (csrf.JSONPointer == "") {
+			return config.SessionAuth{}, faults.Invalid(
+				"managed-service.http.auth.session.csrf must define exactly one of response-header, json-pointer",
+				nil,
+			)
+		}
+		if csrf.JSONPointer != "" {
+			if _, err := resource.ParseJSONPointer(csrf.JSONPointer); err != nil {
+				return config.SessionAuth{}, faults.Invalid("managed-service.http.auth.session.csrf.json-pointer is invalid", err)
+			}
+		}
+		session.CSRF = &csrf
+	}
+	return session, nil
+}
+
+// applySession logs in on first use and adds the CSRF token; the session
+// cookies are added by the client's cookie jar.
+func (g *Client) applySession(ctx context.Context, request *http.Request) error {
+	debugctx.Detailf(ctx, "auth mode=session login_url=%q", g.auth.session.LoginURL)
+	csrfToken, err := g.sessionLogin(ctx)
+	if err != nil {
+		return err
+	}
+	if g.auth.session.CSRF != nil {
+		request.Header.Set(g.auth.session.CSRF.Header, csrfToken)
+		if debugctx.Insecure(ctx) {
+			debugctx.Detailf(ctx, "auth session csrf %s: %s", g.auth.session.CSRF.Header, csrfToken)
+		}
+	}
+	return nil
+}
+
+func (g *Client) sessionLogin(ctx context.Context) (string, error) {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+
+	if g.sessionActive {
+		return g.sessionCSRFToken, nil
+	}
+
+	request, err := g.newSessionLoginRequest(ctx)
+	if err != nil {
+		return "", err
+	}
+	response, err := g.doRequest(ctx, "session-login", request)
+	if err != nil {
+		return "", faults.Transport("session login request failed", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return "", faults.Transport("failed to read session login response", err)
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return "", faults.Auth(fmt.Sprintf("session login failed with status %d", response.StatusCode), nil)
+	}
+
+	csrfToken, err := sessionCSRFToken(g.auth.session.CSRF, response.Header, body)
+	if err != nil {
+		return "", err
+	}
+
+	debugctx.Detailf(ctx, "session login succeeded status=%d", response.StatusCode)
+	g.sessionActive = true
+	g.sessionCSRFToken = csrfToken
+	return csrfToken, nil
+}
+
+func (g *Client) newSessionLoginRequest(ctx context.Context) (*http.Request, error) {
+	session := g.auth.session
+
+	loginURL := session.LoginURL
+	if parsed, err := url.Parse(loginURL); err == nil && parsed.Scheme == "" {
+		resolved, err := g.resolveRequestURL(loginURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		loginURL = resolved
+	}
+
+	fields := make(map[string]string, len(session.Fields)+2)
+	for key, value := range session.Fields {
+		fields[key] = value
+	}
+	if session.Credentials != nil {
+		creds, err := promptauth.ResolveCredentials(
+			g.auth.runtime,
+			ctx,
+			session.Credentials.CredentialName(),
+			session.Credentials.Username,
+			session.Credentials.Password,
+		)
+		if err != nil {
+			return nil, err
+		}
+		fields[session.UsernameField] = creds.Username
+		fields[session.PasswordField] = creds.Password
+	}
+
+	var body []byte
+	var contentType string
+	switch session.BodyFormat {
+	case config.SessionBodyJSON:
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			return nil, faults.Internal("failed to encode session login request", err)
+		}
+		body, contentType = encoded, defaultMediaType
+	default:
+		values := url.Values{}
+		for key, value := range fields {
+			values.Set(key, value)
+		}
+		body, contentType = []byte(values.Encode()), "application/x-www-form-urlencoded"
+	}
+
+	request, err := http.NewRequestWithContext(ctx, session.Method, loginURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, faults.Internal("failed to create session login request", err)
+	}
+	request.Header.Set("Accept", defaultMediaType)
+	request.Header.Set("Content-Type", contentType)
+	return request, nil
+}
+
+func sessionCSRFToken(csrf *config.SessionCSRF, header http.Header, body []byte) (string, error) {
+	if csrf == nil {
+		return "", nil
+	}
+
+	if csrf.ResponseHeader != "" {
+		token := strings.TrimSpace(header.Get(csrf.ResponseHeader))
+		if token == "" {
+			return "", faults.Auth(
+				fmt.Sprintf("session login response has no CSRF token header %q", csrf.ResponseHeader),
+				nil,
+			)
+		}
+		return token, nil
+	}
+
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", faults.Auth("session login response is not JSON; cannot read CSRF token", err)
+	}
+	token, found, err := resource.LookupJSONPointerString(payload, csrf.JSONPointer)
+	if err != nil || !found || strings.TrimSpace(token) == "" {
+		return "", faults.Auth(
+			fmt.Sprintf("session login response has no CSRF token at %q", csrf.JSONPointer),
+			err,
+		)
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// invalidateSession drops the session and its cookies, so the next request
+// logs in again.
+func (g *Client) invalidateSession() {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	g.sessionActive = false
+	g.sessionCSRFToken = ""
+	if g.sessionJar != nil {
+		g.sessionJar.reset()
+	}
+}
+
+// renewRejectedCredentials reports whether a failed request should be sent
+// once more because the server rejected the login session or the exec
+// credential with 401.
+func (g *Client) renewRejectedCredentials(ctx context.Context, statusCode int) bool {
+	if statusCode != http.StatusUnauthorized || g.cassette.replaying() {
+		return false
+	}
+	switch g.auth.mode {
+	case authModeSession:
+		debugctx.Detailf(ctx, "session rejected with status %d; logging in again", statusCode)
+		g.invalidateSession()
+	case authModeExec:
+		debugctx.Detailf(ctx, "exec credential rejected with status %d; running the helper again", statusCode)
+		g.auth.execProvider.Invalidate()
+	default:
+		return false
+	}
+	return true
+}
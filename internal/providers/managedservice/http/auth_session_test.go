@@ -0,0 +1,244 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// sessionLoginServer is a form-login API stand-in: /login issues a session
+// cookie and CSRF token, and every other path requires both.
+type sessionLoginServer struct {
+	mu       sync.Mutex
+	logins   int
+	sessions map[string]string
+	loginFn  func(w http.ResponseWriter, r *http.Request, sessionID string, csrfToken string)
+}
+
+func newSessionLoginServer(t *testing.T, loginFn func(w http.ResponseWriter, r *http.Request, sessionID string, csrfToken string)) (*httptest.Server, *sessionLoginServer) {
+	t.Helper()
+
+	state := &sessionLoginServer{sessions: map[string]string{}, loginFn: loginFn}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+
+		if r.URL.Path == "/api/login" {
+			state.logins++
+			sessionID := "session-" + strconv.Itoa(state.logins)
+			csrfToken := "csrf-" + strconv.Itoa(state.logins)
+			state.sessions[sessionID] = csrfToken
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: sessionID, Path: "/"})
+			state.loginFn(w, r, sessionID, csrfToken)
+			return
+		}
+
+		cookie, err := r.Cookie("JSESSIONID")
+		if err != nil || state.sessions[cookie.Value] == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("X-CSRF-Token") != state.sessions[cookie.Value] {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"job-1"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, state
+}
+
+func (s *sessionLoginServer) expireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = map[string]string{}
+}
+
+func (s *sessionLoginServer) loginCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins
+}
+
+func sessionTestRequest(t *testing.T, client *Client) {
+	t.Helper()
+
+	md := metadata.ResourceMetadata{
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationGet): {Path: "/jobs/job-1"},
+		},
+	}
+	if _, err := client.Get(context.Background(), resource.Resource{LogicalPath: "/jobs/job-1"}, md); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+}
+
+func TestSessionAuthFormLoginWithCSRFHeader(t *testing.T) {
+	t.Parallel()
+
+	server, state := newSessionLoginServer(t, func(w http.ResponseWriter, r *http.Request, _ string, csrfToken string) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		if r.Method != http.MethodPost ||
+			r.PostForm.Get("j_username") != "admin" ||
+			r.PostForm.Get("j_password") != "s3cret" ||
+			r.PostForm.Get("remember") != "false" {
+			t.Errorf("unexpected login request %s %v", r.Method, r.PostForm)
+		}
+		w.Header().Set("X-CSRF-Token", csrfToken)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL + "/api",
+		Auth: &config.HTTPAuth{Session: &config.SessionAuth{
+			LoginURL: "/login",
+			Credentials: &config.BasicAuth{
+				Username: config.LiteralCredential("admin"),
+				Password: config.LiteralCredential("s3cret"),
+			},
+			UsernameField: "j_username",
+			PasswordField: "j_password",
+			Fields:        map[string]string{"remember": "false"},
+			CSRF:          &config.SessionCSRF{Header: "X-CSRF-Token", ResponseHeader: "X-CSRF-Token"},
+		}},
+	})
+
+	sessionTestRequest(t, client)
+	sessionTestRequest(t, client)
+	if got := state.loginCount(); got != 1 {
+		t.Fatalf("expected one login for the session, got %d", got)
+	}
+}
+
+func TestSessionAuthJSONLoginReloginOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server, state := newSessionLoginServer(t, func(w http.ResponseWriter, r *http.Request, _ string, csrfToken string) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Decode returned error: %v", err)
+		}
+		if r.Header.Get("Content-Type") != "application/json" || body["username"] != "admin" || body["password"] != "s3cret" {
+			t.Errorf("unexpected JSON login request %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"session":{"csrf":%q}}`, csrfToken)
+	})
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL + "/api",
+		Auth: &config.HTTPAuth{Session: &config.SessionAuth{
+			LoginURL:   server.URL + "/api/login",
+			BodyFormat: config.SessionBodyJSON,
+			Credentials: &config.BasicAuth{
+				Username: config.LiteralCredential("admin"),
+				Password: config.LiteralCredential("s3cret"),
+			},
+			CSRF: &config.SessionCSRF{Header: "X-CSRF-Token", JSONPointer: "/session/csrf"},
+		}},
+	})
+
+	sessionTestRequest(t, client)
+	state.expireSessions()
+	sessionTestRequest(t, client)
+	if got := state.loginCount(); got != 2 {
+		t.Fatalf("expected a second login after the session expired, got %d logins", got)
+	}
+}
+
+func TestSessionAuthLoginFailureReturnsAuthError(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newSessionLoginServer(t, func(w http.ResponseWriter, _ *http.Request, _ string, _ string) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL + "/api",
+		Auth:    &config.HTTPAuth{Session: &config.SessionAuth{LoginURL: "/login"}},
+	})
+
+	md := metadata.ResourceMetadata{
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationGet): {Path: "/jobs/job-1"},
+		},
+	}
+	_, err := client.Get(context.Background(), resource.Resource{LogicalPath: "/jobs/job-1"}, md)
+	assertTypedCategory(t, err, faults.AuthError)
+}
+
+func TestSessionAuthRedactsSessionHeaders(t *testing.T) {
+	t.Parallel()
+
+	auth, err := buildAuthConfig(&config.HTTPAuth{Session: &config.SessionAuth{
+		LoginURL: "/login",
+		CSRF:     &config.SessionCSRF{Header: "X-Rundeck-CSRF", ResponseHeader: "X-Rundeck-CSRF"},
+	}}, nil, nil)
+	if err != nil {
+		t.Fatalf("buildAuthConfig returned error: %v", err)
+	}
+	for _, header := range []string{"Cookie", "set-cookie", "x-rundeck-csrf", "Authorization"} {
+		if !auth.shouldRedactHeader(header) {
+			t.Fatalf("expected %s to be redacted", header)
+		}
+	}
+	if auth.shouldRedactHeader("Accept") {
+		t.Fatal("expected Accept not to be redacted")
+	}
+}
+
+func TestSessionAuthValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]config.SessionAuth{
+		"missing_login_url":       {},
+		"unsupported_method":      {LoginURL: "/login", Method: "GET"},
+		"unsupported_body_format": {LoginURL: "/login", BodyFormat: "xml"},
+		"csrf_without_header":     {LoginURL: "/login", CSRF: &config.SessionCSRF{ResponseHeader: "X-CSRF-Token"}},
+		"csrf_without_source":     {LoginURL: "/login", CSRF: &config.SessionCSRF{Header: "X-CSRF-Token"}},
+		"csrf_with_both_sources": {LoginURL: "/login", CSRF: &config.SessionCSRF{
+			Header:         "X-CSRF-Token",
+			ResponseHeader: "X-CSRF-Token",
+			JSONPointer:    "/csrf",
+		}},
+		"invalid_json_pointer": {LoginURL: "/login", CSRF: &config.SessionCSRF{Header: "X-CSRF-Token", JSONPointer: "csrf"}},
+	}
+	for name, session := range testCases {
+		session := session
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewClient(config.HTTPServer{
+				BaseURL: "https://api.example.test",
+				Auth:    &config.HTTPAuth{Session: &session},
+			})
+			assertTypedCategory(t, err, faults.ValidationError)
+		})
+	}
+}
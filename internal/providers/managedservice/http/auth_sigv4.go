@@ -0,0 +1,357 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+)
+
+const (
+	sigV4Algorithm           = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat          = "20060102T150405Z"
+	sigV4DateFormat          = "20060102"
+	sigV4ContentHashHeader   = "X-Amz-Content-Sha256"
+	sigV4DateHeader          = "X-Amz-Date"
+	sigV4SecurityTokenHeader = "X-Amz-Security-Token"
+	sigV4DefaultProfile      = "default"
+)
+
+type sigV4Credentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+func validateSigV4Auth(cfg config.SigV4Auth) (config.SigV4Auth, error) {
+	cfg.Region = strings.TrimSpace(cfg.Region)
+	cfg.Service = strings.TrimSpace(cfg.Service)
+	cfg.AccessKeyID = strings.TrimSpace(cfg.AccessKeyID)
+	cfg.SecretAccessKey = strings.TrimSpace(cfg.SecretAccessKey)
+	cfg.SessionToken = strings.TrimSpace(cfg.SessionToken)
+	cfg.CredentialsFile = strings.TrimSpace(cfg.CredentialsFile)
+	cfg.Profile = strings.TrimSpace(cfg.Profile)
+
+	if cfg.Region == "" || cfg.Service == "" {
+		return config.SigV4Auth{}, faults.Invalid("managed-service.http.auth.sigv4 requires region and service", nil)
+	}
+	if (cfg.AccessKeyID == "") != (cfg.SecretAccessKey == "") {
+		return config.SigV4Auth{}, faults.Invalid(
+			"managed-service.http.auth.sigv4 access-key-id and secret-access-key must be set together",
+			nil,
+		)
+	}
+	return cfg, nil
+}
+
+// applySigV4 signs request with the payload hash of its body. Credentials are
+// resolved on every request so rotated files and environments are picked up.
+func (g *Client) applySigV4(ctx context.Context, request *http.Request) error {
+	credentials, err := resolveSigV4Credentials(g.auth.sigv4)
+	if err != nil {
+		return err
+	}
+
+	body, err := sigV4RequestBody(request)
+	if err != nil {
+		return err
+	}
+	payloadHash := sha256.Sum256(body)
+	request.Header.Set(sigV4ContentHashHeader, hex.EncodeToString(payloadHash[:]))
+
+	debugctx.Detailf(
+		ctx,
+		"auth mode=sigv4 region=%q service=%q access_key_id=%q",
+		g.auth.sigv4.Region,
+		g.auth.sigv4.Service,
+		credentials.accessKeyID,
+	)
+	signSigV4Request(request, body, credentials, g.auth.sigv4.Region, g.auth.sigv4.Service, time.Now())
+	return nil
+}
+
+func sigV4RequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	if request.GetBody == nil {
+		return nil, faults.Internal("sigv4 signing requires a replayable request body", nil)
+	}
+	reader, err := request.GetBody()
+	if err != nil {
+		return nil, faults.Internal("failed to read request body for sigv4 signing", err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, faults.Internal("failed to read request body for sigv4 signing", err)
+	}
+	return body, nil
+}
+
+// signSigV4Request adds the SigV4 Authorization header. It signs host,
+// content-type, and every x-amz-* header already set on the request.
+func signSigV4Request(
+	request *http.Request,
+	body []byte,
+	credentials sigV4Credentials,
+	region string,
+	service string,
+	now time.Time,
+) {
+	now = now.UTC()
+	request.Header.Set(sigV4DateHeader, now.Format(sigV4TimeFormat))
+	if credentials.sessionToken != "" {
+		request.Header.Set(sigV4SecurityTokenHeader, credentials.sessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := sigV4CanonicalHeaders(request)
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		sigV4CanonicalURI(request.URL, service),
+		sigV4CanonicalQuery(request.URL),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	date := now.Format(sigV4DateFormat)
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		now.Format(sigV4TimeFormat),
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set(
+		"Authorization",
+		sigV4Algorithm+" Credential="+credentials.accessKeyID+"/"+scope+
+			", SignedHeaders="+signedHeaders+
+			", Signature="+signature,
+	)
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+func sigV4CanonicalHeaders(request *http.Request) (string, string) {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+	values := map[string]string{"host": host}
+	for name, headerValues := range request.Header {
+		lower := strings.ToLower(name)
+		if lower != "content-type" && !strings.HasPrefix(lower, "x-amz-") {
+			continue
+		}
+		trimmed := make([]string, 0, len(headerValues))
+		for _, value := range headerValues {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+		values[lower] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name)
+		canonical.WriteByte(':')
+		canonical.WriteString(values[name])
+		canonical.WriteByte('\n')
+	}
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// sigV4CanonicalURI encodes each path segment again on top of the request
+// encoding, as SigV4 requires for every service except S3.
+func sigV4CanonicalURI(target *url.URL, service string) string {
+	path := target.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	if service == "s3" {
+		return path
+	}
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		segments[idx] = sigV4Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func sigV4CanonicalQuery(target *url.URL) string {
+	query := target.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(query))
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, sigV4Escape(key)+"="+sigV4Escape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// sigV4Escape percent-encodes everything except the RFC 3986 unreserved
+// characters.
+func sigV4Escape(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var builder strings.Builder
+	for idx := 0; idx < len(value); idx++ {
+		c := value[idx]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			builder.WriteByte(c)
+			continue
+		}
+		builder.WriteByte('%')
+		builder.WriteByte(hexDigits[c>>4])
+		builder.WriteByte(hexDigits[c&0x0f])
+	}
+	return builder.String()
+}
+
+func resolveSigV4Credentials(cfg config.SigV4Auth) (sigV4Credentials, error) {
+	if cfg.AccessKeyID != "" {
+		return sigV4Credentials{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+		}, nil
+	}
+	if cfg.CredentialsFile != "" {
+		return readSigV4CredentialsFile(cfg.CredentialsFile, cfg.Profile)
+	}
+
+	credentials := sigV4Credentials{
+		accessKeyID:     strings.TrimSpace(os.Getenv("AWS_ACCESS_KEY_ID")),
+		secretAccessKey: strings.TrimSpace(os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		sessionToken:    strings.TrimSpace(os.Getenv("AWS_SESSION_TOKEN")),
+	}
+	if credentials.accessKeyID == "" || credentials.secretAccessKey == "" {
+		return sigV4Credentials{}, faults.Auth(
+			"managed-service.http.auth.sigv4 has no credentials; set access-key-id, credentials-file, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY",
+			nil,
+		)
+	}
+	return credentials, nil
+}
+
+// readSigV4CredentialsFile reads one profile of an AWS shared credentials
+// file. The profile defaults to AWS_PROFILE, then "default".
+func readSigV4CredentialsFile(path string, profile string) (sigV4Credentials, error) {
+	if profile == "" {
+		profile = strings.TrimSpace(os.Getenv("AWS_PROFILE"))
+	}
+	if profile == "" {
+		profile = sigV4DefaultProfile
+	}
+	if strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return sigV4Credentials{}, faults.Auth("failed to resolve home directory for sigv4 credentials file", err)
+		}
+		path = filepath.Join(homeDir, path[2:])
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return sigV4Credentials{}, faults.Auth("failed to read sigv4 credentials file", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var credentials sigV4Credentials
+	inProfile := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inProfile = strings.TrimSpace(line[1:len(line)-1]) == profile
+			continue
+		}
+		if !inProfile {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "aws_access_key_id":
+			credentials.accessKeyID = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			credentials.secretAccessKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			credentials.sessionToken = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return sigV4Credentials{}, faults.Auth("failed to read sigv4 credentials file", err)
+	}
+	if credentials.accessKeyID == "" || credentials.secretAccessKey == "" {
+		return sigV4Credentials{}, faults.Auth(
+			fmt.Sprintf("sigv4 credentials file profile %q does not define aws_access_key_id and aws_secret_access_key", profile),
+			nil,
+		)
+	}
+	return credentials, nil
+}
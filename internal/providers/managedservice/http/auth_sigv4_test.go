@@ -0,0 +1,270 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// TestSignSigV4RequestMatchesAWSTestSuite uses the get-vanilla case of the
+// AWS Signature Version 4 test suite.
+func TestSignSigV4RequestMatchesAWSTestSuite(t *testing.T) {
+	t.Parallel()
+
+	request, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	signSigV4Request(
+		request,
+		nil,
+		sigV4Credentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"},
+		"us-east-1",
+		"service",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC),
+	)
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := request.Header.Get("Authorization"); got != expected {
+		t.Fatalf("unexpected Authorization header\nexpected: %s\ngot:      %s", expected, got)
+	}
+}
+
+// verifySigV4 is a stand-in for an AWS endpoint: it recomputes the signature
+// of a received request from its signed headers and the secret key.
+func verifySigV4(r *http.Request, body []byte, secretAccessKey string) error {
+	authorization := r.Header.Get("Authorization")
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(authorization, "AWS4-HMAC-SHA256 "), ", ") {
+		key, value, _ := strings.Cut(part, "=")
+		fields[key] = value
+	}
+	credentialScope := strings.SplitN(fields["Credential"], "/", 2)
+	if len(credentialScope) != 2 {
+		return fmt.Errorf("malformed credential %q", fields["Credential"])
+	}
+	scope := strings.Split(credentialScope[1], "/")
+
+	payloadHash := sha256.Sum256(body)
+	if got := r.Header.Get("X-Amz-Content-Sha256"); got != hex.EncodeToString(payloadHash[:]) {
+		return fmt.Errorf("x-amz-content-sha256 %q does not match the body", got)
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strings.ReplaceAll(query.Get(key), " ", "%20"))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		strings.Join(pairs, "&"),
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + r.Header.Get("X-Amz-Date") + "\n" + credentialScope[1] + "\n" +
+		hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range scope {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	if expected := hex.EncodeToString(mac.Sum(nil)); expected != fields["Signature"] {
+		return fmt.Errorf("signature mismatch: expected %s, got %s", expected, fields["Signature"])
+	}
+	return nil
+}
+
+func TestSigV4SignsManagedServiceRequests(t *testing.T) {
+	t.Parallel()
+
+	var verified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("ReadAll returned error: %v", err)
+		}
+		if err := verifySigV4(r, body, "static-secret"); err != nil {
+			t.Errorf("request %s %s failed verification: %v", r.Method, r.URL, err)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDSTATIC/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/es/aws4_request") {
+			t.Errorf("unexpected credential scope %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Amz-Security-Token") != "session-token" {
+			t.Errorf("expected session token header, got %q", r.Header.Get("X-Amz-Security-Token"))
+		}
+		verified++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"orders"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{SigV4: &config.SigV4Auth{
+			Region:          "eu-west-1",
+			Service:         "es",
+			AccessKeyID:     "AKIDSTATIC",
+			SecretAccessKey: "static-secret",
+			SessionToken:    "session-token",
+		}},
+	})
+
+	md := metadata.ResourceMetadata{
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationGet): {
+				Path:  "/indices/orders",
+				Query: map[string]string{"pretty": "true", "expand": "a b"},
+			},
+			string(metadata.OperationUpdate): {Path: "/indices/orders"},
+		},
+	}
+	item := resource.Resource{
+		LogicalPath: "/indices/orders",
+		Payload:     map[string]any{"id": "orders", "shards": 3},
+	}
+
+	if _, err := client.Get(context.Background(), item, md); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if _, err := client.Update(context.Background(), item, md); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if verified < 2 {
+		t.Fatalf("expected signed GET and PUT requests, verified %d", verified)
+	}
+}
+
+func TestResolveSigV4Credentials(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	content := "[default]\naws_access_key_id = AKIDDEFAULT\naws_secret_access_key = default-secret\n\n" +
+		"[ops]\naws_access_key_id=AKIDOPS\naws_secret_access_key=ops-secret\naws_session_token=ops-token\n"
+	if err := os.WriteFile(credentialsFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDENV")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_PROFILE", "")
+
+	testCases := []struct {
+		name     string
+		cfg      config.SigV4Auth
+		expected sigV4Credentials
+	}{
+		{
+			name:     "static",
+			cfg:      config.SigV4Auth{AccessKeyID: "AKIDSTATIC", SecretAccessKey: "static-secret", CredentialsFile: credentialsFile},
+			expected: sigV4Credentials{accessKeyID: "AKIDSTATIC", secretAccessKey: "static-secret"},
+		},
+		{
+			name:     "file_default_profile",
+			cfg:      config.SigV4Auth{CredentialsFile: credentialsFile},
+			expected: sigV4Credentials{accessKeyID: "AKIDDEFAULT", secretAccessKey: "default-secret"},
+		},
+		{
+			name:     "file_named_profile",
+			cfg:      config.SigV4Auth{CredentialsFile: credentialsFile, Profile: "ops"},
+			expected: sigV4Credentials{accessKeyID: "AKIDOPS", secretAccessKey: "ops-secret", sessionToken: "ops-token"},
+		},
+		{
+			name:     "environment",
+			cfg:      config.SigV4Auth{},
+			expected: sigV4Credentials{accessKeyID: "AKIDENV", secretAccessKey: "env-secret"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveSigV4Credentials(tc.cfg)
+			if err != nil {
+				t.Fatalf("resolveSigV4Credentials returned error: %v", err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %#v, got %#v", tc.expected, got)
+			}
+		})
+	}
+
+	_, err := resolveSigV4Credentials(config.SigV4Auth{CredentialsFile: credentialsFile, Profile: "missing"})
+	assertTypedCategory(t, err, faults.AuthError)
+}
+
+func TestSigV4AuthValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]config.SigV4Auth{
+		"missing_region":         {Service: "execute-api"},
+		"missing_service":        {Region: "us-east-1"},
+		"access_key_without_key": {Region: "us-east-1", Service: "execute-api", AccessKeyID: "AKID"},
+	}
+	for name, sigv4 := range testCases {
+		sigv4 := sigv4
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewClient(config.HTTPServer{
+				BaseURL: "https://api.example.test",
+				Auth:    &config.HTTPAuth{SigV4: &sigv4},
+			})
+			assertTypedCategory(t, err, faults.ValidationError)
+		})
+	}
+}
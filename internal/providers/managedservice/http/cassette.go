@@ -0,0 +1,484 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+type cassetteMode int
+
+const (
+	cassetteModeRecord cassetteMode = iota + 1
+	cassetteModeReplay
+)
+
+const (
+	cassetteBodyEncodingBase64 = "base64"
+	cassetteSlugMaxLength      = 80
+)
+
+// oauth2 and session login requests are never recorded: replay skips
+// authentication, and their requests and responses carry live credentials.
+var cassetteSkippedPurposePrefixes = []string{"oauth2-", "session-"}
+
+// httpCassette records request/response pairs into dir or serves them back.
+// Interactions are keyed by method, path, query, and request body; repeated
+// requests with the same key are numbered in call order, and replay serves the
+// last recorded interaction once a key's recordings are exhausted.
+type httpCassette struct {
+	mode cassetteMode
+	dir  string
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method       string              `json:"method"`
+	URL          string              `json:"url"`
+	Headers      map[string][]string `json:"headers,omitempty"`
+	Body         string              `json:"body,omitempty"`
+	BodyEncoding string              `json:"bodyEncoding,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status       int                 `json:"status"`
+	Headers      map[string][]string `json:"headers,omitempty"`
+	Body         string              `json:"body,omitempty"`
+	BodyEncoding string              `json:"bodyEncoding,omitempty"`
+}
+
+// WithHTTPRecording records every managed-service request and response into
+// dir. Authentication headers, and the metadata secretAttributes of structured
+// bodies, are redacted before anything is written.
+func WithHTTPRecording(dir string) ClientOption {
+	return withHTTPCassette(cassetteModeRecord, dir)
+}
+
+// WithHTTPReplay serves managed-service responses from interactions recorded
+// into dir instead of contacting the server. Authentication is skipped.
+func WithHTTPReplay(dir string) ClientOption {
+	return withHTTPCassette(cassetteModeReplay, dir)
+}
+
+func withHTTPCassette(mode cassetteMode, dir string) ClientOption {
+	return func(g *Client) {
+		if g == nil || strings.TrimSpace(dir) == "" {
+			return
+		}
+		g.cassette = &httpCassette{
+			mode:  mode,
+			dir:   filepath.Clean(strings.TrimSpace(dir)),
+			calls: map[string]int{},
+		}
+	}
+}
+
+func (c *httpCassette) replaying() bool {
+	return c != nil && c.mode == cassetteModeReplay
+}
+
+func (c *httpCassette) intercepts(purpose string) bool {
+	if c == nil {
+		return false
+	}
+	for _, prefix := range cassetteSkippedPurposePrefixes {
+		if strings.HasPrefix(purpose, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *httpCassette) do(
+	ctx context.Context,
+	request *http.Request,
+	auth authConfig,
+	invoke func() (*http.Response, error),
+) (*http.Response, error) {
+	body, err := readCassetteRequestBody(request)
+	if err != nil {
+		return nil, faults.Internal("failed to read request body for HTTP cassette", err)
+	}
+	key := cassetteKey(request, body)
+	fileName := cassetteFileName(request, key, c.nextCall(key))
+
+	if c.mode == cassetteModeReplay {
+		return c.replay(ctx, request, key, fileName)
+	}
+
+	response, err := invoke()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.record(ctx, request, body, response, auth, fileName); err != nil {
+		_ = response.Body.Close()
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *httpCassette) nextCall(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[key]++
+	return c.calls[key]
+}
+
+func (c *httpCassette) record(
+	ctx context.Context,
+	request *http.Request,
+	requestBody []byte,
+	response *http.Response,
+	auth authConfig,
+	fileName string,
+) error {
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return faults.Transport("failed to read remote response body", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := cassetteInteraction{
+		Request: cassetteRequest{
+			Method:  request.Method,
+			URL:     request.URL.RequestURI(),
+			Headers: redactCassetteHeaders(request.Header, auth),
+		},
+		Response: cassetteResponse{
+			Status:  response.StatusCode,
+			Headers: redactCassetteHeaders(response.Header, auth),
+		},
+	}
+	secretAttributes := cassetteSecretAttributes(ctx)
+	recordedRequestBody, requestContentType, err := redactCassetteBody(requestBody, request.Header.Get("Content-Type"), secretAttributes)
+	if err != nil {
+		return faults.Invalid(fmt.Sprintf("failed to redact request body of %s %s for HTTP cassette", request.Method, request.URL.Path), err)
+	}
+	recordedResponseBody, responseContentType, err := redactCassetteBody(responseBody, response.Header.Get("Content-Type"), secretAttributes)
+	if err != nil {
+		return faults.Invalid(fmt.Sprintf("failed to redact response body of %s %s for HTTP cassette", request.Method, request.URL.Path), err)
+	}
+	setCassetteContentType(interaction.Request.Headers, requestContentType)
+	setCassetteContentType(interaction.Response.Headers, responseContentType)
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeCassetteBody(recordedRequestBody)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeCassetteBody(recordedResponseBody)
+
+	encoded, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return faults.Internal("failed to encode HTTP cassette interaction", err)
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return faults.Invalid(fmt.Sprintf("failed to create HTTP cassette directory %q", c.dir), err)
+	}
+	path := filepath.Join(c.dir, fileName)
+	if err := os.WriteFile(path, append(encoded, '\n'), 0o600); err != nil {
+		return faults.Invalid(fmt.Sprintf("failed to write HTTP cassette %q", path), err)
+	}
+	debugctx.Detailf(ctx, "http cassette recorded method=%q url=%q file=%q", request.Method, request.URL.Path, path)
+	return nil
+}
+
+func (c *httpCassette) replay(ctx context.Context, request *http.Request, key string, fileName string) (*http.Response, error) {
+	path := filepath.Join(c.dir, fileName)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		path, err = c.lastRecording(request, key)
+		if err == nil {
+			content, err = os.ReadFile(path)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, faults.Transport(
+				fmt.Sprintf("no recorded HTTP interaction for %s %s in %q", request.Method, request.URL.RequestURI(), c.dir),
+				nil,
+			)
+		}
+		return nil, faults.Invalid(fmt.Sprintf("failed to read HTTP cassette %q", path), err)
+	}
+
+	var interaction cassetteInteraction
+	if err := json.Unmarshal(content, &interaction); err != nil {
+		return nil, faults.Invalid(fmt.Sprintf("HTTP cassette %q is not valid JSON", path), err)
+	}
+	body, err := decodeCassetteBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+	if err != nil {
+		return nil, faults.Invalid(fmt.Sprintf("HTTP cassette %q has an invalid response body", path), err)
+	}
+	debugctx.Detailf(ctx, "http cassette replayed method=%q url=%q file=%q", request.Method, request.URL.Path, path)
+
+	status := interaction.Response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(interaction.Response.Headers).Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+// lastRecording returns the highest-numbered recording for key.
+func (c *httpCassette) lastRecording(request *http.Request, key string) (string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return "", err
+	}
+	prefix := cassetteFilePrefix(request, key)
+	last := ""
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if len(name) > len(last) || (len(name) == len(last) && name > last) {
+			last = name
+		}
+	}
+	if last == "" {
+		return "", fs.ErrNotExist
+	}
+	return filepath.Join(c.dir, last), nil
+}
+
+func readCassetteRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	if request.GetBody != nil {
+		reader, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+		return io.ReadAll(reader)
+	}
+
+	body, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func cassetteKey(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, request.Method+"\n"+request.URL.RequestURI()+"\n")
+	_, _ = hash.Write(stripMultipartBoundary(request, body))
+	return hex.EncodeToString(hash.Sum(nil))[:12]
+}
+
+// stripMultipartBoundary replaces the boundary of a multipart body with a
+// fixed token, so the key depends only on the parts and not on how the
+// boundary was chosen.
+func stripMultipartBoundary(request *http.Request, body []byte) []byte {
+	mediaType, params, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return body
+	}
+	return bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("boundary"))
+}
+
+func cassetteFilePrefix(request *http.Request, key string) string {
+	return cassetteSlug(request.Method+" "+request.URL.Path) + "-" + key + "-"
+}
+
+func cassetteFileName(request *http.Request, key string, call int) string {
+	return fmt.Sprintf("%s%03d.json", cassetteFilePrefix(request, key), call)
+}
+
+func cassetteSlug(value string) string {
+	var builder strings.Builder
+	lastDash := false
+	for _, char := range strings.ToLower(value) {
+		isAlphaNumeric := (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9')
+		switch {
+		case isAlphaNumeric:
+			builder.WriteRune(char)
+			lastDash = false
+		case !lastDash:
+			builder.WriteByte('-')
+			lastDash = true
+		}
+	}
+	slug := strings.Trim(builder.String(), "-")
+	if len(slug) > cassetteSlugMaxLength {
+		slug = strings.TrimRight(slug[:cassetteSlugMaxLength], "-")
+	}
+	return slug
+}
+
+func redactCassetteHeaders(headers http.Header, auth authConfig) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		cloned := append([]string(nil), values...)
+		if auth.shouldRedactHeader(key) {
+			for idx := range cloned {
+				cloned[idx] = redactHeaderValue(key, cloned[idx])
+			}
+		}
+		redacted[key] = cloned
+	}
+	return redacted
+}
+
+type cassetteSecretAttributesKey struct{}
+
+// withCassetteSecretAttributes scopes the secretAttributes of md to requests
+// made with the returned context, so recorded bodies do not keep their values.
+func (g *Client) withCassetteSecretAttributes(ctx context.Context, md metadata.ResourceMetadata) context.Context {
+	if g.cassette == nil || g.cassette.mode != cassetteModeRecord || len(md.SecretAttributes) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, cassetteSecretAttributesKey{}, slices.Clone(md.SecretAttributes))
+}
+
+func cassetteSecretAttributes(ctx context.Context) []string {
+	attributes, _ := ctx.Value(cassetteSecretAttributesKey{}).([]string)
+	return attributes
+}
+
+// redactCassetteBody replaces secret attributes in a structured body. The
+// body is decoded with the payload codec of contentType (JSON when it names
+// none), and each pointer is applied to every object in the tree, so nested
+// list shapes are covered. It returns the body and content type to record; the
+// content type changes only when a multipart body gets a new boundary. A body
+// that cannot be decoded fails, so cleartext is never written.
+func redactCassetteBody(body []byte, contentType string, secretAttributes []string) ([]byte, string, error) {
+	if len(secretAttributes) == 0 || len(bytes.TrimSpace(body)) == 0 {
+		return body, contentType, nil
+	}
+	payloadType := resource.PayloadTypeJSON
+	if strings.TrimSpace(contentType) != "" {
+		resolved, ok := resource.PayloadTypeForMediaType(contentType)
+		if !ok || !resource.IsStructuredPayloadType(resolved) {
+			return nil, "", fmt.Errorf("cannot redact secret attributes from %q body", contentType)
+		}
+		payloadType = resolved
+	}
+
+	value, err := resource.DecodePayload(body, payloadType)
+	if err != nil {
+		return nil, "", err
+	}
+	if !redactCassetteSecretAttributes(value, secretAttributes) {
+		return body, contentType, nil
+	}
+	redacted, err := resource.EncodePayload(value, payloadType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if boundary, ok := resource.MultipartBoundary(redacted); ok && payloadType == resource.PayloadTypeMultipart {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, "", err
+		}
+		params["boundary"] = boundary
+		contentType = mime.FormatMediaType(mediaType, params)
+	}
+	return redacted, contentType, nil
+}
+
+func redactCassetteSecretAttributes(value any, secretAttributes []string) bool {
+	redacted := false
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, attribute := range secretAttributes {
+			current, found, err := resource.LookupJSONPointer(typed, attribute)
+			if err != nil || !found || current == nil {
+				continue
+			}
+			if _, err := resource.SetJSONPointerValue(typed, attribute, "<redacted>"); err == nil {
+				redacted = true
+			}
+		}
+		for _, item := range typed {
+			redacted = redactCassetteSecretAttributes(item, secretAttributes) || redacted
+		}
+	case []any:
+		for _, item := range typed {
+			redacted = redactCassetteSecretAttributes(item, secretAttributes) || redacted
+		}
+	}
+	return redacted
+}
+
+func setCassetteContentType(headers map[string][]string, contentType string) {
+	if _, found := headers["Content-Type"]; found && contentType != "" {
+		headers["Content-Type"] = []string{contentType}
+	}
+}
+
+func encodeCassetteBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), cassetteBodyEncodingBase64
+}
+
+func decodeCassetteBody(body string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case cassetteBodyEncodingBase64:
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unsupported body encoding %q", encoding)
+	}
+}
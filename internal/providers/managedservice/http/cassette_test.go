@@ -0,0 +1,391 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+func TestHTTPCassetteRecordsAndReplaysInteractions(t *testing.T) {
+	t.Parallel()
+
+	var version atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if version.Load() == 0 {
+				_, _ = w.Write([]byte(`{"id":"a","name":"old"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"a","name":"new"}`))
+		case http.MethodPut:
+			version.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	md := metadata.ResourceMetadata{ID: "{{/id}}"}
+	resolved := resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		LocalAlias:     "a",
+		RemoteID:       "a",
+		Payload:        map[string]any{"id": "a", "name": "new"},
+	}
+	auth := &config.HTTPAuth{
+		CustomHeaders: []config.HeaderTokenAuth{{Header: "X-Api-Key", Value: "top-secret"}},
+	}
+
+	exercise := func(client *Client) []resource.Value {
+		t.Helper()
+
+		values := make([]resource.Value, 0, 2)
+		for _, step := range []string{"get", "update", "get"} {
+			switch step {
+			case "get":
+				content, err := client.Get(context.Background(), resolved, md)
+				if err != nil {
+					t.Fatalf("Get returned error: %v", err)
+				}
+				values = append(values, content.Value)
+			case "update":
+				if _, err := client.Update(context.Background(), resolved, md); err != nil {
+					t.Fatalf("Update returned error: %v", err)
+				}
+			}
+		}
+		return values
+	}
+
+	recorder := mustManagedServiceClient(t, config.HTTPServer{BaseURL: server.URL, Auth: auth}, WithHTTPRecording(dir))
+	recorded := exercise(recorder)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 recorded interactions, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile returned error: %v", err)
+		}
+		if strings.Contains(string(content), "top-secret") {
+			t.Fatalf("expected auth header to be redacted in %s, got %s", entry.Name(), content)
+		}
+	}
+
+	replayer := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: "http://127.0.0.1:1",
+		Auth:    auth,
+	}, WithHTTPReplay(dir))
+	replayed := exercise(replayer)
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Fatalf("expected replayed values %#v, got %#v", recorded, replayed)
+	}
+
+	content, err := replayer.Get(context.Background(), resolved, md)
+	if err != nil {
+		t.Fatalf("Get past the recorded calls returned error: %v", err)
+	}
+	if !reflect.DeepEqual(content.Value, recorded[1]) {
+		t.Fatalf("expected last recorded interaction to be served again, got %#v", content.Value)
+	}
+}
+
+func TestHTTPCassetteRedactsSecretAttributesInBodies(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/items":
+			_, _ = w.Write([]byte(`[{"id":"a","credentials":{"password":"list-secret"}}]`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"id":"a","credentials":{"password":"get-secret"}}`))
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	md := metadata.ResourceMetadata{
+		ID:               "{{/id}}",
+		SecretAttributes: []string{"/credentials/password"},
+	}
+	resolved := resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		LocalAlias:     "a",
+		RemoteID:       "a",
+		Payload: map[string]any{
+			"id":          "a",
+			"credentials": map[string]any{"password": "put-secret"},
+		},
+	}
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	}, WithHTTPRecording(dir))
+	content, err := client.Get(context.Background(), resolved, md)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	password, _, _ := resource.LookupJSONPointerString(content.Value, "/credentials/password")
+	if password != "get-secret" {
+		t.Fatalf("expected the live response to keep its secret, got %q", password)
+	}
+	if _, err := client.Update(context.Background(), resolved, md); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if _, err := client.List(context.Background(), "/items", md); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 recorded interactions, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile returned error: %v", err)
+		}
+		for _, secret := range []string{"get-secret", "put-secret", "list-secret"} {
+			if strings.Contains(string(content), secret) {
+				t.Fatalf("expected %q to be redacted in %s, got %s", secret, entry.Name(), content)
+			}
+		}
+		if !strings.Contains(string(content), `\"password\":\"\\u003credacted\\u003e\"`) {
+			t.Fatalf("expected redacted password in %s, got %s", entry.Name(), content)
+		}
+	}
+}
+
+func TestHTTPCassetteRedactsCookiesForEveryAuthMode(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "server-session", Path: "/"})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"a"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "X-Api-Key", Value: "key"}},
+		},
+	}, WithHTTPRecording(dir))
+	if _, err := client.Get(context.Background(), resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		RemoteID:       "a",
+	}, metadata.ResourceMetadata{ID: "{{/id}}"}); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 recorded interaction, got %d", len(entries))
+	}
+	content, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if strings.Contains(string(content), "server-session") {
+		t.Fatalf("expected Set-Cookie to be redacted, got %s", content)
+	}
+}
+
+func TestHTTPCassetteReplayMissIsTransportError(t *testing.T) {
+	t.Parallel()
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: "http://127.0.0.1:1",
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	}, WithHTTPReplay(t.TempDir()))
+
+	_, err := client.Get(context.Background(), resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		RemoteID:       "a",
+	}, metadata.ResourceMetadata{ID: "{{/id}}"})
+	assertTypedCategory(t, err, faults.TransportError)
+	if !strings.Contains(err.Error(), "no recorded HTTP interaction") {
+		t.Fatalf("expected replay miss error, got %v", err)
+	}
+}
+
+func TestHTTPCassetteKeyIgnoresMultipartBoundary(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(boundary string) (*http.Request, []byte) {
+		body := []byte("--" + boundary + "\r\n" +
+			"Content-Disposition: form-data; name=\"name\"\r\n\r\n" +
+			"deploy\r\n" +
+			"--" + boundary + "--\r\n")
+		request := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(string(body)))
+		request.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+		return request, body
+	}
+
+	first, firstBody := newRequest("first-boundary")
+	second, secondBody := newRequest("second-boundary")
+	if cassetteKey(first, firstBody) != cassetteKey(second, secondBody) {
+		t.Fatal("expected multipart bodies differing only by boundary to share a cassette key")
+	}
+
+	changed, changedBody := newRequest("first-boundary")
+	changedBody = []byte(strings.Replace(string(changedBody), "deploy", "rollback", 1))
+	if cassetteKey(first, firstBody) == cassetteKey(changed, changedBody) {
+		t.Fatal("expected different multipart parts to produce different cassette keys")
+	}
+}
+
+func TestHTTPCassetteRedactsSecretAttributesInEveryStructuredBody(t *testing.T) {
+	t.Parallel()
+
+	for _, contentType := range []string{"application/x-www-form-urlencoded", "multipart/form-data"} {
+		t.Run(contentType, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `{"data":{"items":[{"name":"deploy","credentials":{"password":"nested-secret"}}]}}`)
+			}))
+			t.Cleanup(server.Close)
+
+			dir := t.TempDir()
+			md := metadata.ResourceMetadata{
+				SecretAttributes: []string{"/password", "/credentials/password"},
+				Operations: map[string]metadata.OperationSpec{
+					string(metadata.OperationCreate): {Path: "/jobs", ContentType: contentType},
+				},
+			}
+			resolved := formTestResource()
+			resolved.Payload = map[string]any{"name": "deploy", "password": "form-secret"}
+
+			client := mustManagedServiceClient(t, config.HTTPServer{
+				BaseURL: server.URL,
+				Auth: &config.HTTPAuth{
+					CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+				},
+			}, WithHTTPRecording(dir))
+			if _, err := client.Create(context.Background(), resolved, md); err != nil {
+				t.Fatalf("Create returned error: %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("ReadDir returned error: %v", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("expected 1 recorded interaction, got %d", len(entries))
+			}
+			content, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+			if err != nil {
+				t.Fatalf("ReadFile returned error: %v", err)
+			}
+			for _, secret := range []string{"form-secret", "nested-secret"} {
+				if strings.Contains(string(content), secret) {
+					t.Fatalf("expected %q to be redacted, got %s", secret, content)
+				}
+			}
+
+			var interaction cassetteInteraction
+			if err := json.Unmarshal(content, &interaction); err != nil {
+				t.Fatalf("Unmarshal returned error: %v", err)
+			}
+			body, err := decodeCassetteBody(interaction.Request.Body, interaction.Request.BodyEncoding)
+			if err != nil {
+				t.Fatalf("decodeCassetteBody returned error: %v", err)
+			}
+			payloadType, _ := resource.PayloadTypeForMediaType(interaction.Request.Headers["Content-Type"][0])
+			value, err := resource.DecodePayload(body, payloadType)
+			if err != nil {
+				t.Fatalf("expected the recorded content type to describe the redacted body: %v", err)
+			}
+			password, _, _ := resource.LookupJSONPointerString(value, "/password")
+			if password != "<redacted>" {
+				t.Fatalf("expected redacted form password, got %#v", value)
+			}
+		})
+	}
+}
+
+func TestHTTPCassetteRefusesToRecordUnredactableBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "password=plain-secret")
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	md := metadata.ResourceMetadata{SecretAttributes: []string{"/password"}}
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	}, WithHTTPRecording(dir))
+	if _, err := client.Get(context.Background(), formTestResource(), md); err == nil {
+		t.Fatal("expected Get to fail when the response body cannot be redacted")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected nothing to be recorded, got %d interactions", len(entries))
+	}
+}
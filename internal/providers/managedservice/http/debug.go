@@ -89,6 +89,12 @@ func (g *Client) doRequest(ctx context.Context, purpose string, request *http.Re
 	invoke := func() (*http.Response, error) {
 		return g.client.Do(request)
 	}
+	if g.cassette.intercepts(purpose) {
+		liveInvoke := invoke
+		invoke = func() (*http.Response, error) {
+			return g.cassette.do(ctx, request, g.auth, liveInvoke)
+		}
+	}
 	response, err := g.executeWithThrottle(ctx, purpose, request, invoke)
 	elapsed := time.Since(start)
 
@@ -161,7 +167,7 @@ func debugRequestHeaders(ctx context.Context, request *http.Request, auth authCo
 			}
 			for _, value := range headers.Values(key) {
 				headers.Del(key)
-				headers.Add(key, redactHeaderValue(value))
+				headers.Add(key, redactHeaderValue(key, value))
 			}
 		}
 	}
@@ -182,6 +188,9 @@ func debugResponseHeaders(ctx context.Context, response *http.Response) {
 	keys := sortedHeaderKeys(response.Header)
 	for _, key := range keys {
 		for _, value := range response.Header.Values(key) {
+			if strings.EqualFold(key, "Set-Cookie") && !debugctx.Insecure(ctx) {
+				value = redactHeaderValue(key, value)
+			}
 			debugctx.Printf(ctx, "http response header %s: %s", key, value)
 		}
 	}
@@ -196,7 +205,12 @@ func sortedHeaderKeys(headers http.Header) []string {
 	return keys
 }
 
-func redactHeaderValue(value string) string {
+// redactHeaderValue keeps an auth scheme such as "Bearer" and hides the rest.
+// Cookie values have no scheme, so they are hidden whole.
+func redactHeaderValue(name string, value string) string {
+	if strings.EqualFold(name, "Cookie") || strings.EqualFold(name, "Set-Cookie") {
+		return "<redacted>"
+	}
 	parts := strings.SplitN(value, " ", 2)
 	if len(parts) == 2 {
 		return parts[0] + " <redacted>"
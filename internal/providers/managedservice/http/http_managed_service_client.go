@@ -17,9 +17,11 @@ package http
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"maps"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -47,17 +49,28 @@ type Client struct {
 	auth             authConfig
 	client           *http.Client
 	throttle         *requestThrottleGate
+	retry            *requestRetryPolicy
 	tlsDebug         tlsDebugInfo
 	openAPISource    string
 	metadataRenderer metadata.ResourceOperationSpecRenderer
+	cassette         *httpCassette
 
 	openapiMu     sync.Mutex
 	openapiLoaded bool
 	openapiDoc    map[string]any
 
-	oauthMu          sync.Mutex
-	oauthAccessToken string
-	oauthExpiresAt   time.Time
+	oauthMu           sync.Mutex
+	oauthAccessToken  string
+	oauthExpiresAt    time.Time
+	oauthRefreshToken string
+	oauthSessionStale bool
+	oauthLoginOutput  io.Writer
+	oauthOpenBrowser  func(string) error
+
+	sessionMu        sync.Mutex
+	sessionActive    bool
+	sessionCSRFToken string
+	sessionJar       *sessionCookieJar
 
 	promptRuntime *promptauth.Runtime
 }
@@ -108,25 +121,36 @@ func NewClient(cfg config.HTTPServer, opts ...ClientOption) (*Client, error) {
 			Timeout:   defaultHTTPTimeout,
 			Transport: transport,
 		},
-		tlsDebug:      newTLSDebugInfo(cfg.TLS),
-		openAPISource: strings.TrimSpace(cfg.OpenAPI),
+		tlsDebug:         newTLSDebugInfo(cfg.TLS),
+		openAPISource:    strings.TrimSpace(cfg.OpenAPI),
+		oauthLoginOutput: os.Stderr,
+		oauthOpenBrowser: openBrowserURL,
 	}
 	throttle, err := buildRequestThrottle(cfg.RequestThrottling)
 	if err != nil {
 		return nil, err
 	}
 	client.throttle = throttle
+	retry, err := buildRequestRetryPolicy(cfg.Retry)
+	if err != nil {
+		return nil, err
+	}
+	client.retry = retry
 	for _, opt := range opts {
 		if opt == nil {
 			continue
 		}
 		opt(client)
 	}
-	auth, err := buildAuthConfig(cfg.Auth, client.promptRuntime)
+	auth, err := buildAuthConfig(cfg.Auth, cfg.TLS, client.promptRuntime)
 	if err != nil {
 		return nil, err
 	}
 	client.auth = auth
+	if auth.mode == authModeSession {
+		client.sessionJar = newSessionCookieJar()
+		client.client.Jar = client.sessionJar
+	}
 	if proxyFunc, err := buildProxyFunc(cfg.Proxy, client.promptRuntime); err != nil {
 		return nil, err
 	} else if proxyFunc != nil {
@@ -136,6 +160,7 @@ func NewClient(cfg config.HTTPServer, opts ...ClientOption) (*Client, error) {
 }
 
 func (g *Client) Get(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (resource.Content, error) {
+	ctx = g.withCassetteSecretAttributes(ctx, md)
 	spec, err := g.BuildRequestFromMetadata(ctx, resolvedResource, md, metadata.OperationGet)
 	if err != nil {
 		return resource.Content{}, err
@@ -156,16 +181,18 @@ func (g *Client) Get(ctx context.Context, resolvedResource resource.Resource, md
 		return resource.Content{}, err
 	}
 	content.Value = value
+	content.Version = remoteVersionFromHeaders(headers)
 	return content, nil
 }
 
 func (g *Client) Create(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (resource.Content, error) {
+	ctx = g.withCassetteSecretAttributes(ctx, md)
 	spec, err := g.BuildRequestFromMetadata(ctx, resolvedResource, md, metadata.OperationCreate)
 	if err != nil {
 		return resource.Content{}, err
 	}
 
-	body, headers, err := g.execute(ctx, spec)
+	body, headers, err := g.executeMutation(ctx, spec)
 	if err != nil {
 		return resource.Content{}, err
 	}
@@ -174,30 +201,40 @@ func (g *Client) Create(ctx context.Context, resolvedResource resource.Resource,
 }
 
 func (g *Client) Update(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (resource.Content, error) {
+	ctx = g.withCassetteSecretAttributes(ctx, md)
 	spec, err := g.BuildRequestFromMetadata(ctx, resolvedResource, md, metadata.OperationUpdate)
 	if err != nil {
 		return resource.Content{}, err
 	}
+	applyRequestPreconditions(&spec, resolvedResource.Version)
 
-	body, headers, err := g.execute(ctx, spec)
+	body, headers, err := g.executeMutation(ctx, spec)
 	if err != nil {
 		return resource.Content{}, err
 	}
 
-	return decodeResponseBody(body, headers, g.requestBodyDescriptor(resolvedResource, md))
+	content, err := decodeResponseBody(body, headers, g.requestBodyDescriptor(resolvedResource, md))
+	if err != nil {
+		return resource.Content{}, err
+	}
+	content.Version = remoteVersionFromHeaders(headers)
+	return content, nil
 }
 
 func (g *Client) Delete(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) error {
+	ctx = g.withCassetteSecretAttributes(ctx, md)
 	spec, err := g.BuildRequestFromMetadata(ctx, resolvedResource, md, metadata.OperationDelete)
 	if err != nil {
 		return err
 	}
+	applyRequestPreconditions(&spec, resolvedResource.Version)
 
-	_, _, err = g.execute(ctx, spec)
+	_, _, err = g.executeMutation(ctx, spec)
 	return err
 }
 
 func (g *Client) List(ctx context.Context, collectionPath string, md metadata.ResourceMetadata) ([]resource.Resource, error) {
+	ctx = g.withCassetteSecretAttributes(ctx, md)
 	spec, err := g.BuildRequestFromMetadata(ctx, resource.Resource{
 		LogicalPath:    collectionPath,
 		CollectionPath: collectionPath,
@@ -206,15 +243,23 @@ func (g *Client) List(ctx context.Context, collectionPath string, md metadata.Re
 		return nil, err
 	}
 
-	body, headers, err := g.execute(ctx, spec)
+	if spec.Pagination == nil {
+		body, headers, err := g.execute(ctx, spec)
+		if err != nil {
+			return nil, err
+		}
+		return g.decodeListResponse(ctx, collectionPath, md, spec, body, headers)
+	}
+
+	items, descriptor, err := g.listAllPages(ctx, md, spec)
 	if err != nil {
 		return nil, err
 	}
-
-	return g.decodeListResponse(ctx, collectionPath, md, spec, body, headers)
+	return managedservice.BuildListResources(collectionPath, md, items, descriptor)
 }
 
 func (g *Client) Exists(ctx context.Context, resolvedResource resource.Resource, md metadata.ResourceMetadata) (bool, error) {
+	ctx = g.withCassetteSecretAttributes(ctx, md)
 	spec, err := g.BuildRequestFromMetadata(ctx, resolvedResource, md, metadata.OperationGet)
 	if err != nil {
 		return false, err
@@ -3534,6 +3534,20 @@ func mustManagedServiceClient(
 	return client
 }
 
+// bearerTokenTestClient builds a client against baseURL with a static bearer
+// token and the given retry configuration, or none when retry is nil.
+func bearerTokenTestClient(t *testing.T, baseURL string, retry *config.HTTPRetry) *Client {
+	t.Helper()
+
+	return mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: baseURL,
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+		Retry: retry,
+	})
+}
+
 func TestJQCacheBounding(t *testing.T) {
 	t.Parallel()
 
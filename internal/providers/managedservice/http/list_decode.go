@@ -21,11 +21,9 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/crmarques/declarest/faults"
 	managedservicedomain "github.com/crmarques/declarest/managedservice"
 	"github.com/crmarques/declarest/metadata"
 	"github.com/crmarques/declarest/resource"
-	"github.com/crmarques/declarest/resource/identity"
 )
 
 func extractListItems(payload any) ([]any, error) {
@@ -71,10 +69,6 @@ func extractListItems(payload any) ([]any, error) {
 	}
 }
 
-func buildLogicalPath(collectionPath string, alias string) (string, error) {
-	return resource.JoinLogicalPath(collectionPath, alias)
-}
-
 func (g *Client) decodeListResponse(
 	ctx context.Context,
 	collectionPath string,
@@ -83,70 +77,46 @@ func (g *Client) decodeListResponse(
 	body []byte,
 	headers http.Header,
 ) ([]resource.Resource, error) {
-	content, err := decodeResponseBody(body, headers, g.metadataPayloadDescriptor(md))
-	if err != nil {
-		return nil, err
-	}
-	payload, err := g.applyOperationPayloadTransforms(ctx, content.Value, spec)
+	page, err := g.decodeListPage(ctx, md, spec, body, headers)
 	if err != nil {
 		return nil, err
 	}
+	return managedservicedomain.BuildListResources(collectionPath, md, page.items, page.descriptor)
+}
 
-	items, err := extractListItems(payload)
+// listPage holds one decoded list response. items are the transformed list
+// entries; raw is the decoded response before operation transforms so that
+// pagination can inspect cursors and page sizes the transforms may drop.
+type listPage struct {
+	items      []any
+	raw        any
+	descriptor resource.PayloadDescriptor
+}
+
+func (g *Client) decodeListPage(
+	ctx context.Context,
+	md metadata.ResourceMetadata,
+	spec metadata.OperationSpec,
+	body []byte,
+	headers http.Header,
+) (listPage, error) {
+	content, err := decodeResponseBody(body, headers, g.metadataPayloadDescriptor(md))
 	if err != nil {
-		return nil, err
+		return listPage{}, err
 	}
-
-	normalizedCollectionPath, err := resource.NormalizeLogicalPath(collectionPath)
+	payload, err := g.applyOperationPayloadTransforms(ctx, content.Value, spec)
 	if err != nil {
-		return nil, err
+		return listPage{}, err
 	}
 
-	seenAliases := make(map[string]struct{}, len(items))
-	list := make([]resource.Resource, 0, len(items))
-
-	for _, item := range items {
-		itemMap, ok := item.(map[string]any)
-		if !ok {
-			return nil, managedservicedomain.NewListPayloadShapeError("list payload entries must be JSON objects", nil)
-		}
-
-		normalizedPayload, err := resource.Normalize(itemMap)
-		if err != nil {
-			return nil, err
-		}
-
-		payloadMap, ok := normalizedPayload.(map[string]any)
-		if !ok {
-			return nil, managedservicedomain.NewListPayloadShapeError("list payload entry normalization failed", nil)
-		}
-
-		alias, remoteID, err := identity.ResolveAliasAndRemoteIDForListItem(payloadMap, md)
-		if err != nil {
-			return nil, err
-		}
-		if _, exists := seenAliases[alias]; exists {
-			return nil, faults.Conflict(fmt.Sprintf("remote list contains duplicate alias %q", alias), nil)
-		}
-		seenAliases[alias] = struct{}{}
-
-		logicalPath, err := buildLogicalPath(normalizedCollectionPath, alias)
-		if err != nil {
-			return nil, err
-		}
-
-		list = append(list, resource.Resource{
-			LogicalPath:       logicalPath,
-			CollectionPath:    normalizedCollectionPath,
-			LocalAlias:        alias,
-			RemoteID:          remoteID,
-			Payload:           payloadMap,
-			PayloadDescriptor: content.Descriptor,
-		})
+	items, err := extractListItems(payload)
+	if err != nil {
+		return listPage{}, err
 	}
 
-	sort.Slice(list, func(i int, j int) bool {
-		return list[i].LogicalPath < list[j].LogicalPath
-	})
-	return list, nil
+	return listPage{
+		items:      items,
+		raw:        content.Value,
+		descriptor: content.Descriptor,
+	}, nil
 }
@@ -0,0 +1,229 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// listAllPages follows the list operation pagination spec until the remote
+// reports no further pages, returning the concatenated (transformed) items.
+func (g *Client) listAllPages(
+	ctx context.Context,
+	md metadata.ResourceMetadata,
+	spec metadata.OperationSpec,
+) ([]any, resource.PayloadDescriptor, error) {
+	pagination := metadata.ResolvePaginationSpec(spec.Pagination)
+
+	pageSpec := spec
+	pageSpec.Query = maps.Clone(spec.Query)
+	if pageSpec.Query == nil {
+		pageSpec.Query = map[string]string{}
+	}
+	if pagination.Limit > 0 && strings.TrimSpace(pagination.LimitParam) != "" {
+		pageSpec.Query[pagination.LimitParam] = strconv.Itoa(pagination.Limit)
+	}
+
+	var (
+		items      []any
+		descriptor resource.PayloadDescriptor
+		offset     int
+		cursor     string
+	)
+	for pageIndex := 0; ; pageIndex++ {
+		if pageIndex >= pagination.MaxPages {
+			return nil, resource.PayloadDescriptor{}, faults.Invalid(
+				fmt.Sprintf(
+					"list pagination for %q exceeded maxPages (%d); raise operations.list.pagination.maxPages if the collection is larger",
+					spec.Path,
+					pagination.MaxPages,
+				),
+				nil,
+			)
+		}
+
+		switch pagination.Type {
+		case metadata.PaginationTypeOffset:
+			pageSpec.Query[pagination.OffsetParam] = strconv.Itoa(offset)
+		case metadata.PaginationTypePage:
+			pageSpec.Query[pagination.PageParam] = strconv.Itoa(*pagination.FirstPage + pageIndex)
+		case metadata.PaginationTypeCursor:
+			if cursor != "" {
+				pageSpec.Query[pagination.CursorParam] = cursor
+			}
+		}
+
+		body, headers, err := g.execute(ctx, pageSpec)
+		if err != nil {
+			return nil, resource.PayloadDescriptor{}, err
+		}
+		page, err := g.decodeListPage(ctx, md, pageSpec, body, headers)
+		if err != nil {
+			return nil, resource.PayloadDescriptor{}, err
+		}
+		items = append(items, page.items...)
+		descriptor = page.descriptor
+
+		rawCount := rawListPageSize(page)
+		debugctx.Printf(
+			ctx,
+			"list pagination type=%q page=%d path=%q items=%d",
+			pagination.Type,
+			pageIndex+1,
+			pageSpec.Path,
+			rawCount,
+		)
+
+		switch pagination.Type {
+		case metadata.PaginationTypeOffset, metadata.PaginationTypePage:
+			if rawCount == 0 || (pagination.Limit > 0 && rawCount < pagination.Limit) {
+				return items, descriptor, nil
+			}
+			offset += rawCount
+		case metadata.PaginationTypeCursor:
+			next, found, err := resource.LookupJSONPointerString(page.raw, pagination.CursorPointer)
+			if err != nil || !found || strings.TrimSpace(next) == "" || next == cursor || rawCount == 0 {
+				return items, descriptor, nil
+			}
+			cursor = next
+		case metadata.PaginationTypeLink:
+			nextURL, found := nextLinkURL(headers)
+			if !found {
+				return items, descriptor, nil
+			}
+			nextPath, nextQuery, err := g.relativeRequestFromURL(nextURL, "list pagination next link")
+			if err != nil {
+				return nil, resource.PayloadDescriptor{}, err
+			}
+			pageSpec.Path = nextPath
+			pageSpec.Query = nextQuery
+		default:
+			return items, descriptor, nil
+		}
+	}
+}
+
+// rawListPageSize counts entries in the untransformed response so that list
+// transforms filtering items out do not end pagination early.
+func rawListPageSize(page listPage) int {
+	if rawItems, err := extractListItems(page.raw); err == nil {
+		return len(rawItems)
+	}
+	return len(page.items)
+}
+
+// nextLinkURL returns the RFC 8288 (formerly RFC 5988) Link target whose rel
+// includes "next".
+func nextLinkURL(headers http.Header) (string, bool) {
+	for _, headerValue := range headers.Values("Link") {
+		for _, link := range splitLinkHeader(headerValue) {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">"), true
+					}
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// splitLinkHeader splits a Link header on commas that are not inside the
+// angle-bracketed target URL.
+func splitLinkHeader(value string) []string {
+	var (
+		links   []string
+		depth   int
+		current strings.Builder
+	)
+	for _, char := range value {
+		switch {
+		case char == '<':
+			depth++
+		case char == '>' && depth > 0:
+			depth--
+		case char == ',' && depth == 0:
+			links = append(links, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(char)
+	}
+	if strings.TrimSpace(current.String()) != "" {
+		links = append(links, current.String())
+	}
+	return links
+}
+
+// relativeRequestFromURL maps a server-provided URL (next-page link, async
+// status URL) back to a request path relative to the base URL so it flows
+// through the regular request pipeline.
+func (g *Client) relativeRequestFromURL(rawLink string, label string) (string, map[string]string, error) {
+	parsed, err := url.Parse(rawLink)
+	if err != nil {
+		return "", nil, faults.Invalid(label+" is invalid", err)
+	}
+	resolved := g.baseURL.ResolveReference(parsed)
+	if !strings.EqualFold(resolved.Scheme, g.baseURL.Scheme) || !strings.EqualFold(resolved.Host, g.baseURL.Host) {
+		return "", nil, faults.Invalid(
+			fmt.Sprintf("%s %q must stay on managed-service.http.base-url", label, rawLink),
+			nil,
+		)
+	}
+
+	requestPath := resolved.Path
+	basePath := strings.TrimSuffix(g.baseURL.Path, "/")
+	if basePath != "" {
+		if requestPath != basePath && !strings.HasPrefix(requestPath, basePath+"/") {
+			return "", nil, faults.Invalid(
+				fmt.Sprintf("%s %q must stay under managed-service.http.base-url", label, rawLink),
+				nil,
+			)
+		}
+		requestPath = strings.TrimPrefix(requestPath, basePath)
+	}
+
+	query := map[string]string{}
+	for key, values := range resolved.Query() {
+		if len(values) > 0 {
+			query[key] = values[0]
+		}
+	}
+	return requestPath, query, nil
+}
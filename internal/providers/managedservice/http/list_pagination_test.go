@@ -0,0 +1,223 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+)
+
+func paginatedListMetadata(pagination *metadata.PaginationSpec) metadata.ResourceMetadata {
+	return metadata.ResourceMetadata{
+		ID: "{{/id}}",
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationList): {Pagination: pagination},
+		},
+	}
+}
+
+func listPageItems(ids ...string) []map[string]any {
+	items := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, map[string]any{"id": id})
+	}
+	return items
+}
+
+func TestListPagination(t *testing.T) {
+	t.Parallel()
+
+	t.Run("offset_limit_merges_pages", func(t *testing.T) {
+		t.Parallel()
+
+		all := []string{"e", "d", "c", "b", "a"}
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+			max, _ := strconv.Atoi(r.URL.Query().Get("max"))
+			if max != 2 {
+				t.Errorf("expected max=2, got %q", r.URL.Query().Get("max"))
+			}
+			end := min(first+max, len(all))
+			_ = json.NewEncoder(w).Encode(listPageItems(all[min(first, len(all)):end]...))
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		items, err := client.List(context.Background(), "/users", paginatedListMetadata(&metadata.PaginationSpec{
+			Type:        metadata.PaginationTypeOffset,
+			OffsetParam: "first",
+			LimitParam:  "max",
+			Limit:       2,
+		}))
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(items) != 5 || items[0].LogicalPath != "/users/a" || items[4].LogicalPath != "/users/e" {
+			t.Fatalf("expected 5 sorted items, got %#v", items)
+		}
+		if calls.Load() != 3 {
+			t.Fatalf("expected 3 page requests, got %d", calls.Load())
+		}
+	})
+
+	t.Run("page_number_counts_raw_items_before_transforms", func(t *testing.T) {
+		t.Parallel()
+
+		pages := map[string][]map[string]any{
+			"1": {{"id": "a", "kind": "keep"}, {"id": "b", "kind": "drop"}},
+			"2": {{"id": "c", "kind": "drop"}, {"id": "d", "kind": "drop"}},
+			"3": {{"id": "e", "kind": "keep"}},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": pages[r.URL.Query().Get("page")]})
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		md := paginatedListMetadata(&metadata.PaginationSpec{Type: metadata.PaginationTypePage, Limit: 2})
+		listSpec := md.Operations[string(metadata.OperationList)]
+		listSpec.Transforms = jqMutation(`[ .items[] | select(.kind == "keep") ]`)
+		md.Operations[string(metadata.OperationList)] = listSpec
+
+		items, err := client.List(context.Background(), "/things", md)
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(items) != 2 || items[0].LogicalPath != "/things/a" || items[1].LogicalPath != "/things/e" {
+			t.Fatalf("expected filtered items from all pages, got %#v", items)
+		}
+	})
+
+	t.Run("cursor_from_json_pointer", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("after") {
+			case "":
+				_, _ = fmt.Fprint(w, `{"items":[{"id":"a"}],"meta":{"next":"c1"}}`)
+			case "c1":
+				_, _ = fmt.Fprint(w, `{"items":[{"id":"b"}],"meta":{"next":""}}`)
+			default:
+				t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		items, err := client.List(context.Background(), "/things", paginatedListMetadata(&metadata.PaginationSpec{
+			Type:          metadata.PaginationTypeCursor,
+			CursorParam:   "after",
+			CursorPointer: "/meta/next",
+		}))
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("expected 2 items, got %#v", items)
+		}
+	})
+
+	t.Run("link_header_next", func(t *testing.T) {
+		t.Parallel()
+
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v4/groups" {
+				t.Errorf("unexpected path %q", r.URL.Path)
+			}
+			switch r.URL.Query().Get("page") {
+			case "":
+				w.Header().Set("Link", fmt.Sprintf(`<%s/api/v4/groups?page=2&per_page=1>; rel="next", <%s/api/v4/groups?page=2&per_page=1>; rel="last"`, server.URL, server.URL))
+				_, _ = fmt.Fprint(w, `[{"id":"a"}]`)
+			case "2":
+				_, _ = fmt.Fprint(w, `[{"id":"b"}]`)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL+"/api/v4", nil)
+		items, err := client.List(context.Background(), "/groups", paginatedListMetadata(&metadata.PaginationSpec{
+			Type: metadata.PaginationTypeLink,
+		}))
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("expected 2 items, got %#v", items)
+		}
+	})
+
+	t.Run("max_pages_cap_fails_instead_of_truncating", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `[{"id":"p%s"}]`, r.URL.Query().Get("page"))
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		_, err := client.List(context.Background(), "/things", paginatedListMetadata(&metadata.PaginationSpec{
+			Type:     metadata.PaginationTypePage,
+			Limit:    1,
+			MaxPages: 3,
+		}))
+		assertTypedCategory(t, err, faults.ValidationError)
+	})
+
+	t.Run("duplicate_alias_across_pages", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "3" {
+				_, _ = fmt.Fprint(w, `[]`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `[{"id":"same"}]`)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		_, err := client.List(context.Background(), "/things", paginatedListMetadata(&metadata.PaginationSpec{
+			Type: metadata.PaginationTypePage,
+		}))
+		assertTypedCategory(t, err, faults.ConflictError)
+	})
+}
+
+func TestNextLinkURL(t *testing.T) {
+	t.Parallel()
+
+	headers := http.Header{}
+	headers.Add("Link", `<https://api.example.com/items?page=1>; rel="prev", <https://api.example.com/items?a=1,2&page=3>; rel="next"`)
+	next, found := nextLinkURL(headers)
+	if !found || next != "https://api.example.com/items?a=1,2&page=3" {
+		t.Fatalf("unexpected next link %q (found=%t)", next, found)
+	}
+
+	if _, found := nextLinkURL(http.Header{"Link": []string{`<https://x/items?page=1>; rel="last"`}}); found {
+		t.Fatal("expected no next link")
+	}
+}
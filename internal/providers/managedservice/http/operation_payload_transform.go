@@ -24,6 +24,12 @@ import (
 	"github.com/crmarques/declarest/resource"
 )
 
+// TransformPayload applies the transform steps of spec to payload, the same
+// way resource request and response payloads are transformed.
+func (g *Client) TransformPayload(ctx context.Context, payload any, spec metadata.OperationSpec) (resource.Value, error) {
+	return g.applyOperationPayloadTransforms(ctx, payload, spec)
+}
+
 func (g *Client) applyOperationPayloadTransforms(
 	ctx context.Context,
 	payload any,
@@ -0,0 +1,184 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crmarques/declarest/debugctx"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// executeMutation sends a create/update/delete request. When the operation
+// declares `async` and the remote answers 202 Accepted, it polls the status
+// URL until the completion predicate holds, so callers only see the mutation
+// once it has actually been applied. The accepted response body describes the
+// pending operation rather than the resource, so it is not returned.
+func (g *Client) executeMutation(ctx context.Context, spec metadata.OperationSpec) ([]byte, http.Header, error) {
+	response, err := g.executeResponse(ctx, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if spec.Async == nil || response.statusCode != http.StatusAccepted {
+		return response.body, response.header, nil
+	}
+
+	if err := g.awaitAsyncOperation(ctx, spec, response); err != nil {
+		return nil, nil, err
+	}
+	return nil, nil, nil
+}
+
+func (g *Client) awaitAsyncOperation(ctx context.Context, spec metadata.OperationSpec, accepted remoteResponse) error {
+	async := metadata.ResolveAsyncSpec(spec.Async)
+	pollInterval, err := parseAsyncDuration("pollInterval", async.PollInterval)
+	if err != nil {
+		return err
+	}
+	timeout, err := parseAsyncDuration("timeout", async.Timeout)
+	if err != nil {
+		return err
+	}
+
+	statusURL, err := asyncStatusURL(async, accepted)
+	if err != nil {
+		return err
+	}
+	statusPath, statusQuery, err := g.relativeRequestFromURL(statusURL, "async status URL")
+	if err != nil {
+		return err
+	}
+	statusSpec := metadata.OperationSpec{
+		Method: http.MethodGet,
+		Path:   statusPath,
+		Query:  statusQuery,
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		body, headers, err := g.execute(pollCtx, statusSpec)
+		if err != nil {
+			if ctx.Err() == nil && errors.Is(pollCtx.Err(), context.DeadlineExceeded) {
+				return asyncTimeoutError(spec, statusURL, timeout)
+			}
+			return err
+		}
+		status, err := decodeResponseBody(body, headers, resource.NormalizePayloadDescriptor(resource.PayloadDescriptor{
+			PayloadType: resource.PayloadTypeJSON,
+		}))
+		if err != nil {
+			return faults.Transport(fmt.Sprintf("failed to decode async status response from %q", statusURL), err)
+		}
+
+		failed, err := g.evaluateAsyncPredicate(pollCtx, "failedWhen", async.FailedWhen, status.Value)
+		if err != nil {
+			return err
+		}
+		if failed {
+			return faults.Transport(
+				fmt.Sprintf("remote %s %s operation failed (status %q): %s", spec.Method, spec.Path, statusURL, summarizeBodyForLevel(body, 1)),
+				nil,
+			)
+		}
+		completed, err := g.evaluateAsyncPredicate(pollCtx, "completedWhen", async.CompletedWhen, status.Value)
+		if err != nil {
+			return err
+		}
+		if completed {
+			debugctx.Printf(ctx, "http async operation completed method=%q path=%q polls=%d", spec.Method, spec.Path, attempt)
+			return nil
+		}
+
+		// Level 2: polling progress
+		debugctx.Detailf(ctx, "http async operation pending method=%q path=%q status=%q poll=%d", spec.Method, spec.Path, statusURL, attempt)
+		if waitErr := waitForRetry(pollCtx, pollInterval); waitErr != nil {
+			if ctx.Err() == nil && errors.Is(waitErr, context.DeadlineExceeded) {
+				return asyncTimeoutError(spec, statusURL, timeout)
+			}
+			return waitErr
+		}
+	}
+}
+
+// asyncStatusURL reads the status location from the 202 response header or,
+// when statusPointer is set, from the decoded response body.
+func asyncStatusURL(async *metadata.AsyncSpec, accepted remoteResponse) (string, error) {
+	if pointer := strings.TrimSpace(async.StatusPointer); pointer != "" {
+		content, err := decodeResponseBody(accepted.body, accepted.header, resource.NormalizePayloadDescriptor(resource.PayloadDescriptor{
+			PayloadType: resource.PayloadTypeJSON,
+		}))
+		if err != nil {
+			return "", faults.Transport("failed to decode 202 Accepted response body", err)
+		}
+		value, found, err := resource.LookupJSONPointerString(content.Value, pointer)
+		if err != nil || !found || strings.TrimSpace(value) == "" {
+			return "", faults.Transport(
+				fmt.Sprintf("202 Accepted response has no async status URL at %q", pointer),
+				err,
+			)
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	value := strings.TrimSpace(accepted.header.Get(async.StatusHeader))
+	if value == "" {
+		return "", faults.Transport(
+			fmt.Sprintf("202 Accepted response has no async status URL in header %q", async.StatusHeader),
+			nil,
+		)
+	}
+	return value, nil
+}
+
+func (g *Client) evaluateAsyncPredicate(ctx context.Context, field string, expression string, value any) (bool, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return false, nil
+	}
+
+	code, err := cachedListJQCode(expression)
+	if err != nil {
+		return false, faults.Invalid(fmt.Sprintf("invalid async.%s jq expression", field), err)
+	}
+	matched, err := evaluateAssertionResults(code.RunWithContext(ctx, value))
+	if err != nil {
+		return false, faults.Invalid(fmt.Sprintf("failed to evaluate async.%s", field), err)
+	}
+	return matched, nil
+}
+
+func parseAsyncDuration(field string, value string) (time.Duration, error) {
+	parsed, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || parsed <= 0 {
+		return 0, faults.Invalid(fmt.Sprintf("async.%s must be a positive duration", field), err)
+	}
+	return parsed, nil
+}
+
+func asyncTimeoutError(spec metadata.OperationSpec, statusURL string, timeout time.Duration) error {
+	return faults.Transport(
+		fmt.Sprintf("remote %s %s operation did not complete within %s (status %q)", spec.Method, spec.Path, timeout, statusURL),
+		context.DeadlineExceeded,
+	)
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+func asyncMetadata(operation metadata.Operation, async *metadata.AsyncSpec) metadata.ResourceMetadata {
+	return metadata.ResourceMetadata{
+		ID: "{{/id}}",
+		Operations: map[string]metadata.OperationSpec{
+			string(operation): {Async: async},
+		},
+	}
+}
+
+func asyncTestResource() resource.Resource {
+	return resource.Resource{
+		LogicalPath:    "/jobs/a",
+		CollectionPath: "/jobs",
+		LocalAlias:     "a",
+		RemoteID:       "a",
+		Payload:        map[string]any{"id": "a"},
+	}
+}
+
+func TestExecuteMutationAsync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("location_header_polls_until_completed", func(t *testing.T) {
+		t.Parallel()
+
+		var polls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/jobs":
+				w.Header().Set("Location", "/operations/1")
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = fmt.Fprint(w, `{"operation":"1"}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/operations/1":
+				if polls.Add(1) < 3 {
+					_, _ = fmt.Fprint(w, `{"state":"running"}`)
+					return
+				}
+				_, _ = fmt.Fprint(w, `{"state":"done"}`)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		content, err := client.Create(context.Background(), asyncTestResource(), asyncMetadata(metadata.OperationCreate, &metadata.AsyncSpec{
+			CompletedWhen: `.state == "done"`,
+			PollInterval:  "1ms",
+		}))
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		if content.Value != nil {
+			t.Fatalf("expected accepted body to be dropped, got %#v", content.Value)
+		}
+		if polls.Load() != 3 {
+			t.Fatalf("expected 3 status polls, got %d", polls.Load())
+		}
+	})
+
+	t.Run("status_pointer_and_failure_predicate", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = fmt.Fprint(w, `{"links":{"status":"/operations/2?verbose=true"}}`)
+			case http.MethodGet:
+				if r.URL.Query().Get("verbose") != "true" {
+					t.Errorf("expected status query to be preserved, got %q", r.URL.RawQuery)
+				}
+				_, _ = fmt.Fprint(w, `{"state":"error","message":"quota exceeded"}`)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		err := client.Delete(context.Background(), asyncTestResource(), asyncMetadata(metadata.OperationDelete, &metadata.AsyncSpec{
+			StatusPointer: "/links/status",
+			CompletedWhen: `.state == "done"`,
+			FailedWhen:    `.state == "error"`,
+			PollInterval:  "1ms",
+		}))
+		assertTypedCategory(t, err, faults.TransportError)
+	})
+
+	t.Run("timeout_stops_polling", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				w.Header().Set("Location", "/operations/3")
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"state":"running"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		_, err := client.Update(context.Background(), asyncTestResource(), asyncMetadata(metadata.OperationUpdate, &metadata.AsyncSpec{
+			CompletedWhen: `.state == "done"`,
+			PollInterval:  "5ms",
+			Timeout:       "30ms",
+		}))
+		assertTypedCategory(t, err, faults.TransportError)
+	})
+
+	t.Run("status_url_must_stay_on_base_url", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "https://elsewhere.example.com/operations/4")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		_, err := client.Create(context.Background(), asyncTestResource(), asyncMetadata(metadata.OperationCreate, &metadata.AsyncSpec{
+			CompletedWhen: `.state == "done"`,
+		}))
+		assertTypedCategory(t, err, faults.ValidationError)
+	})
+
+	t.Run("accepted_without_async_spec_is_returned_as_is", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("unexpected %s request", r.Method)
+			}
+			w.Header().Set("Location", "/operations/5")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprint(w, `{"id":"a"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, nil)
+		content, err := client.Create(context.Background(), asyncTestResource(), metadata.ResourceMetadata{ID: "{{/id}}"})
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		if content.Value == nil {
+			t.Fatal("expected synchronous response body to be returned")
+		}
+	})
+}
@@ -71,6 +71,12 @@ func (g *Client) BuildRequestFromMetadata(ctx context.Context, resolvedResource
 	spec.Query = maps.Clone(spec.Query)
 	spec.Headers = mergeHeaders(g.defaultHeaders, spec.Headers)
 
+	if operation == metadata.OperationUpdate && metadata.IsPatchUpdateStrategy(spec.Strategy) {
+		metadata.ApplyUpdateStrategyDefaults(&spec, explicitMethod, explicitContentType)
+		explicitMethod = true
+		explicitContentType = true
+	}
+
 	if err := g.applyOpenAPIFallback(ctx, spec.Path, operation, &spec, explicitMethod, explicitAccept, explicitContentType); err != nil {
 		return metadata.OperationSpec{}, err
 	}
@@ -125,6 +131,16 @@ func (g *Client) BuildRequestFromMetadata(ctx context.Context, resolvedResource
 	if err := g.validateOperationPayload(ctx, resolvedResource, md, spec); err != nil {
 		return metadata.OperationSpec{}, err
 	}
+	if operation == metadata.OperationUpdate && metadata.IsPatchUpdateStrategy(spec.Strategy) {
+		if err := applyUpdatePatchBody(&spec, resolvedResource, bodyDescriptor); err != nil {
+			return metadata.OperationSpec{}, err
+		}
+	}
+	if operationRequiresBody(operation) {
+		if err := applyMultipartFileParts(&spec, md); err != nil {
+			return metadata.OperationSpec{}, err
+		}
+	}
 
 	return spec, nil
 }
@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/crmarques/declarest/debugctx"
 	"github.com/crmarques/declarest/faults"
@@ -106,18 +107,114 @@ func (g *Client) Request(
 	return decodeResponseBody(responseBody, responseHeaders, g.requestFallbackDescriptor(ctx, requestSpec, spec))
 }
 
+// PostJSON sends body as a JSON POST to path, relative to the base URL, and
+// returns the raw response body. It shares the auth, throttling, cassette,
+// and error classification of resource requests; it is retried only when
+// idempotent is true. Backends layered on HTTP, such as GraphQL, use it as
+// their transport.
+func (g *Client) PostJSON(
+	ctx context.Context,
+	path string,
+	headers map[string]string,
+	body any,
+	idempotent bool,
+) ([]byte, error) {
+	descriptor := resource.NormalizePayloadDescriptor(resource.PayloadDescriptor{PayloadType: resource.PayloadTypeJSON})
+	spec := metadata.OperationSpec{
+		Method:      http.MethodPost,
+		Path:        managedservicedomain.NormalizeRequestPath(path),
+		Headers:     mergeHeaders(g.defaultHeaders, headers),
+		Accept:      defaultMediaType,
+		ContentType: defaultMediaType,
+		Body:        resource.Content{Value: body, Descriptor: descriptor},
+		Idempotent:  &idempotent,
+	}
+	if spec.Path == "" {
+		spec.Path = "/"
+	}
+
+	responseBody, _, err := g.execute(ctx, spec)
+	return responseBody, err
+}
+
+// remoteResponse is the outcome of one successful (non-error status) request.
+type remoteResponse struct {
+	statusCode int
+	body       []byte
+	header     http.Header
+}
+
+// execute sends spec and replays it according to the configured retry policy
+// when the operation is idempotent. Every attempt rebuilds the request and
+// passes through the throttle gate again; backoff waits hold no throttle slot.
 func (g *Client) execute(ctx context.Context, spec metadata.OperationSpec) ([]byte, http.Header, error) {
-	request, err := g.newRequest(ctx, spec)
+	response, err := g.executeResponse(ctx, spec)
 	if err != nil {
 		return nil, nil, err
 	}
+	return response.body, response.header, nil
+}
+
+func (g *Client) executeResponse(ctx context.Context, spec metadata.OperationSpec) (remoteResponse, error) {
+	retrySafe := g.retry != nil && spec.IsIdempotent()
+	for attempt := 1; ; attempt++ {
+		response, failure, err := g.executeAttempt(ctx, spec)
+		if err == nil {
+			return response, nil
+		}
+		if !retrySafe || failure == nil {
+			return remoteResponse{}, err
+		}
+		delay, ok := g.retry.nextDelay(attempt, *failure, time.Now())
+		if !ok {
+			return remoteResponse{}, err
+		}
+
+		// Level 2: retry decision
+		debugctx.Detailf(
+			ctx,
+			"http request retry method=%q path=%q attempt=%d status=%d delay=%s error=%v",
+			spec.Method,
+			spec.Path,
+			attempt,
+			failure.statusCode,
+			delay.Truncate(time.Millisecond),
+			err,
+		)
+		if waitErr := waitForRetry(ctx, delay); waitErr != nil {
+			return remoteResponse{}, err
+		}
+	}
+}
+
+// executeAttempt performs one request. The returned failure is non-nil only
+// when the request reached the transport and may be considered for retry.
+// A request rejected because its login session or exec credential expired is
+// sent once more with renewed credentials.
+func (g *Client) executeAttempt(ctx context.Context, spec metadata.OperationSpec) (remoteResponse, *retryableFailure, error) {
+	response, failure, err := g.sendAttempt(ctx, spec)
+	if failure != nil && g.renewRejectedCredentials(ctx, failure.statusCode) {
+		return g.sendAttempt(ctx, spec)
+	}
+	return response, failure, err
+}
+
+func (g *Client) sendAttempt(ctx context.Context, spec metadata.OperationSpec) (remoteResponse, *retryableFailure, error) {
+	request, err := g.newRequest(ctx, spec)
+	if err != nil {
+		return remoteResponse{}, nil, err
+	}
 
 	// Level 3: log request body
 	debugRequestBody(ctx, request)
 
 	response, err := g.doRequest(ctx, "resource", request)
 	if err != nil {
-		return nil, nil, faults.Transport("remote request failed", err)
+		var failure *retryableFailure
+		if ctx.Err() == nil && !faults.IsCategory(err, faults.ConflictError) {
+			failure = &retryableFailure{}
+		}
+		return remoteResponse{}, failure, faults.Transport("remote request failed", err)
 	}
 	defer func() {
 		_ = response.Body.Close()
@@ -125,18 +222,19 @@ func (g *Client) execute(ctx context.Context, spec metadata.OperationSpec) ([]by
 
 	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
 	if err != nil {
-		return nil, nil, faults.Transport("failed to read remote response body", err)
+		return remoteResponse{}, &retryableFailure{}, faults.Transport("failed to read remote response body", err)
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
 		debugErrorResponse(ctx, spec.Method, spec.Path, response.StatusCode, body)
-		return nil, nil, classifyStatusError(response.StatusCode, body)
+		failure := &retryableFailure{statusCode: response.StatusCode, header: response.Header.Clone()}
+		return remoteResponse{}, failure, classifyStatusError(response.StatusCode, body)
 	}
 
 	// Level 3: log successful response body
 	debugctx.Printf(ctx, "http response body length=%d content=%s", len(body), summarizeBodyForLevel(body, 3))
 
-	return body, response.Header.Clone(), nil
+	return remoteResponse{statusCode: response.StatusCode, body: body, header: response.Header.Clone()}, nil, nil
 }
 
 // debugRequestBody logs the request body at trace level (3).
@@ -235,7 +333,7 @@ func (g *Client) newRequest(ctx context.Context, spec metadata.OperationSpec) (*
 		request.Header.Set("Accept", spec.Accept)
 	}
 	if len(requestBody) > 0 && strings.TrimSpace(spec.ContentType) != "" {
-		request.Header.Set("Content-Type", spec.ContentType)
+		request.Header.Set("Content-Type", requestContentType(spec.ContentType, requestBody))
 	}
 
 	if len(spec.Headers) > 0 {
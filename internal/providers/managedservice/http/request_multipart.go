@@ -0,0 +1,103 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"maps"
+	"mime"
+	"path"
+
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// applyMultipartFileParts turns top-level externalized attributes of a
+// multipart body into file parts named after their externalized file, so
+// uploads carry a filename and a content type. Nested attributes stay plain
+// fields.
+func applyMultipartFileParts(spec *metadata.OperationSpec, md metadata.ResourceMetadata) error {
+	if !isMultipartContentType(spec.ContentType) {
+		return nil
+	}
+	content, ok := spec.Body.(resource.Content)
+	if !ok {
+		return nil
+	}
+	body, ok := content.Value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	attributes, err := metadata.ResolveExternalizedAttributes(md)
+	if err != nil || len(attributes) == 0 {
+		return err
+	}
+
+	body = maps.Clone(body)
+	for _, attribute := range attributes {
+		tokens, err := resource.ParseJSONPointer(attribute.Path)
+		if err != nil || len(tokens) != 1 {
+			continue
+		}
+		fileContent, ok := multipartFileContent(body[tokens[0]])
+		if !ok {
+			continue
+		}
+
+		fileName := path.Base(attribute.File)
+		contentType := resource.DefaultOctetStreamDescriptor().MediaType
+		if descriptor, ok := resource.PayloadDescriptorForFileName(fileName); ok {
+			contentType = descriptor.MediaType
+		}
+		body[tokens[0]] = resource.MultipartFile{
+			FileName:    fileName,
+			ContentType: contentType,
+			Content:     fileContent,
+		}
+	}
+
+	spec.Body = resource.Content{Value: body, Descriptor: content.Descriptor}
+	return nil
+}
+
+func multipartFileContent(value any) ([]byte, bool) {
+	if text, ok := value.(string); ok {
+		return []byte(text), true
+	}
+	return resource.BinaryBytes(value)
+}
+
+// requestContentType adds the boundary of an encoded multipart body to its
+// Content-Type, replacing any boundary set in metadata.
+func requestContentType(contentType string, body []byte) string {
+	if !isMultipartContentType(contentType) {
+		return contentType
+	}
+	boundary, ok := resource.MultipartBoundary(body)
+	if !ok {
+		return contentType
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	params["boundary"] = boundary
+	return mime.FormatMediaType(mediaType, params)
+}
+
+func isMultipartContentType(contentType string) bool {
+	descriptor, ok := resource.PayloadDescriptorForContentType(contentType)
+	return ok && descriptor.PayloadType == resource.PayloadTypeMultipart
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+func formTestResource() resource.Resource {
+	return resource.Resource{
+		LogicalPath:    "/jobs/deploy",
+		CollectionPath: "/jobs",
+		LocalAlias:     "deploy",
+		RemoteID:       "deploy",
+		Payload: map[string]any{
+			"name":   "deploy",
+			"script": "#!/bin/sh\necho ok\n",
+			"retry":  int64(2),
+		},
+	}
+}
+
+func TestCreateSendsFormURLEncodedBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/x-www-form-urlencoded" {
+			t.Errorf("unexpected content type %q", got)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+		}
+		if r.PostForm.Get("name") != "deploy" || r.PostForm.Get("retry") != "2" {
+			t.Errorf("unexpected form fields %#v", r.PostForm)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"name":"deploy"}`)
+	}))
+	defer server.Close()
+
+	md := metadata.ResourceMetadata{
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationCreate): {
+				Path:        "/jobs",
+				ContentType: "application/x-www-form-urlencoded",
+			},
+		},
+	}
+	if _, err := bearerTokenTestClient(t, server.URL, nil).Create(context.Background(), formTestResource(), md); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+}
+
+func TestCreateSendsExternalizedAttributesAsMultipartFiles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("name") != "deploy" || r.FormValue("retry") != "2" {
+			t.Errorf("unexpected multipart fields %#v", r.MultipartForm.Value)
+		}
+
+		file, header, err := r.FormFile("script")
+		if err != nil {
+			t.Errorf("FormFile returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		if header.Filename != "deploy.sh" || string(content) != "#!/bin/sh\necho ok\n" {
+			t.Errorf("unexpected file part %q: %q", header.Filename, content)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"name":"deploy"}`)
+	}))
+	defer server.Close()
+
+	md := metadata.ResourceMetadata{
+		ExternalizedAttributes: []metadata.ExternalizedAttribute{{Path: "/script", File: "scripts/deploy.sh"}},
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationCreate): {
+				Path:        "/jobs",
+				ContentType: "multipart/form-data",
+			},
+		},
+	}
+	if _, err := bearerTokenTestClient(t, server.URL, nil).Create(context.Background(), formTestResource(), md); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// remoteVersionFromHeaders captures the concurrency token of a read response.
+// Weak ETags are ignored because If-Match requires strong comparison.
+func remoteVersionFromHeaders(headers http.Header) resource.Version {
+	version := resource.Version{
+		LastModified: strings.TrimSpace(headers.Get("Last-Modified")),
+	}
+	if etag := strings.TrimSpace(headers.Get("ETag")); etag != "" && !strings.HasPrefix(etag, "W/") {
+		version.ETag = etag
+	}
+	return version
+}
+
+// applyRequestPreconditions adds If-Match (preferred) or If-Unmodified-Since
+// for the observed remote version. Explicit metadata headers are left intact.
+func applyRequestPreconditions(spec *metadata.OperationSpec, version resource.Version) {
+	if spec == nil || version.IsZero() {
+		return
+	}
+	for key := range spec.Headers {
+		if strings.EqualFold(key, "If-Match") || strings.EqualFold(key, "If-Unmodified-Since") {
+			return
+		}
+	}
+
+	headers := maps.Clone(spec.Headers)
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	if version.ETag != "" {
+		headers["If-Match"] = version.ETag
+	} else {
+		headers["If-Unmodified-Since"] = version.LastModified
+	}
+	spec.Headers = headers
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/managedservice"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+func TestRemoteVersionFromHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers := http.Header{}
+	headers.Set("ETag", `"v2"`)
+	headers.Set("Last-Modified", "Wed, 21 Oct 2026 07:28:00 GMT")
+	if got := remoteVersionFromHeaders(headers); got.ETag != `"v2"` || got.LastModified != "Wed, 21 Oct 2026 07:28:00 GMT" {
+		t.Fatalf("unexpected version %#v", got)
+	}
+
+	headers.Set("ETag", `W/"v2"`)
+	if got := remoteVersionFromHeaders(headers); got.ETag != "" {
+		t.Fatalf("expected weak ETag to be ignored, got %q", got.ETag)
+	}
+}
+
+func TestApplyRequestPreconditions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		headers  map[string]string
+		version  resource.Version
+		expected map[string]string
+	}{
+		{
+			name:     "etag_sets_if_match",
+			version:  resource.Version{ETag: `"v1"`, LastModified: "Wed, 21 Oct 2026 07:28:00 GMT"},
+			expected: map[string]string{"If-Match": `"v1"`},
+		},
+		{
+			name:     "last_modified_sets_if_unmodified_since",
+			version:  resource.Version{LastModified: "Wed, 21 Oct 2026 07:28:00 GMT"},
+			expected: map[string]string{"If-Unmodified-Since": "Wed, 21 Oct 2026 07:28:00 GMT"},
+		},
+		{
+			name:     "explicit_metadata_header_wins",
+			headers:  map[string]string{"if-match": "*"},
+			version:  resource.Version{ETag: `"v1"`},
+			expected: map[string]string{"if-match": "*"},
+		},
+		{
+			name:     "zero_version_leaves_headers",
+			headers:  map[string]string{"X-Test": "1"},
+			expected: map[string]string{"X-Test": "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := metadata.OperationSpec{Headers: tc.headers}
+			applyRequestPreconditions(&spec, tc.version)
+			if fmt.Sprint(spec.Headers) != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected headers %v, got %v", tc.expected, spec.Headers)
+			}
+		})
+	}
+}
+
+func TestUpdateSendsIfMatchAndMapsPreconditionFailed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = fmt.Fprint(w, `{"id":"a","name":"old"}`)
+		case http.MethodPut:
+			if r.Header.Get("If-Match") != `"v1"` {
+				t.Errorf("expected If-Match \"v1\", got %q", r.Header.Get("If-Match"))
+			}
+			w.WriteHeader(http.StatusPreconditionFailed)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	})
+	resolved := resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		LocalAlias:     "a",
+		RemoteID:       "a",
+	}
+	md := metadata.ResourceMetadata{ID: "{{/id}}"}
+
+	remote, err := client.Get(context.Background(), resolved, md)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resolved.Payload = map[string]any{"id": "a", "name": "new"}
+	resolved.Version = remote.Version
+
+	_, err = client.Update(context.Background(), resolved, md)
+	assertTypedCategory(t, err, faults.ConflictError)
+	if !managedservice.IsPreconditionFailedError(err) {
+		t.Fatalf("expected precondition failed error, got %v", err)
+	}
+}
+
+func TestDeleteSendsIfMatchAndMapsPreconditionFailed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = fmt.Fprint(w, `{"id":"a","name":"old"}`)
+		case http.MethodDelete:
+			if r.Header.Get("If-Match") != `"v1"` {
+				t.Errorf("expected If-Match \"v1\", got %q", r.Header.Get("If-Match"))
+			}
+			w.WriteHeader(http.StatusPreconditionFailed)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: server.URL,
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	})
+	resolved := resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		LocalAlias:     "a",
+		RemoteID:       "a",
+	}
+	md := metadata.ResourceMetadata{ID: "{{/id}}"}
+
+	remote, err := client.Get(context.Background(), resolved, md)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resolved.Version = remote.Version
+
+	err = client.Delete(context.Background(), resolved, md)
+	assertTypedCategory(t, err, faults.ConflictError)
+	if !managedservice.IsPreconditionFailedError(err) {
+		t.Fatalf("expected precondition failed error, got %v", err)
+	}
+}
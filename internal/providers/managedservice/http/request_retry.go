@@ -0,0 +1,211 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 30 * time.Second
+)
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// requestRetryPolicy decides whether and when a failed attempt is replayed.
+// A nil policy performs exactly one attempt.
+type requestRetryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         bool
+	statusCodes    map[int]struct{}
+	random         func() float64
+}
+
+// retryableFailure describes a failed attempt that reached the transport.
+// statusCode is zero when no response was received (connection refused,
+// reset, timeout).
+type retryableFailure struct {
+	statusCode int
+	header     http.Header
+}
+
+func buildRequestRetryPolicy(cfg *config.HTTPRetry) (*requestRetryPolicy, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	policy := &requestRetryPolicy{
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     defaultRetryMaxBackoff,
+		jitter:         cfg.Jitter,
+		statusCodes:    map[int]struct{}{},
+		random:         rand.Float64,
+	}
+	if cfg.MaxAttempts < 0 {
+		return nil, faults.Invalid("managed-service.http.retry.max-attempts must be greater than zero when set", nil)
+	}
+	if policy.maxAttempts == 0 {
+		policy.maxAttempts = defaultRetryMaxAttempts
+	}
+
+	initialBackoff, initialSet, err := parseRetryDuration("initial-backoff", cfg.InitialBackoff)
+	if err != nil {
+		return nil, err
+	}
+	maxBackoff, maxSet, err := parseRetryDuration("max-backoff", cfg.MaxBackoff)
+	if err != nil {
+		return nil, err
+	}
+	if initialSet {
+		policy.initialBackoff = initialBackoff
+	}
+	if maxSet {
+		policy.maxBackoff = maxBackoff
+	}
+	if policy.maxBackoff < policy.initialBackoff {
+		if maxSet {
+			return nil, faults.Invalid("managed-service.http.retry.max-backoff must be greater than or equal to initial-backoff", nil)
+		}
+		policy.maxBackoff = policy.initialBackoff
+	}
+
+	statusCodes := cfg.RetryableStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultRetryableStatusCodes
+	}
+	for _, statusCode := range statusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return nil, faults.Invalid(
+				fmt.Sprintf("managed-service.http.retry.retryable-status-codes contains invalid status code %d", statusCode),
+				nil,
+			)
+		}
+		policy.statusCodes[statusCode] = struct{}{}
+	}
+	return policy, nil
+}
+
+func parseRetryDuration(field string, value string) (time.Duration, bool, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, false, nil
+	}
+	parsed, err := time.ParseDuration(trimmed)
+	if err != nil || parsed <= 0 {
+		return 0, false, faults.Invalid(
+			fmt.Sprintf("managed-service.http.retry.%s must be a positive duration", field),
+			err,
+		)
+	}
+	return parsed, true, nil
+}
+
+// nextDelay returns how long to wait before the attempt following the given
+// (1-based) failed attempt, or false when the failure must not be retried.
+// A Retry-After header longer than the backoff is honored; one exceeding
+// maxBackoff stops retrying so the caller sees the remote error promptly.
+func (p *requestRetryPolicy) nextDelay(attempt int, failure retryableFailure, now time.Time) (time.Duration, bool) {
+	if p == nil || attempt >= p.maxAttempts {
+		return 0, false
+	}
+	if failure.statusCode != 0 {
+		if _, ok := p.statusCodes[failure.statusCode]; !ok {
+			return 0, false
+		}
+	}
+
+	delay := p.backoff(attempt)
+	if retryAfter, ok := parseRetryAfter(failure.header, now); ok {
+		if retryAfter > p.maxBackoff {
+			return 0, false
+		}
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+	return delay, true
+}
+
+// backoff doubles initialBackoff per failed attempt up to maxBackoff. With
+// jitter enabled the delay is drawn uniformly from [backoff/2, backoff].
+func (p *requestRetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.initialBackoff
+	for step := 1; step < attempt && delay < p.maxBackoff; step++ {
+		delay *= 2
+	}
+	if delay > p.maxBackoff {
+		delay = p.maxBackoff
+	}
+	if p.jitter && p.random != nil {
+		half := delay / 2
+		delay = half + time.Duration(p.random()*float64(delay-half))
+	}
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header expressed either as
+// delay-seconds or as an HTTP-date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+)
+
+func TestBuildRequestRetryPolicyValidatesConfiguration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		cfg  *config.HTTPRetry
+	}{
+		{name: "negative max attempts", cfg: &config.HTTPRetry{MaxAttempts: -1}},
+		{name: "invalid initial backoff", cfg: &config.HTTPRetry{InitialBackoff: "soon"}},
+		{name: "non-positive max backoff", cfg: &config.HTTPRetry{MaxBackoff: "0s"}},
+		{name: "max below initial", cfg: &config.HTTPRetry{InitialBackoff: "2s", MaxBackoff: "1s"}},
+		{name: "invalid status code", cfg: &config.HTTPRetry{RetryableStatusCodes: []int{42}}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := buildRequestRetryPolicy(tc.cfg)
+			if !faults.IsCategory(err, faults.ValidationError) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRequestRetryPolicyNextDelay(t *testing.T) {
+	t.Parallel()
+
+	policy, err := buildRequestRetryPolicy(&config.HTTPRetry{
+		MaxAttempts:    4,
+		InitialBackoff: "100ms",
+		MaxBackoff:     "250ms",
+	})
+	if err != nil {
+		t.Fatalf("buildRequestRetryPolicy returned error: %v", err)
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	unavailable := retryableFailure{statusCode: http.StatusServiceUnavailable}
+
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 250 * time.Millisecond} {
+		delay, ok := policy.nextDelay(attempt, unavailable, now)
+		if !ok || delay != expected {
+			t.Fatalf("attempt %d: expected delay %s, got %s (ok=%t)", attempt, expected, delay, ok)
+		}
+	}
+	if _, ok := policy.nextDelay(4, unavailable, now); ok {
+		t.Fatal("expected no retry after maxAttempts")
+	}
+	if _, ok := policy.nextDelay(1, retryableFailure{statusCode: http.StatusBadRequest}, now); ok {
+		t.Fatal("expected 400 not to be retried")
+	}
+
+	header := http.Header{"Retry-After": []string{now.Add(200 * time.Millisecond).Format(http.TimeFormat)}}
+	if delay, ok := policy.nextDelay(1, retryableFailure{statusCode: http.StatusTooManyRequests, header: header}, now); !ok || delay != 100*time.Millisecond {
+		t.Fatalf("expected sub-second HTTP-date Retry-After to round down to backoff, got %s (ok=%t)", delay, ok)
+	}
+	header = http.Header{"Retry-After": []string{"120"}}
+	if _, ok := policy.nextDelay(1, retryableFailure{statusCode: http.StatusTooManyRequests, header: header}, now); ok {
+		t.Fatal("expected Retry-After beyond maxBackoff to stop retrying")
+	}
+
+	policy.jitter = true
+	policy.random = func() float64 { return 0 }
+	if delay, _ := policy.nextDelay(2, unavailable, now); delay != 100*time.Millisecond {
+		t.Fatalf("expected jittered delay to be half the backoff, got %s", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "seconds", value: "3", expected: 3 * time.Second, ok: true},
+		{name: "http date", value: now.Add(5 * time.Second).Format(http.TimeFormat), expected: 5 * time.Second, ok: true},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0, ok: true},
+		{name: "missing", value: "", ok: false},
+		{name: "garbage", value: "later", ok: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			if tc.value != "" {
+				header.Set("Retry-After", tc.value)
+			}
+			delay, ok := parseRetryAfter(header, now)
+			if ok != tc.ok || delay != tc.expected {
+				t.Fatalf("expected (%s, %t), got (%s, %t)", tc.expected, tc.ok, delay, ok)
+			}
+		})
+	}
+}
+
+func TestExecuteRetries(t *testing.T) {
+	t.Parallel()
+
+	fastRetry := &config.HTTPRetry{MaxAttempts: 3, InitialBackoff: "1ms", MaxBackoff: "5ms"}
+
+	t.Run("idempotent_get_retries_until_success", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"id":"a"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, fastRetry)
+		_, _, err := client.execute(context.Background(), metadata.OperationSpec{Method: http.MethodGet, Path: "/items/a"})
+		if err != nil {
+			t.Fatalf("execute returned error: %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Fatalf("expected 3 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("exhausted_attempts_return_last_error", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, fastRetry)
+		_, _, err := client.execute(context.Background(), metadata.OperationSpec{Method: http.MethodDelete, Path: "/items/a"})
+		assertTypedCategory(t, err, faults.TransportError)
+		if calls.Load() != 3 {
+			t.Fatalf("expected 3 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("post_is_not_retried_by_default", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, fastRetry)
+		_, _, err := client.execute(context.Background(), metadata.OperationSpec{Method: http.MethodPost, Path: "/items", Body: map[string]any{"id": "a"}})
+		assertTypedCategory(t, err, faults.TransportError)
+		if calls.Load() != 1 {
+			t.Fatalf("expected a single attempt, got %d", calls.Load())
+		}
+	})
+
+	t.Run("post_marked_idempotent_replays_body", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buf := make([]byte, 64)
+			n, _ := r.Body.Read(buf)
+			if string(buf[:n]) != `{"id":"a"}` {
+				t.Errorf("unexpected body on attempt %d: %q", calls.Load()+1, string(buf[:n]))
+			}
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"id":"a"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, fastRetry)
+		_, _, err := client.execute(context.Background(), metadata.OperationSpec{
+			Method:     http.MethodPost,
+			Path:       "/items",
+			Body:       map[string]any{"id": "a"},
+			Idempotent: boolPointer(true),
+		})
+		if err != nil {
+			t.Fatalf("execute returned error: %v", err)
+		}
+		if calls.Load() != 2 {
+			t.Fatalf("expected 2 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("non_retryable_status_fails_fast", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		t.Cleanup(server.Close)
+
+		client := bearerTokenTestClient(t, server.URL, fastRetry)
+		_, _, err := client.execute(context.Background(), metadata.OperationSpec{Method: http.MethodGet, Path: "/items/a"})
+		assertTypedCategory(t, err, faults.NotFoundError)
+		if calls.Load() != 1 {
+			t.Fatalf("expected a single attempt, got %d", calls.Load())
+		}
+	})
+
+	t.Run("retries_pass_through_throttle", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{}`)
+		}))
+		t.Cleanup(server.Close)
+
+		client := mustManagedServiceClient(t, config.HTTPServer{
+			BaseURL: server.URL,
+			Auth: &config.HTTPAuth{
+				CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+			},
+			RequestThrottling: &config.HTTPRequestThrottling{MaxConcurrentRequests: 1, RequestsPerSecond: 20, Burst: 1},
+			Retry:             fastRetry,
+		})
+		start := time.Now()
+		if _, _, err := client.execute(context.Background(), metadata.OperationSpec{Method: http.MethodGet, Path: "/items/a"}); err != nil {
+			t.Fatalf("execute returned error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+			t.Fatalf("expected the retry to wait for the rate limiter, elapsed %s", elapsed)
+		}
+	})
+}
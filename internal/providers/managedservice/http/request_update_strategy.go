@@ -0,0 +1,51 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+// applyUpdatePatchBody replaces the update body with the patch computed by
+// the orchestrator. Without a computed patch, merge-patch falls back to the
+// full payload, which is itself a valid merge patch; json-patch cannot.
+func applyUpdatePatchBody(spec *metadata.OperationSpec, resolvedResource resource.Resource, bodyDescriptor resource.PayloadDescriptor) error {
+	strategy := metadata.NormalizeUpdateStrategy(spec.Strategy)
+	if !resource.IsStructuredPayloadType(bodyDescriptor.PayloadType) {
+		return faults.Invalid(
+			fmt.Sprintf("update strategy %q requires structured payloads, got %q", strategy, bodyDescriptor.PayloadType),
+			nil,
+		)
+	}
+
+	patch := resolvedResource.Patch
+	if patch == nil {
+		if strategy != metadata.UpdateStrategyMergePatch {
+			return faults.Invalid(
+				fmt.Sprintf("update strategy %q requires a patch computed from the remote state", strategy),
+				nil,
+			)
+		}
+		patch = unwrapContentValue(spec.Body)
+	}
+
+	descriptor, _ := resource.PayloadDescriptorForContentType(metadata.UpdateStrategyMediaType(strategy))
+	spec.Body = resource.Content{Value: patch, Descriptor: descriptor}
+	return nil
+}
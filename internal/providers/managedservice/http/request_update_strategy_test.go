@@ -0,0 +1,140 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crmarques/declarest/config"
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+)
+
+func TestUpdateSendsPatchForPatchStrategies(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		strategy    string
+		method      string
+		patch       resource.Value
+		contentType string
+		body        string
+	}{
+		{
+			name:        "merge_patch",
+			strategy:    metadata.UpdateStrategyMergePatch,
+			patch:       map[string]any{"name": "new", "legacy": nil},
+			contentType: metadata.MergePatchMediaType,
+			body:        `{"legacy":null,"name":"new"}`,
+		},
+		{
+			name:        "json_patch",
+			strategy:    metadata.UpdateStrategyJSONPatch,
+			patch:       []any{map[string]any{"op": "replace", "path": "/name", "value": "new"}},
+			contentType: metadata.JSONPatchMediaType,
+			body:        `[{"op":"replace","path":"/name","value":"new"}]`,
+		},
+		{
+			name:        "explicit_method_is_kept",
+			strategy:    metadata.UpdateStrategyMergePatch,
+			method:      http.MethodPost,
+			patch:       map[string]any{"name": "new"},
+			contentType: metadata.MergePatchMediaType,
+			body:        `{"name":"new"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expectedMethod := http.MethodPatch
+			if tc.method != "" {
+				expectedMethod = tc.method
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != expectedMethod {
+					t.Errorf("expected method %s, got %s", expectedMethod, r.Method)
+				}
+				if got := r.Header.Get("Content-Type"); got != tc.contentType {
+					t.Errorf("expected content type %q, got %q", tc.contentType, got)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tc.body {
+					t.Errorf("expected body %s, got %s", tc.body, body)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			t.Cleanup(server.Close)
+
+			client := mustManagedServiceClient(t, config.HTTPServer{
+				BaseURL: server.URL,
+				Auth: &config.HTTPAuth{
+					CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+				},
+			})
+			md := metadata.ResourceMetadata{
+				ID: "{{/id}}",
+				Operations: map[string]metadata.OperationSpec{
+					string(metadata.OperationUpdate): {Method: tc.method, Strategy: tc.strategy},
+				},
+			}
+			resolved := resource.Resource{
+				LogicalPath:    "/items/a",
+				CollectionPath: "/items",
+				LocalAlias:     "a",
+				RemoteID:       "a",
+				Payload:        map[string]any{"id": "a", "name": "new"},
+				Patch:          tc.patch,
+			}
+
+			if _, err := client.Update(context.Background(), resolved, md); err != nil {
+				t.Fatalf("Update returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateJSONPatchRequiresComputedPatch(t *testing.T) {
+	t.Parallel()
+
+	client := mustManagedServiceClient(t, config.HTTPServer{
+		BaseURL: "https://example.test",
+		Auth: &config.HTTPAuth{
+			CustomHeaders: []config.HeaderTokenAuth{{Header: "Authorization", Prefix: "Bearer", Value: "token"}},
+		},
+	})
+	md := metadata.ResourceMetadata{
+		Operations: map[string]metadata.OperationSpec{
+			string(metadata.OperationUpdate): {Strategy: metadata.UpdateStrategyJSONPatch},
+		},
+	}
+	resolved := resource.Resource{
+		LogicalPath:    "/items/a",
+		CollectionPath: "/items",
+		RemoteID:       "a",
+		Payload:        map[string]any{"id": "a"},
+	}
+
+	_, err := client.BuildRequestFromMetadata(context.Background(), resolved, md, metadata.OperationUpdate)
+	assertTypedCategory(t, err, faults.ValidationError)
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/managedservice"
 	"github.com/crmarques/declarest/resource"
 )
 
@@ -184,6 +185,11 @@ func classifyStatusError(statusCode int, body []byte) error {
 		return faults.NotFound(message, nil)
 	case http.StatusConflict:
 		return faults.Conflict(message, nil)
+	case http.StatusPreconditionFailed:
+		return managedservice.NewPreconditionFailedError(
+			fmt.Sprintf("remote object changed since it was read (status %d): %s", statusCode, summarizeBody(body)),
+			nil,
+		)
 	}
 
 	if statusCode >= 400 && statusCode < 500 {
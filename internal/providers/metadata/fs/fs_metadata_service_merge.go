@@ -47,6 +47,9 @@ func validateResourceMetadata(kind metadataPathKind, metadata metadatadomain.Res
 	if _, err := metadatadomain.ResolveExternalizedAttributes(metadata); err != nil {
 		return err
 	}
+	if err := metadatadomain.ValidateArrayAttributes(metadata.ArrayAttributes); err != nil {
+		return err
+	}
 	if err := validateIdentityTemplate("resource.id", metadata.ID); err != nil {
 		return err
 	}
@@ -59,6 +62,14 @@ func validateResourceMetadata(kind metadataPathKind, metadata metadatadomain.Res
 	if err := validateAttributePointers("resource.secretAttributes", metadata.SecretAttributes); err != nil {
 		return err
 	}
+	if versionAttribute := strings.TrimSpace(metadata.VersionAttribute); versionAttribute != "" {
+		if _, err := resource.ParseJSONPointer(versionAttribute); err != nil {
+			return faults.Invalid("resource.versionAttribute must be a valid JSON pointer", err)
+		}
+	}
+	if err := metadatadomain.ValidateDependsOn(metadata.DependsOn); err != nil {
+		return err
+	}
 	if err := validateStructuredOnlyMetadataFields(resolvedPayloadType, metadata); err != nil {
 		return err
 	}
@@ -90,6 +101,18 @@ func validateResourceMetadata(kind metadataPathKind, metadata metadatadomain.Res
 		if err := validateOperationValidationSpec(metadatadomain.Operation(key), operationSpec.Validate); err != nil {
 			return err
 		}
+		if err := metadatadomain.ValidatePaginationSpec(metadatadomain.Operation(key), operationSpec.Pagination); err != nil {
+			return err
+		}
+		if err := metadatadomain.ValidateAsyncSpec(metadatadomain.Operation(key), operationSpec.Async); err != nil {
+			return err
+		}
+		if err := metadatadomain.ValidateGraphQLSpec(metadatadomain.Operation(key), operationSpec.GraphQL); err != nil {
+			return err
+		}
+		if err := metadatadomain.ValidateUpdateStrategy(metadatadomain.Operation(key), operationSpec.Strategy); err != nil {
+			return err
+		}
 		if err := metadatadomain.ValidateOperationSpecTemplates(fmt.Sprintf("operation %q", key), operationSpec); err != nil {
 			return err
 		}
@@ -167,6 +190,16 @@ func validateStructuredOnlyMetadataFields(
 			nil,
 		)
 	}
+	if len(metadata.ArrayAttributes) > 0 {
+		return faults.Invalid(
+			fmt.Sprintf(
+				"resource.arrayAttributes requires structured payload type (%s); got %q",
+				structuredPayloadTypes,
+				payloadType,
+			),
+			nil,
+		)
+	}
 	if metadatadomain.HasDefaultsSpecDirectives(metadata.Defaults) {
 		return faults.Invalid(
 			fmt.Sprintf(
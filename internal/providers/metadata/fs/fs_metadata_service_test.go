@@ -849,10 +849,10 @@ func TestFSMetadataValidationFormat(t *testing.T) {
 
 		service := NewFSMetadataService(t.TempDir())
 		err := service.Set(context.Background(), "/customers/_", metadatadomain.ResourceMetadata{
-			Format: "toml",
+			Format: "edn",
 		})
 		assertTypedCategory(t, err, faults.ValidationError)
-		if !strings.Contains(err.Error(), `unsupported payload type "toml"`) {
+		if !strings.Contains(err.Error(), `unsupported payload type "edn"`) {
 			t.Fatalf("expected unsupported payload type error, got %q", err.Error())
 		}
 	})
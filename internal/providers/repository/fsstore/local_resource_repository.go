@@ -22,16 +22,19 @@ import (
 
 	"github.com/crmarques/declarest/faults"
 	"github.com/crmarques/declarest/repository"
+	"github.com/crmarques/declarest/resource"
 )
 
 var _ repository.ResourceStore = (*LocalResourceRepository)(nil)
 var _ repository.RepositorySync = (*LocalResourceRepository)(nil)
 var _ repository.RepositoryTreeReader = (*LocalResourceRepository)(nil)
 var _ repository.ResourceArtifactStore = (*LocalResourceRepository)(nil)
+var _ repository.ResourceNormalizer = (*LocalResourceRepository)(nil)
 
 type LocalResourceRepository struct {
 	baseDir         string
 	metadataBaseDir string
+	serialization   resource.SerializationPolicy
 }
 
 func NewLocalResourceRepository(baseDir string, metadataBaseDir ...string) *LocalResourceRepository {
@@ -41,6 +44,11 @@ func NewLocalResourceRepository(baseDir string, metadataBaseDir ...string) *Loca
 	}
 }
 
+// SetSerializationPolicy sets how resource payload files are written.
+func (r *LocalResourceRepository) SetSerializationPolicy(policy resource.SerializationPolicy) {
+	r.serialization = policy
+}
+
 func (r *LocalResourceRepository) Init(_ context.Context) error {
 	if r.baseDir == "" {
 		return faults.Invalid("repository base directory must not be empty", nil)
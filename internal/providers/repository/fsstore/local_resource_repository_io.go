@@ -53,7 +53,7 @@ func (r *LocalResourceRepository) Save(_ context.Context, logicalPath string, co
 		return r.removePayloadFile(existingFiles.Resource)
 	}
 
-	encoded, err := resource.EncodeContentPretty(content)
+	encoded, err := resource.EncodeContentCanonical(content, r.serialization)
 	if err != nil {
 		return faults.Internal("failed to encode payload", err)
 	}
@@ -118,7 +118,7 @@ func (r *LocalResourceRepository) SaveResourceWithArtifacts(
 		return r.removePayloadFile(existingFiles.Resource)
 	}
 
-	encoded, err := resource.EncodeContentPretty(content)
+	encoded, err := resource.EncodeContentCanonical(content, r.serialization)
 	if err != nil {
 		return faults.Internal("failed to encode payload", err)
 	}
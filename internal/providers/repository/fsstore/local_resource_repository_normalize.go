@@ -0,0 +1,86 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+func (r *LocalResourceRepository) NormalizeResource(
+	_ context.Context,
+	logicalPath string,
+	transform func(resource.Value) (resource.Value, error),
+	dryRun bool,
+) (bool, error) {
+	normalizedPath, err := resource.NormalizeLogicalPath(logicalPath)
+	if err != nil {
+		return false, err
+	}
+	if normalizedPath == "/" {
+		return false, faults.Invalid("logical path must target a resource, not root", nil)
+	}
+
+	files, err := r.discoverPayloadFiles(normalizedPath)
+	if err != nil {
+		return false, err
+	}
+	if files.Resource == nil {
+		return false, faults.NotFound(fmt.Sprintf("resource %q not found", normalizedPath), nil)
+	}
+
+	current, err := os.ReadFile(files.Resource.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, faults.NotFound(fmt.Sprintf("resource payload %q not found", files.Resource.Path), nil)
+		}
+		return false, faults.Internal("failed to read resource payload", err)
+	}
+	content, err := resource.DecodeContent(current, files.Resource.Descriptor)
+	if err != nil {
+		return false, err
+	}
+
+	if transform != nil {
+		content.Value, err = transform(content.Value)
+		if err != nil {
+			return false, err
+		}
+	}
+	if content.Value == nil {
+		return false, nil
+	}
+
+	encoded, err := resource.EncodeContentCanonical(content, r.serialization)
+	if err != nil {
+		return false, faults.Internal("failed to encode payload", err)
+	}
+	if bytes.Equal(encoded, current) {
+		return false, nil
+	}
+	if dryRun {
+		return true, nil
+	}
+	if err := r.writeFileAtomically(files.Resource.Path, encoded, ".declarest-tmp-*", "resource"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
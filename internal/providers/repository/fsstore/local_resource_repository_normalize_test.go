@@ -0,0 +1,106 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crmarques/declarest/resource"
+)
+
+func TestLocalResourceRepositorySaveAppliesSerializationPolicy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	repo := NewLocalResourceRepository(root)
+	repo.SetSerializationPolicy(resource.SerializationPolicy{
+		KeyOrder:         []string{"id"},
+		NormalizeNumbers: true,
+		TrailingNewline:  true,
+	})
+
+	err := repo.Save(context.Background(), "/customers/acme", resource.Content{
+		Value:      map[string]any{"name": "ACME", "id": "acme", "tier": 1.0},
+		Descriptor: resource.PayloadDescriptor{PayloadType: resource.PayloadTypeJSON},
+	})
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "customers", "acme", "resource.json"))
+	if err != nil {
+		t.Fatalf("failed to read saved payload: %v", err)
+	}
+	want := "{\n  \"id\": \"acme\",\n  \"name\": \"ACME\",\n  \"tier\": 1\n}\n"
+	if string(data) != want {
+		t.Fatalf("unexpected saved payload: got %q want %q", data, want)
+	}
+}
+
+func TestLocalResourceRepositoryNormalizeResource(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	payloadPath := filepath.Join(root, "customers", "acme", "resource.yaml")
+	if err := os.MkdirAll(filepath.Dir(payloadPath), 0o755); err != nil {
+		t.Fatalf("failed to create resource directory: %v", err)
+	}
+	original := "tags: [b, a]\nname:   ACME\n"
+	if err := os.WriteFile(payloadPath, []byte(original), 0o600); err != nil {
+		t.Fatalf("failed to write payload: %v", err)
+	}
+
+	repo := NewLocalResourceRepository(root)
+	repo.SetSerializationPolicy(resource.SerializationPolicy{YAMLIndent: 2})
+	sortTags := func(value resource.Value) (resource.Value, error) {
+		object := value.(map[string]any)
+		object["tags"] = []any{"a", "b"}
+		return object, nil
+	}
+
+	changed, err := repo.NormalizeResource(context.Background(), "/customers/acme", sortTags, true)
+	if err != nil {
+		t.Fatalf("NormalizeResource dry run returned error: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected dry run to report a change")
+	}
+	if data, _ := os.ReadFile(payloadPath); string(data) != original {
+		t.Fatalf("expected dry run to leave payload untouched, got %q", data)
+	}
+
+	changed, err = repo.NormalizeResource(context.Background(), "/customers/acme", sortTags, false)
+	if err != nil {
+		t.Fatalf("NormalizeResource returned error: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected NormalizeResource to report a change")
+	}
+	want := "name: ACME\ntags:\n  - a\n  - b\n"
+	if data, _ := os.ReadFile(payloadPath); string(data) != want {
+		t.Fatalf("unexpected normalized payload: got %q want %q", data, want)
+	}
+
+	changed, err = repo.NormalizeResource(context.Background(), "/customers/acme", sortTags, false)
+	if err != nil {
+		t.Fatalf("NormalizeResource returned error: %v", err)
+	}
+	if changed {
+		t.Fatal("expected canonical payload to be reported unchanged")
+	}
+}
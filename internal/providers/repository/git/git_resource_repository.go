@@ -17,13 +17,16 @@ package git
 import (
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/crmarques/declarest/config"
 	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/internal/execcredential"
 	"github.com/crmarques/declarest/internal/promptauth"
 	"github.com/crmarques/declarest/internal/providers/repository/fsstore"
 	proxyhelper "github.com/crmarques/declarest/internal/proxy"
 	"github.com/crmarques/declarest/repository"
+	"github.com/crmarques/declarest/resource"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 )
 
@@ -33,6 +36,8 @@ var _ repository.RepositorySync = (*GitResourceRepository)(nil)
 
 var _ repository.ResourceArtifactStore = (*GitResourceRepository)(nil)
 
+var _ repository.ResourceNormalizer = (*GitResourceRepository)(nil)
+
 var _ repository.RepositoryCommitter = (*GitResourceRepository)(nil)
 
 var _ repository.RepositoryHistoryReader = (*GitResourceRepository)(nil)
@@ -53,6 +58,9 @@ type GitResourceRepository struct {
 	proxy    *config.HTTPProxy
 	autoInit bool
 	runtime  *promptauth.Runtime
+
+	execMu       sync.Mutex
+	execProvider *execcredential.Provider
 }
 
 type Option func(*GitResourceRepository)
@@ -66,6 +74,17 @@ func WithPromptRuntime(runtime *promptauth.Runtime) Option {
 	}
 }
 
+// WithSerializationPolicy sets how resource payload files are written to the
+// local worktree.
+func WithSerializationPolicy(policy resource.SerializationPolicy) Option {
+	return func(repository *GitResourceRepository) {
+		if repository == nil {
+			return
+		}
+		repository.local.SetSerializationPolicy(policy)
+	}
+}
+
 func NewGitResourceRepository(repoConfig config.GitRepository, opts ...Option) *GitResourceRepository {
 	var remoteProxy *config.HTTPProxy
 	if repoConfig.Remote != nil {
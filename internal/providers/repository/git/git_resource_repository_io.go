@@ -40,6 +40,18 @@ func (r *GitResourceRepository) SaveResourceWithArtifacts(
 	return r.local.SaveResourceWithArtifacts(ctx, logicalPath, content, artifacts)
 }
 
+func (r *GitResourceRepository) NormalizeResource(
+	ctx context.Context,
+	logicalPath string,
+	transform func(resource.Value) (resource.Value, error),
+	dryRun bool,
+) (bool, error) {
+	if err := r.ensureInitializedForOperation(ctx); err != nil {
+		return false, err
+	}
+	return r.local.NormalizeResource(ctx, logicalPath, transform, dryRun)
+}
+
 func (r *GitResourceRepository) Get(ctx context.Context, logicalPath string) (resource.Content, error) {
 	if err := r.ensureInitializedForOperation(ctx); err != nil {
 		return resource.Content{}, err
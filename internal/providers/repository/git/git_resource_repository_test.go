@@ -327,6 +327,29 @@ func TestGitRepositoryAuthMethodSanity(t *testing.T) {
 		t.Fatal("expected non-nil token auth method")
 	}
 
+	execProvider := NewGitResourceRepository(
+		config.GitRepository{
+			Local: config.GitLocal{BaseDir: t.TempDir()},
+			Remote: &config.GitRemote{
+				URL: "https://example.invalid/repo.git",
+				Auth: &config.GitAuth{
+					Exec: &config.ExecAuth{
+						Command: "/bin/sh",
+						Args:    []string{"-c", `echo '{"status":{"token":"exec-token"}}'`},
+					},
+				},
+			},
+		},
+	)
+	execAuth, err := execProvider.authMethod(context.Background())
+	if err != nil {
+		t.Fatalf("authMethod exec returned error: %v", err)
+	}
+	execBasicAuth, ok := execAuth.(*httpauth.BasicAuth)
+	if !ok || execBasicAuth.Password != "exec-token" {
+		t.Fatalf("expected exec token as basic auth password, got %#v", execAuth)
+	}
+
 	promptProvider := NewGitResourceRepository(
 		config.GitRepository{
 			Local: config.GitLocal{BaseDir: t.TempDir()},
@@ -19,6 +19,7 @@ import (
 	"net/url"
 
 	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/internal/execcredential"
 	"github.com/crmarques/declarest/internal/promptauth"
 	proxyhelper "github.com/crmarques/declarest/internal/proxy"
 	"github.com/go-git/go-git/v5/plumbing/transport"
@@ -53,6 +54,19 @@ func (r *GitResourceRepository) authMethod(ctx context.Context) (transport.AuthM
 			Username: "token",
 			Password: auth.AccessKey.Token,
 		}, nil
+	case auth.Exec != nil:
+		provider, err := r.execCredentialProvider()
+		if err != nil {
+			return nil, err
+		}
+		token, err := provider.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &httpauth.BasicAuth{
+			Username: "token",
+			Password: token,
+		}, nil
 	case auth.SSH != nil:
 		username := auth.SSH.User
 		if username == "" {
@@ -69,6 +83,22 @@ func (r *GitResourceRepository) authMethod(ctx context.Context) (transport.AuthM
 	}
 }
 
+// execCredentialProvider returns the repository's exec credential helper, so
+// its token is cached across fetches and pushes.
+func (r *GitResourceRepository) execCredentialProvider() (*execcredential.Provider, error) {
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+
+	if r.execProvider == nil {
+		provider, err := execcredential.New("repository.git.remote.auth.exec", *r.remote.Auth.Exec)
+		if err != nil {
+			return nil, err
+		}
+		r.execProvider = provider
+	}
+	return r.execProvider, nil
+}
+
 func (r *GitResourceRepository) proxyOptions(ctx context.Context) (transport.ProxyOptions, error) {
 	proxyConfig, disabled, err := proxyhelper.ResolveWithRuntime("repository.git.remote.proxy", r.proxy, r.runtime)
 	if err != nil {
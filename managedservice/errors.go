@@ -50,3 +50,35 @@ func IsListPayloadShapeError(err error) bool {
 	var target *ListPayloadShapeError
 	return errors.As(err, &target)
 }
+
+// PreconditionFailedError marks conflicts raised because the remote object
+// changed after it was read (HTTP 412 on a conditional update or delete), so
+// callers can retry against fresh state instead of treating it as permanent.
+type PreconditionFailedError struct {
+	err error
+}
+
+func (e *PreconditionFailedError) Error() string {
+	if e == nil || e.err == nil {
+		return "<nil>"
+	}
+	return e.err.Error()
+}
+
+func (e *PreconditionFailedError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.err
+}
+
+func NewPreconditionFailedError(message string, cause error) error {
+	return &PreconditionFailedError{
+		err: faults.Conflict(message, cause),
+	}
+}
+
+func IsPreconditionFailedError(err error) bool {
+	var target *PreconditionFailedError
+	return errors.As(err, &target)
+}
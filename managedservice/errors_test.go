@@ -31,3 +31,18 @@ func TestListPayloadShapeError(t *testing.T) {
 		t.Fatalf("expected list payload shape error to preserve validation category")
 	}
 }
+
+func TestPreconditionFailedError(t *testing.T) {
+	t.Parallel()
+
+	err := NewPreconditionFailedError("remote object changed since it was read", nil)
+	if !IsPreconditionFailedError(err) {
+		t.Fatalf("expected precondition failed error predicate to match")
+	}
+	if !faults.IsCategory(err, faults.ConflictError) {
+		t.Fatalf("expected precondition failed error to preserve conflict category")
+	}
+	if IsPreconditionFailedError(faults.Conflict("duplicate", nil)) {
+		t.Fatalf("expected plain conflict not to match precondition failed predicate")
+	}
+}
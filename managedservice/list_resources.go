@@ -0,0 +1,88 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managedservice
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata"
+	"github.com/crmarques/declarest/resource"
+	"github.com/crmarques/declarest/resource/identity"
+)
+
+// BuildListResources turns decoded list items into resources under
+// collectionPath, resolving each alias and remote ID from metadata identity.
+// Entries are sorted by logical path; duplicate aliases are a conflict.
+func BuildListResources(
+	collectionPath string,
+	md metadata.ResourceMetadata,
+	items []any,
+	descriptor resource.PayloadDescriptor,
+) ([]resource.Resource, error) {
+	normalizedCollectionPath, err := resource.NormalizeLogicalPath(collectionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	seenAliases := make(map[string]struct{}, len(items))
+	list := make([]resource.Resource, 0, len(items))
+
+	for _, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, NewListPayloadShapeError("list payload entries must be JSON objects", nil)
+		}
+
+		normalizedPayload, err := resource.Normalize(itemMap)
+		if err != nil {
+			return nil, err
+		}
+
+		payloadMap, ok := normalizedPayload.(map[string]any)
+		if !ok {
+			return nil, NewListPayloadShapeError("list payload entry normalization failed", nil)
+		}
+
+		alias, remoteID, err := identity.ResolveAliasAndRemoteIDForListItem(payloadMap, md)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := seenAliases[alias]; exists {
+			return nil, faults.Conflict(fmt.Sprintf("remote list contains duplicate alias %q", alias), nil)
+		}
+		seenAliases[alias] = struct{}{}
+
+		logicalPath, err := resource.JoinLogicalPath(normalizedCollectionPath, alias)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, resource.Resource{
+			LogicalPath:       logicalPath,
+			CollectionPath:    normalizedCollectionPath,
+			LocalAlias:        alias,
+			RemoteID:          remoteID,
+			Payload:           payloadMap,
+			PayloadDescriptor: descriptor,
+		})
+	}
+
+	sort.Slice(list, func(i int, j int) bool {
+		return list[i].LogicalPath < list[j].LogicalPath
+	})
+	return list, nil
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+// ValidateArrayAttributes checks that every resource.arrayAttributes entry
+// targets a distinct array by JSON pointer and that its sortBy and mergeKeys
+// pointers parse. An empty pointer selects the element itself.
+func ValidateArrayAttributes(values []ArrayAttribute) error {
+	seen := map[string]int{}
+	for idx, item := range values {
+		pathValue := strings.TrimSpace(item.Path)
+		if pathValue == "" {
+			return faults.Invalid(fmt.Sprintf("resource.arrayAttributes[%d].path is required", idx), nil)
+		}
+		if _, err := resource.ParseJSONPointer(pathValue); err != nil {
+			return faults.Invalid(fmt.Sprintf("resource.arrayAttributes[%d].path must be a valid JSON pointer", idx), err)
+		}
+		if previous, exists := seen[pathValue]; exists {
+			return faults.Invalid(
+				fmt.Sprintf(
+					"resource.arrayAttributes[%d].path duplicates resource.arrayAttributes[%d].path",
+					idx,
+					previous,
+				),
+				nil,
+			)
+		}
+		seen[pathValue] = idx
+
+		for keyIdx, pointer := range item.SortBy {
+			if _, err := resource.ParseJSONPointer(strings.TrimSpace(pointer)); err != nil {
+				return faults.Invalid(
+					fmt.Sprintf("resource.arrayAttributes[%d].sortBy[%d] must be a valid JSON pointer", idx, keyIdx),
+					err,
+				)
+			}
+		}
+		for keyIdx, pointer := range item.MergeKeys {
+			if _, err := resource.ParseJSONPointer(strings.TrimSpace(pointer)); err != nil {
+				return faults.Invalid(
+					fmt.Sprintf("resource.arrayAttributes[%d].mergeKeys[%d] must be a valid JSON pointer", idx, keyIdx),
+					err,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// SortArrayAttributes returns a copy of value with every array declared with
+// sortBy ordered by the values those pointers select in each element.
+// Elements that compare equal keep their relative order, and declared paths
+// that are missing or do not hold an array are left untouched.
+func SortArrayAttributes(value any, values []ArrayAttribute) (any, error) {
+	if err := ValidateArrayAttributes(values); err != nil {
+		return nil, err
+	}
+
+	sorted := value
+	copied := false
+	for _, item := range values {
+		if item.SortBy == nil {
+			continue
+		}
+		pathValue := strings.TrimSpace(item.Path)
+		current, found, err := resource.LookupJSONPointer(sorted, pathValue)
+		if err != nil {
+			return nil, err
+		}
+		items, ok := current.([]any)
+		if !found || !ok {
+			continue
+		}
+
+		ordered := slices.Clone(items)
+		slices.SortStableFunc(ordered, func(left any, right any) int {
+			for _, pointer := range item.SortBy {
+				if result := compareArraySortKeys(left, right, strings.TrimSpace(pointer)); result != 0 {
+					return result
+				}
+			}
+			return 0
+		})
+
+		if !copied {
+			sorted = resource.DeepCopyValue(sorted)
+			copied = true
+		}
+		sorted, err = resource.SetJSONPointerValue(sorted, pathValue, ordered)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// IgnoresOrder reports whether element order is irrelevant when payloads are
+// compared. Arrays sorted on save are order-insensitive too, since the remote
+// side may list them in any order.
+func (a ArrayAttribute) IgnoresOrder() bool {
+	return a.Unordered || len(a.SortBy) > 0
+}
+
+// MatchesElementsByKey reports whether elements of the array are matched by
+// identity rather than by position when payloads are compared.
+func (a ArrayAttribute) MatchesElementsByKey() bool {
+	return len(a.MergeKeys) > 0 || a.IgnoresOrder()
+}
+
+// ArrayElementKeys returns one identity key per element built from the
+// values the MergeKeys pointers select, or from the whole element when no
+// merge keys are declared. Repeated identities are numbered by occurrence so
+// every key is unique. It reports false when any element lacks a merge key.
+func ArrayElementKeys(item ArrayAttribute, elements []any) ([]string, bool) {
+	keys := make([]string, len(elements))
+	occurrences := make(map[string]int, len(elements))
+	for idx, element := range elements {
+		var identity any = element
+		if len(item.MergeKeys) > 0 {
+			values := make([]any, len(item.MergeKeys))
+			for keyIdx, pointer := range item.MergeKeys {
+				value, found, err := resource.LookupJSONPointer(element, strings.TrimSpace(pointer))
+				if err != nil || !found {
+					return nil, false
+				}
+				values[keyIdx] = value
+			}
+			identity = values
+		}
+
+		encoded, err := json.Marshal(identity)
+		if err != nil {
+			return nil, false
+		}
+		occurrence := occurrences[string(encoded)]
+		occurrences[string(encoded)] = occurrence + 1
+		keys[idx] = fmt.Sprintf("%s#%d", encoded, occurrence)
+	}
+	return keys, true
+}
+
+// AlignUnorderedArrays returns a copy of local in which every array that
+// ignores order lists the elements it shares with the matching remote array in
+// remote order, followed by its local-only elements in their original order.
+// Arrays whose elements cannot all be keyed are left untouched.
+func AlignUnorderedArrays(local any, remote any, values []ArrayAttribute) (any, error) {
+	if err := ValidateArrayAttributes(values); err != nil {
+		return nil, err
+	}
+
+	aligned := local
+	copied := false
+	for _, item := range values {
+		if !item.IgnoresOrder() {
+			continue
+		}
+		pathValue := strings.TrimSpace(item.Path)
+		localValue, localFound, err := resource.LookupJSONPointer(aligned, pathValue)
+		if err != nil {
+			return nil, err
+		}
+		remoteValue, remoteFound, err := resource.LookupJSONPointer(remote, pathValue)
+		if err != nil {
+			return nil, err
+		}
+		localItems, localIsArray := localValue.([]any)
+		remoteItems, remoteIsArray := remoteValue.([]any)
+		if !localFound || !remoteFound || !localIsArray || !remoteIsArray {
+			continue
+		}
+
+		localKeys, localKeyed := ArrayElementKeys(item, localItems)
+		remoteKeys, remoteKeyed := ArrayElementKeys(item, remoteItems)
+		if !localKeyed || !remoteKeyed {
+			continue
+		}
+
+		localIndexes := make(map[string]int, len(localKeys))
+		for idx, key := range localKeys {
+			localIndexes[key] = idx
+		}
+		ordered := make([]any, 0, len(localItems))
+		matched := make([]bool, len(localItems))
+		for _, key := range remoteKeys {
+			if idx, found := localIndexes[key]; found {
+				ordered = append(ordered, localItems[idx])
+				matched[idx] = true
+			}
+		}
+		for idx, element := range localItems {
+			if !matched[idx] {
+				ordered = append(ordered, element)
+			}
+		}
+
+		if !copied {
+			aligned = resource.DeepCopyValue(aligned)
+			copied = true
+		}
+		aligned, err = resource.SetJSONPointerValue(aligned, pathValue, ordered)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return aligned, nil
+}
+
+// compareArraySortKeys orders missing keys first, then numbers, strings,
+// and booleans by value; any other pair falls back to their JSON encoding.
+func compareArraySortKeys(left any, right any, pointer string) int {
+	leftValue, leftFound, _ := resource.LookupJSONPointer(left, pointer)
+	rightValue, rightFound, _ := resource.LookupJSONPointer(right, pointer)
+	if !leftFound || !rightFound {
+		return cmp.Compare(boolRank(leftFound), boolRank(rightFound))
+	}
+
+	leftNumber, leftIsNumber := sortKeyNumber(leftValue)
+	rightNumber, rightIsNumber := sortKeyNumber(rightValue)
+	if leftIsNumber && rightIsNumber {
+		return cmp.Compare(leftNumber, rightNumber)
+	}
+	leftString, leftIsString := leftValue.(string)
+	rightString, rightIsString := rightValue.(string)
+	if leftIsString && rightIsString {
+		return strings.Compare(leftString, rightString)
+	}
+	leftBool, leftIsBool := leftValue.(bool)
+	rightBool, rightIsBool := rightValue.(bool)
+	if leftIsBool && rightIsBool {
+		return cmp.Compare(boolRank(leftBool), boolRank(rightBool))
+	}
+
+	leftEncoded, _ := json.Marshal(leftValue)
+	rightEncoded, _ := json.Marshal(rightValue)
+	return bytes.Compare(leftEncoded, rightEncoded)
+}
+
+func sortKeyNumber(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case int64:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	default:
+		return 0, false
+	}
+}
+
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
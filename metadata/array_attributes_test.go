@@ -0,0 +1,153 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortArrayAttributes(t *testing.T) {
+	t.Parallel()
+
+	value := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"name": "email", "priority": int64(2)},
+			map[string]any{"name": "audience", "priority": int64(10)},
+			map[string]any{"priority": int64(1)},
+			map[string]any{"name": "audience", "priority": int64(1)},
+		},
+		"redirectUris": []any{"https://b", "https://a"},
+		"scope":        "openid",
+	}
+
+	sorted, err := SortArrayAttributes(value, []ArrayAttribute{
+		{Path: "/protocolMappers", SortBy: []string{"/name", "/priority"}},
+		{Path: "/redirectUris", SortBy: []string{""}},
+		{Path: "/missing", SortBy: []string{"/name"}},
+		{Path: "/scope", SortBy: []string{""}},
+	})
+	if err != nil {
+		t.Fatalf("SortArrayAttributes returned error: %v", err)
+	}
+
+	want := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"priority": int64(1)},
+			map[string]any{"name": "audience", "priority": int64(1)},
+			map[string]any{"name": "audience", "priority": int64(10)},
+			map[string]any{"name": "email", "priority": int64(2)},
+		},
+		"redirectUris": []any{"https://a", "https://b"},
+		"scope":        "openid",
+	}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Fatalf("unexpected sorted payload: got %#v want %#v", sorted, want)
+	}
+	if first := value["redirectUris"].([]any)[0]; first != "https://b" {
+		t.Fatalf("expected input payload to be left untouched, got first uri %v", first)
+	}
+}
+
+func TestValidateArrayAttributesRejectsInvalidEntries(t *testing.T) {
+	t.Parallel()
+
+	for name, values := range map[string][]ArrayAttribute{
+		"missing path":      {{SortBy: []string{"/name"}}},
+		"invalid path":      {{Path: "name"}},
+		"duplicate path":    {{Path: "/a"}, {Path: "/a"}},
+		"invalid sortBy":    {{Path: "/a", SortBy: []string{"name"}}},
+		"invalid mergeKeys": {{Path: "/a", MergeKeys: []string{"name"}}},
+	} {
+		if err := ValidateArrayAttributes(values); err == nil {
+			t.Fatalf("%s: expected ValidateArrayAttributes to fail", name)
+		}
+	}
+}
+
+func TestArrayElementKeys(t *testing.T) {
+	t.Parallel()
+
+	keyed := ArrayAttribute{Path: "/protocolMappers", MergeKeys: []string{"/name"}}
+	keys, ok := ArrayElementKeys(keyed, []any{
+		map[string]any{"name": "email", "priority": int64(2)},
+		map[string]any{"name": "email", "priority": int64(3)},
+		map[string]any{"name": "audience"},
+	})
+	if !ok {
+		t.Fatal("expected every element to be keyed")
+	}
+	want := []string{`["email"]#0`, `["email"]#1`, `["audience"]#0`}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("unexpected keys: got %#v want %#v", keys, want)
+	}
+
+	if _, ok := ArrayElementKeys(keyed, []any{map[string]any{"priority": int64(1)}}); ok {
+		t.Fatal("expected an element without merge key to disable keying")
+	}
+
+	keys, ok = ArrayElementKeys(ArrayAttribute{Path: "/roles", Unordered: true}, []any{"admin", "viewer"})
+	if !ok || !reflect.DeepEqual(keys, []string{`"admin"#0`, `"viewer"#0`}) {
+		t.Fatalf("expected whole-element keys, got %#v", keys)
+	}
+}
+
+func TestAlignUnorderedArrays(t *testing.T) {
+	t.Parallel()
+
+	local := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"name": "local-only"},
+			map[string]any{"name": "email", "claim": "mail"},
+			map[string]any{"name": "audience"},
+		},
+		"redirectUris": []any{"https://b", "https://a"},
+		"ordered":      []any{"b", "a"},
+	}
+	remote := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"name": "audience"},
+			map[string]any{"name": "remote-only"},
+			map[string]any{"name": "email", "claim": "email"},
+		},
+		"redirectUris": []any{"https://a", "https://b"},
+		"ordered":      []any{"a", "b"},
+	}
+
+	aligned, err := AlignUnorderedArrays(local, remote, []ArrayAttribute{
+		{Path: "/protocolMappers", MergeKeys: []string{"/name"}, Unordered: true},
+		{Path: "/redirectUris", Unordered: true},
+		{Path: "/ordered", MergeKeys: []string{""}},
+	})
+	if err != nil {
+		t.Fatalf("AlignUnorderedArrays returned error: %v", err)
+	}
+
+	want := map[string]any{
+		"protocolMappers": []any{
+			map[string]any{"name": "audience"},
+			map[string]any{"name": "email", "claim": "mail"},
+			map[string]any{"name": "local-only"},
+		},
+		"redirectUris": []any{"https://a", "https://b"},
+		"ordered":      []any{"b", "a"},
+	}
+	if !reflect.DeepEqual(aligned, want) {
+		t.Fatalf("unexpected aligned payload: got %#v want %#v", aligned, want)
+	}
+	if first := local["redirectUris"].([]any)[0]; first != "https://b" {
+		t.Fatalf("expected local payload to be left untouched, got first uri %v", first)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+const (
+	DefaultAsyncStatusHeader = "Location"
+	DefaultAsyncPollInterval = "2s"
+	DefaultAsyncTimeout      = "5m"
+)
+
+// ResolveAsyncSpec returns a copy of spec with defaults filled in. The
+// status URL comes from the Location header unless statusPointer or
+// statusHeader says otherwise. A nil spec resolves to nil (synchronous).
+func ResolveAsyncSpec(spec *AsyncSpec) *AsyncSpec {
+	if spec == nil {
+		return nil
+	}
+
+	resolved := CloneAsyncSpec(spec)
+	if strings.TrimSpace(resolved.StatusPointer) == "" && strings.TrimSpace(resolved.StatusHeader) == "" {
+		resolved.StatusHeader = DefaultAsyncStatusHeader
+	}
+	if strings.TrimSpace(resolved.PollInterval) == "" {
+		resolved.PollInterval = DefaultAsyncPollInterval
+	}
+	if strings.TrimSpace(resolved.Timeout) == "" {
+		resolved.Timeout = DefaultAsyncTimeout
+	}
+	return resolved
+}
+
+func ValidateAsyncSpec(operation Operation, spec *AsyncSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	label := fmt.Sprintf("operation %q async", operation)
+	switch operation {
+	case OperationCreate, OperationUpdate, OperationDelete:
+	default:
+		return faults.Invalid(label+" is only supported on create, update, and delete operations", nil)
+	}
+
+	if strings.TrimSpace(spec.StatusHeader) != "" && strings.TrimSpace(spec.StatusPointer) != "" {
+		return faults.Invalid(label+" must set only one of statusHeader or statusPointer", nil)
+	}
+	if strings.TrimSpace(spec.StatusPointer) != "" {
+		if _, err := resource.ParseJSONPointer(spec.StatusPointer); err != nil {
+			return faults.Invalid(label+".statusPointer must be a valid JSON pointer", err)
+		}
+	}
+
+	if strings.TrimSpace(spec.CompletedWhen) == "" {
+		return faults.Invalid(label+".completedWhen is required", nil)
+	}
+	if err := validateAsyncDuration(label+".pollInterval", spec.PollInterval); err != nil {
+		return err
+	}
+	return validateAsyncDuration(label+".timeout", spec.Timeout)
+}
+
+func validateAsyncDuration(label string, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	if parsed, err := time.ParseDuration(strings.TrimSpace(value)); err != nil || parsed <= 0 {
+		return faults.Invalid(label+" must be a positive duration", err)
+	}
+	return nil
+}
+
+func CloneAsyncSpec(value *AsyncSpec) *AsyncSpec {
+	if value == nil {
+		return nil
+	}
+
+	cloned := *value
+	return &cloned
+}
+
+func mergeAsyncSpec(base *AsyncSpec, overlay *AsyncSpec) *AsyncSpec {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	if overlay == nil {
+		return CloneAsyncSpec(base)
+	}
+
+	merged := CloneAsyncSpec(base)
+	if merged == nil {
+		merged = &AsyncSpec{}
+	}
+
+	if overlay.StatusHeader != "" {
+		merged.StatusHeader = overlay.StatusHeader
+		merged.StatusPointer = ""
+	}
+	if overlay.StatusPointer != "" {
+		merged.StatusPointer = overlay.StatusPointer
+		merged.StatusHeader = ""
+	}
+	if overlay.CompletedWhen != "" {
+		merged.CompletedWhen = overlay.CompletedWhen
+	}
+	if overlay.FailedWhen != "" {
+		merged.FailedWhen = overlay.FailedWhen
+	}
+	if overlay.PollInterval != "" {
+		merged.PollInterval = overlay.PollInterval
+	}
+	if overlay.Timeout != "" {
+		merged.Timeout = overlay.Timeout
+	}
+	return merged
+}
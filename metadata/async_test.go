@@ -0,0 +1,109 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+func TestAsyncSpecRoundTripsThroughWire(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DecodeResourceMetadataYAML([]byte(`
+operations:
+  create:
+    async:
+      statusPointer: /links/status
+      completedWhen: .state == "done"
+      failedWhen: .state == "error"
+      timeout: 10m
+`))
+	if err != nil {
+		t.Fatalf("DecodeResourceMetadataYAML returned error: %v", err)
+	}
+
+	async := decoded.Operations[string(OperationCreate)].Async
+	if async == nil || async.StatusPointer != "/links/status" || async.CompletedWhen != `.state == "done"` || async.Timeout != "10m" {
+		t.Fatalf("unexpected decoded async %#v", async)
+	}
+
+	encoded, err := EncodeResourceMetadataYAML(decoded)
+	if err != nil {
+		t.Fatalf("EncodeResourceMetadataYAML returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), "statusPointer: /links/status") {
+		t.Fatalf("expected async in encoded metadata, got:\n%s", encoded)
+	}
+
+	merged := MergeOperationSpec(
+		OperationSpec{Async: &AsyncSpec{StatusPointer: "/links/status", CompletedWhen: ".done"}},
+		OperationSpec{Async: &AsyncSpec{StatusHeader: "Operation-Location"}},
+	)
+	if merged.Async.StatusHeader != "Operation-Location" || merged.Async.StatusPointer != "" || merged.Async.CompletedWhen != ".done" {
+		t.Fatalf("expected overlay status source to replace the base one, got %#v", merged.Async)
+	}
+}
+
+func TestResolveAsyncSpecDefaults(t *testing.T) {
+	t.Parallel()
+
+	if ResolveAsyncSpec(nil) != nil {
+		t.Fatal("expected nil async spec to stay nil")
+	}
+	resolved := ResolveAsyncSpec(&AsyncSpec{CompletedWhen: ".done"})
+	if resolved.StatusHeader != DefaultAsyncStatusHeader || resolved.PollInterval != DefaultAsyncPollInterval || resolved.Timeout != DefaultAsyncTimeout {
+		t.Fatalf("unexpected resolved async %#v", resolved)
+	}
+	if resolved := ResolveAsyncSpec(&AsyncSpec{StatusPointer: "/id", CompletedWhen: ".done"}); resolved.StatusHeader != "" {
+		t.Fatalf("expected statusPointer to suppress the default header, got %#v", resolved)
+	}
+}
+
+func TestValidateAsyncSpec(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		operation Operation
+		spec      *AsyncSpec
+		wantErr   bool
+	}{
+		{name: "nil", operation: OperationCreate},
+		{name: "valid", operation: OperationDelete, spec: &AsyncSpec{CompletedWhen: ".done", Timeout: "1m"}},
+		{name: "get not supported", operation: OperationGet, spec: &AsyncSpec{CompletedWhen: ".done"}, wantErr: true},
+		{name: "missing completedWhen", operation: OperationCreate, spec: &AsyncSpec{}, wantErr: true},
+		{name: "both status sources", operation: OperationCreate, spec: &AsyncSpec{StatusHeader: "Location", StatusPointer: "/id", CompletedWhen: ".done"}, wantErr: true},
+		{name: "invalid pointer", operation: OperationCreate, spec: &AsyncSpec{StatusPointer: "id", CompletedWhen: ".done"}, wantErr: true},
+		{name: "invalid timeout", operation: OperationUpdate, spec: &AsyncSpec{CompletedWhen: ".done", Timeout: "-1s"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateAsyncSpec(tc.operation, tc.spec)
+			if tc.wantErr && !faults.IsCategory(err, faults.ValidationError) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
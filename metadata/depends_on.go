@@ -0,0 +1,83 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/metadata/identitytemplate"
+	"github.com/crmarques/declarest/resource"
+)
+
+// ValidateDependsOn checks that every dependsOn entry is either a static
+// logical path or an identity template that compiles.
+func ValidateDependsOn(entries []string) error {
+	for idx, entry := range entries {
+		trimmed := strings.TrimSpace(entry)
+		if trimmed == "" {
+			return faults.Invalid(fmt.Sprintf("resource.dependsOn[%d] must not be empty", idx), nil)
+		}
+		if strings.Contains(trimmed, "{{") {
+			if _, err := identitytemplate.Compile(trimmed); err != nil {
+				return faults.Invalid(fmt.Sprintf("resource.dependsOn[%d] is not a valid identity template", idx), err)
+			}
+			continue
+		}
+		if _, err := resource.NormalizeLogicalPath(trimmed); err != nil {
+			return faults.Invalid(fmt.Sprintf("resource.dependsOn[%d] is not a valid logical path", idx), err)
+		}
+	}
+	return nil
+}
+
+// ResolveDependsOn renders the dependsOn entries of md against payload and
+// returns the normalized logical paths in declaration order without
+// duplicates. Entries containing "{{" are identity templates; all others are
+// static logical paths. Templates are skipped when payload is nil, which
+// happens for targets known only by path (for example removed resources).
+func ResolveDependsOn(md ResourceMetadata, payload any) ([]string, error) {
+	if len(md.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	resolved := make([]string, 0, len(md.DependsOn))
+	seen := make(map[string]struct{}, len(md.DependsOn))
+	for idx, entry := range md.DependsOn {
+		rawPath := strings.TrimSpace(entry)
+		if strings.Contains(rawPath, "{{") {
+			if payload == nil {
+				continue
+			}
+			rendered, err := identitytemplate.Render(rawPath, payload)
+			if err != nil {
+				return nil, faults.Invalid(fmt.Sprintf("failed to render resource.dependsOn[%d] %q", idx, entry), err)
+			}
+			rawPath = strings.TrimSpace(rendered)
+		}
+
+		logicalPath, err := resource.NormalizeLogicalPath(rawPath)
+		if err != nil {
+			return nil, faults.Invalid(fmt.Sprintf("resource.dependsOn[%d] resolved to invalid logical path %q", idx, rawPath), err)
+		}
+		if _, exists := seen[logicalPath]; exists {
+			continue
+		}
+		seen[logicalPath] = struct{}{}
+		resolved = append(resolved, logicalPath)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveDependsOnRendersTemplatesAndStaticPaths(t *testing.T) {
+	t.Parallel()
+
+	md := ResourceMetadata{DependsOn: []string{
+		"/realms/{{/realm}}",
+		"/realms/master/",
+		"/realms/{{/realm}}/roles/{{/role}}",
+	}}
+	resolved, err := ResolveDependsOn(md, map[string]any{"realm": "master", "role": "admin"})
+	if err != nil {
+		t.Fatalf("ResolveDependsOn returned error: %v", err)
+	}
+	expected := []string{"/realms/master", "/realms/master/roles/admin"}
+	if !reflect.DeepEqual(resolved, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, resolved)
+	}
+
+	withoutPayload, err := ResolveDependsOn(md, nil)
+	if err != nil {
+		t.Fatalf("ResolveDependsOn without payload returned error: %v", err)
+	}
+	if !reflect.DeepEqual(withoutPayload, []string{"/realms/master"}) {
+		t.Fatalf("expected only static paths without payload, got %#v", withoutPayload)
+	}
+
+	if _, err := ResolveDependsOn(md, map[string]any{"realm": "master"}); err == nil {
+		t.Fatal("expected error when a template pointer is missing from payload")
+	}
+}
+
+func TestValidateDependsOn(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateDependsOn([]string{"/realms/master", "/realms/{{/realm}}"}); err != nil {
+		t.Fatalf("expected valid dependsOn, got %v", err)
+	}
+	for _, invalid := range [][]string{{""}, {"realms/master"}, {"/realms/{{/realm"}, {"/realms/../x"}} {
+		if err := ValidateDependsOn(invalid); err == nil {
+			t.Fatalf("expected validation error for %#v", invalid)
+		}
+	}
+}
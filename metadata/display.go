@@ -14,7 +14,10 @@
 
 package metadata
 
-import "maps"
+import (
+	"maps"
+	"strings"
+)
 
 type displayResourceMetadata struct {
 	Selector   displaySelectorWire   `json:"selector" yaml:"selector"`
@@ -36,6 +39,11 @@ type displayResourceWire struct {
 	Secret                 bool                               `json:"secret" yaml:"secret"`
 	SecretAttributes       []string                           `json:"secretAttributes" yaml:"secretAttributes"`
 	ExternalizedAttributes []displayExternalizedAttributeWire `json:"externalizedAttributes" yaml:"externalizedAttributes"`
+	ArrayAttributes        []displayArrayAttributeWire        `json:"arrayAttributes" yaml:"arrayAttributes"`
+	VersionAttribute       string                             `json:"versionAttribute" yaml:"versionAttribute"`
+	ConditionalDelete      bool                               `json:"conditionalDelete" yaml:"conditionalDelete"`
+	DependsOn              []string                           `json:"dependsOn" yaml:"dependsOn"`
+	Prune                  bool                               `json:"prune" yaml:"prune"`
 }
 
 type displayDefaultsSpec struct {
@@ -55,6 +63,13 @@ type displayExternalizedAttributeWire struct {
 	Enabled        bool   `json:"enabled" yaml:"enabled"`
 }
 
+type displayArrayAttributeWire struct {
+	Path      string   `json:"path" yaml:"path"`
+	SortBy    []string `json:"sortBy" yaml:"sortBy"`
+	MergeKeys []string `json:"mergeKeys" yaml:"mergeKeys"`
+	Unordered bool     `json:"unordered" yaml:"unordered"`
+}
+
 type displayOperationsWire struct {
 	Defaults displayOperationDefaultsWire `json:"defaults" yaml:"defaults"`
 	Get      displayOperationWire         `json:"get" yaml:"get"`
@@ -76,9 +91,43 @@ type displayOperationWire struct {
 	Headers    map[string]string              `json:"headers" yaml:"headers"`
 	Body       any                            `json:"body" yaml:"body"`
 	Transforms []displayTransformStepWire     `json:"transforms" yaml:"transforms"`
+	Pagination *displayPaginationWire         `json:"pagination" yaml:"pagination"`
+	Idempotent bool                           `json:"idempotent" yaml:"idempotent"`
+	Async      *displayAsyncWire              `json:"async" yaml:"async"`
+	GraphQL    *displayGraphQLWire            `json:"graphql" yaml:"graphql"`
+	Strategy   string                         `json:"strategy" yaml:"strategy"`
 	Validate   displayOperationValidationWire `json:"validate" yaml:"validate"`
 }
 
+type displayPaginationWire struct {
+	Type          string `json:"type" yaml:"type"`
+	LimitParam    string `json:"limitParam" yaml:"limitParam"`
+	Limit         int    `json:"limit" yaml:"limit"`
+	OffsetParam   string `json:"offsetParam" yaml:"offsetParam"`
+	PageParam     string `json:"pageParam" yaml:"pageParam"`
+	FirstPage     int    `json:"firstPage" yaml:"firstPage"`
+	CursorParam   string `json:"cursorParam" yaml:"cursorParam"`
+	CursorPointer string `json:"cursorPointer" yaml:"cursorPointer"`
+	MaxPages      int    `json:"maxPages" yaml:"maxPages"`
+}
+
+type displayAsyncWire struct {
+	StatusHeader  string `json:"statusHeader" yaml:"statusHeader"`
+	StatusPointer string `json:"statusPointer" yaml:"statusPointer"`
+	CompletedWhen string `json:"completedWhen" yaml:"completedWhen"`
+	FailedWhen    string `json:"failedWhen" yaml:"failedWhen"`
+	PollInterval  string `json:"pollInterval" yaml:"pollInterval"`
+	Timeout       string `json:"timeout" yaml:"timeout"`
+}
+
+type displayGraphQLWire struct {
+	Query           string         `json:"query" yaml:"query"`
+	OperationName   string         `json:"operationName" yaml:"operationName"`
+	Variables       map[string]any `json:"variables" yaml:"variables"`
+	PayloadVariable string         `json:"payloadVariable" yaml:"payloadVariable"`
+	ResultPointer   string         `json:"resultPointer" yaml:"resultPointer"`
+}
+
 type displayTransformStepWire struct {
 	SelectAttributes  []string `json:"selectAttributes" yaml:"selectAttributes"`
 	ExcludeAttributes []string `json:"excludeAttributes" yaml:"excludeAttributes"`
@@ -101,6 +150,7 @@ type displayValidationAssertionWire struct {
 // attributes while preserving the canonical nested resource/operations schema.
 func DisplayResourceMetadataView(value ResourceMetadata) displayResourceMetadata {
 	expanded := MergeResourceMetadata(DefaultResourceMetadata(), value)
+	displayUpdateStrategyDefaults(&expanded, value)
 
 	return displayResourceMetadata{
 		Selector: displaySelectorWire{
@@ -116,6 +166,11 @@ func DisplayResourceMetadataView(value ResourceMetadata) displayResourceMetadata
 			Secret:                 expanded.IsWholeResourceSecret(),
 			SecretAttributes:       cloneStringSliceOrEmpty(expanded.SecretAttributes),
 			ExternalizedAttributes: displayExternalizedAttributes(expanded.ExternalizedAttributes),
+			ArrayAttributes:        displayArrayAttributes(expanded.ArrayAttributes),
+			VersionAttribute:       expanded.VersionAttribute,
+			ConditionalDelete:      expanded.ConditionalDelete != nil && *expanded.ConditionalDelete,
+			DependsOn:              cloneStringSliceOrEmpty(expanded.DependsOn),
+			Prune:                  expanded.AllowsPrune(),
 		},
 		Operations: displayOperationsWire{
 			Defaults: displayOperationDefaultsWire{
@@ -157,10 +212,104 @@ func displayOperation(metadata ResourceMetadata, operation Operation) displayOpe
 		Headers:    headers,
 		Body:       body,
 		Transforms: displayTransformSteps(spec.Transforms),
+		Pagination: displayPagination(spec.Pagination),
+		Idempotent: spec.IsIdempotent(),
+		Async:      displayAsync(spec.Async),
+		GraphQL:    displayGraphQL(spec.GraphQL),
+		Strategy:   displayStrategy(operation, spec.Strategy),
 		Validate:   displayOperationValidation(spec.Validate),
 	}
 }
 
+// displayUpdateStrategyDefaults replaces the default PUT method and payload
+// media type of a patch update strategy with the values actually sent.
+func displayUpdateStrategyDefaults(expanded *ResourceMetadata, overrides ResourceMetadata) {
+	spec, found := expanded.Operations[string(OperationUpdate)]
+	if !found {
+		return
+	}
+	override := overrides.Operations[string(OperationUpdate)]
+	ApplyUpdateStrategyDefaults(
+		&spec,
+		strings.TrimSpace(override.Method) != "",
+		strings.TrimSpace(override.ContentType) != "",
+	)
+	expanded.Operations[string(OperationUpdate)] = spec
+}
+
+// displayStrategy shows the effective update strategy; other operations have
+// none.
+func displayStrategy(operation Operation, value string) string {
+	if operation != OperationUpdate {
+		return ""
+	}
+	return NormalizeUpdateStrategy(value)
+}
+
+// displayPagination shows the effective pagination parameters, or null when
+// the operation reads a single page.
+func displayPagination(value *PaginationSpec) *displayPaginationWire {
+	resolved := ResolvePaginationSpec(value)
+	if resolved == nil {
+		return nil
+	}
+
+	firstPage := 0
+	if resolved.FirstPage != nil {
+		firstPage = *resolved.FirstPage
+	}
+	return &displayPaginationWire{
+		Type:          resolved.Type,
+		LimitParam:    resolved.LimitParam,
+		Limit:         resolved.Limit,
+		OffsetParam:   resolved.OffsetParam,
+		PageParam:     resolved.PageParam,
+		FirstPage:     firstPage,
+		CursorParam:   resolved.CursorParam,
+		CursorPointer: resolved.CursorPointer,
+		MaxPages:      resolved.MaxPages,
+	}
+}
+
+// displayAsync shows the effective 202 polling parameters, or null when the
+// operation completes synchronously.
+func displayAsync(value *AsyncSpec) *displayAsyncWire {
+	resolved := ResolveAsyncSpec(value)
+	if resolved == nil {
+		return nil
+	}
+
+	return &displayAsyncWire{
+		StatusHeader:  resolved.StatusHeader,
+		StatusPointer: resolved.StatusPointer,
+		CompletedWhen: resolved.CompletedWhen,
+		FailedWhen:    resolved.FailedWhen,
+		PollInterval:  resolved.PollInterval,
+		Timeout:       resolved.Timeout,
+	}
+}
+
+// displayGraphQL shows the GraphQL document of an operation, or null for
+// operations that only map to HTTP requests.
+func displayGraphQL(value *GraphQLSpec) *displayGraphQLWire {
+	if value == nil {
+		return nil
+	}
+
+	cloned := CloneGraphQLSpec(value)
+	variables := cloned.Variables
+	if variables == nil {
+		variables = map[string]any{}
+	}
+	return &displayGraphQLWire{
+		Query:           cloned.Query,
+		OperationName:   cloned.OperationName,
+		Variables:       variables,
+		PayloadVariable: cloned.PayloadVariable,
+		ResultPointer:   cloned.ResultPointer,
+	}
+}
+
 func displayTransformSteps(values []TransformStep) []displayTransformStepWire {
 	if len(values) == 0 {
 		return []displayTransformStepWire{}
@@ -218,6 +367,23 @@ func displayExternalizedAttributes(values []ExternalizedAttribute) []displayExte
 	return items
 }
 
+func displayArrayAttributes(values []ArrayAttribute) []displayArrayAttributeWire {
+	if len(values) == 0 {
+		return []displayArrayAttributeWire{}
+	}
+
+	items := make([]displayArrayAttributeWire, len(values))
+	for idx, value := range values {
+		items[idx] = displayArrayAttributeWire{
+			Path:      value.Path,
+			SortBy:    cloneStringSliceOrEmpty(value.SortBy),
+			MergeKeys: cloneStringSliceOrEmpty(value.MergeKeys),
+			Unordered: value.Unordered,
+		}
+	}
+	return items
+}
+
 func displayDefaults(value *DefaultsSpec) displayDefaultsSpec {
 	if value == nil {
 		return displayDefaultsSpec{
@@ -206,6 +206,22 @@ func TestDisplayTypesMatchCanonicalFieldCount(t *testing.T) {
 			displayExtAttrFields, extAttrFields)
 	}
 
+	// PaginationSpec ↔ displayPaginationWire should match exactly.
+	paginationFields := reflect.TypeOf(PaginationSpec{}).NumField()
+	displayPaginationFields := reflect.TypeOf(displayPaginationWire{}).NumField()
+	if displayPaginationFields != paginationFields {
+		t.Fatalf("displayPaginationWire has %d fields but PaginationSpec has %d; update display types",
+			displayPaginationFields, paginationFields)
+	}
+
+	// AsyncSpec ↔ displayAsyncWire should match exactly.
+	asyncFields := reflect.TypeOf(AsyncSpec{}).NumField()
+	displayAsyncFields := reflect.TypeOf(displayAsyncWire{}).NumField()
+	if displayAsyncFields != asyncFields {
+		t.Fatalf("displayAsyncWire has %d fields but AsyncSpec has %d; update display types",
+			displayAsyncFields, asyncFields)
+	}
+
 	// OperationSpec has Accept and ContentType which are promoted into Headers
 	// on the wire, so displayOperationWire should have
 	// NumField(OperationSpec) - 2 (Accept, ContentType) fields.
@@ -0,0 +1,193 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+func ValidateGraphQLSpec(operation Operation, spec *GraphQLSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	label := fmt.Sprintf("operation %q graphql", operation)
+	if strings.TrimSpace(spec.Query) == "" {
+		return faults.Invalid(label+".query is required", nil)
+	}
+	if strings.TrimSpace(spec.ResultPointer) != "" {
+		if _, err := resource.ParseJSONPointer(spec.ResultPointer); err != nil {
+			return faults.Invalid(label+".resultPointer must be a valid JSON pointer", err)
+		}
+	}
+	if name := strings.TrimSpace(spec.PayloadVariable); name != "" {
+		if _, exists := spec.Variables[name]; exists {
+			return faults.Invalid(fmt.Sprintf("%s.payloadVariable %q is also declared in variables", label, name), nil)
+		}
+	}
+	return nil
+}
+
+func CloneGraphQLSpec(value *GraphQLSpec) *GraphQLSpec {
+	if value == nil {
+		return nil
+	}
+
+	cloned := *value
+	cloned.Variables = cloneGraphQLVariables(value.Variables)
+	return &cloned
+}
+
+func cloneGraphQLVariables(values map[string]any) map[string]any {
+	if values == nil {
+		return nil
+	}
+	cloned, _ := resource.DeepCopyValue(values).(map[string]any)
+	return cloned
+}
+
+func mergeGraphQLSpec(base *GraphQLSpec, overlay *GraphQLSpec) *GraphQLSpec {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	if overlay == nil {
+		return CloneGraphQLSpec(base)
+	}
+
+	merged := CloneGraphQLSpec(base)
+	if merged == nil {
+		merged = &GraphQLSpec{}
+	}
+
+	if overlay.Query != "" {
+		merged.Query = overlay.Query
+	}
+	if overlay.OperationName != "" {
+		merged.OperationName = overlay.OperationName
+	}
+	if overlay.Variables != nil {
+		if merged.Variables == nil {
+			merged.Variables = make(map[string]any, len(overlay.Variables))
+		}
+		for key, value := range overlay.Variables {
+			merged.Variables[key] = resource.DeepCopyValue(value)
+		}
+	}
+	if overlay.PayloadVariable != "" {
+		merged.PayloadVariable = overlay.PayloadVariable
+	}
+	if overlay.ResultPointer != "" {
+		merged.ResultPointer = overlay.ResultPointer
+	}
+	return merged
+}
+
+// renderGraphQLSpec renders the string variables of spec. A variable that is
+// a single field or JSON pointer expression, such as {{/port}}, keeps the
+// type of the value it points at; any other template renders to a string.
+func renderGraphQLSpec(spec *GraphQLSpec, scope map[string]any) (*GraphQLSpec, error) {
+	rendered := CloneGraphQLSpec(spec)
+	if rendered == nil {
+		return nil, nil
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(rendered.Variables)) {
+		value, err := renderGraphQLVariable("graphql.variables."+key, rendered.Variables[key], scope)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Variables[key] = value
+	}
+	return rendered, nil
+}
+
+func renderGraphQLVariable(field string, value any, scope map[string]any) (any, error) {
+	switch typed := value.(type) {
+	case string:
+		if pointer, ok := singleTemplateExpressionPointer(typed); ok {
+			resolved, found, err := resource.LookupJSONPointer(scope, pointer)
+			if err != nil {
+				return nil, faults.Invalid(fmt.Sprintf("failed to render metadata template for %s", field), err)
+			}
+			if !found {
+				return nil, faults.Invalid(
+					fmt.Sprintf("failed to render metadata template for %s: JSON pointer %q did not resolve to a value", field, pointer),
+					nil,
+				)
+			}
+			return resource.DeepCopyValue(resolved), nil
+		}
+		return renderTemplateString(field, typed, scope)
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(typed)) {
+			item, err := renderGraphQLVariable(field+"."+key, typed[key], scope)
+			if err != nil {
+				return nil, err
+			}
+			typed[key] = item
+		}
+		return typed, nil
+	case []any:
+		for idx := range typed {
+			item, err := renderGraphQLVariable(field+"["+strconv.Itoa(idx)+"]", typed[idx], scope)
+			if err != nil {
+				return nil, err
+			}
+			typed[idx] = item
+		}
+		return typed, nil
+	default:
+		return value, nil
+	}
+}
+
+func singleTemplateExpressionPointer(raw string) (string, bool) {
+	trimmed := strings.TrimSpace(raw)
+	if !strings.HasPrefix(trimmed, "{{") || !strings.HasSuffix(trimmed, "}}") {
+		return "", false
+	}
+	expression := trimmed[2 : len(trimmed)-2]
+	if strings.Contains(expression, "{{") || strings.Contains(expression, "}}") {
+		return "", false
+	}
+	return templateExpressionPointer(expression)
+}
+
+func graphQLVariableTemplates(field string, value any, visit func(field string, raw string) error) error {
+	switch typed := value.(type) {
+	case string:
+		return visit(field, typed)
+	case map[string]any:
+		for key, item := range typed {
+			if err := graphQLVariableTemplates(field+"."+key, item, visit); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for idx, item := range typed {
+			if err := graphQLVariableTemplates(field+"["+strconv.Itoa(idx)+"]", item, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+func TestGraphQLSpecRoundTripsThroughWire(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DecodeResourceMetadataYAML([]byte(`
+operations:
+  get:
+    graphql:
+      query: "query Get($id: ID!) { project(id: $id) { id } }"
+      variables:
+        id: "{{/id}}"
+        filter:
+          tags: ["{{/name}}"]
+      resultPointer: /project
+`))
+	if err != nil {
+		t.Fatalf("DecodeResourceMetadataYAML returned error: %v", err)
+	}
+
+	graphql := decoded.Operations[string(OperationGet)].GraphQL
+	if graphql == nil || graphql.ResultPointer != "/project" || graphql.Variables["id"] != "{{/id}}" {
+		t.Fatalf("unexpected decoded graphql %#v", graphql)
+	}
+
+	encoded, err := EncodeResourceMetadataYAML(decoded)
+	if err != nil {
+		t.Fatalf("EncodeResourceMetadataYAML returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), "resultPointer: /project") {
+		t.Fatalf("expected graphql in encoded metadata, got:\n%s", encoded)
+	}
+
+	merged := MergeOperationSpec(
+		OperationSpec{GraphQL: &GraphQLSpec{Query: "query { a }", Variables: map[string]any{"id": "1", "limit": 10}}},
+		OperationSpec{GraphQL: &GraphQLSpec{Variables: map[string]any{"limit": 50}}},
+	)
+	if merged.GraphQL.Query != "query { a }" || !reflect.DeepEqual(merged.GraphQL.Variables, map[string]any{"id": "1", "limit": 50}) {
+		t.Fatalf("expected overlay variables to merge by key, got %#v", merged.GraphQL)
+	}
+}
+
+func TestRenderGraphQLSpecKeepsExpressionTypes(t *testing.T) {
+	t.Parallel()
+
+	spec := &GraphQLSpec{
+		Query: "query { a }",
+		Variables: map[string]any{
+			"port":   "{{/port}}",
+			"label":  "svc-{{/name}}",
+			"nested": map[string]any{"enabled": "{{/enabled}}"},
+			"fixed":  3,
+		},
+	}
+	scope := map[string]any{"port": 8080, "name": "api", "enabled": true}
+
+	rendered, err := renderGraphQLSpec(spec, scope)
+	if err != nil {
+		t.Fatalf("renderGraphQLSpec returned error: %v", err)
+	}
+	want := map[string]any{
+		"port":   8080,
+		"label":  "svc-api",
+		"nested": map[string]any{"enabled": true},
+		"fixed":  3,
+	}
+	if !reflect.DeepEqual(rendered.Variables, want) {
+		t.Fatalf("unexpected rendered variables %#v", rendered.Variables)
+	}
+	if spec.Variables["port"] != "{{/port}}" {
+		t.Fatalf("expected source spec to stay unrendered, got %#v", spec.Variables)
+	}
+
+	if _, err := renderGraphQLSpec(&GraphQLSpec{Query: "q", Variables: map[string]any{"id": "{{/missing}}"}}, scope); !faults.IsCategory(err, faults.ValidationError) {
+		t.Fatalf("expected validation error for unresolved pointer, got %v", err)
+	}
+}
+
+func TestValidateGraphQLSpec(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		spec    *GraphQLSpec
+		wantErr bool
+	}{
+		{name: "nil"},
+		{name: "valid", spec: &GraphQLSpec{Query: "query { a }", PayloadVariable: "input", ResultPointer: "/a"}},
+		{name: "missing query", spec: &GraphQLSpec{ResultPointer: "/a"}, wantErr: true},
+		{name: "invalid pointer", spec: &GraphQLSpec{Query: "query { a }", ResultPointer: "a"}, wantErr: true},
+		{name: "payload variable redeclared", spec: &GraphQLSpec{Query: "query { a }", PayloadVariable: "input", Variables: map[string]any{"input": "x"}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateGraphQLSpec(OperationGet, tc.spec)
+			if tc.wantErr && !faults.IsCategory(err, faults.ValidationError) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -669,7 +669,13 @@ func openAPIMediaTypePriority(mediaType string, schema any, openAPISpec any) int
 		return 1
 	case resource.PayloadTypeXML:
 		return 2
-	case resource.PayloadTypeHCL, resource.PayloadTypeINI, resource.PayloadTypeProperties, resource.PayloadTypeText:
+	case resource.PayloadTypeHCL,
+		resource.PayloadTypeINI,
+		resource.PayloadTypeProperties,
+		resource.PayloadTypeTOML,
+		resource.PayloadTypeCSV,
+		resource.PayloadTypeNDJSON,
+		resource.PayloadTypeText:
 		return 3
 	case resource.PayloadTypeOctetStream:
 		return 4
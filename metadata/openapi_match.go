@@ -120,6 +120,11 @@ func normalizeOperationSpecForComparison(spec OperationSpec) OperationSpec {
 		ContentType: strings.TrimSpace(spec.ContentType),
 		Body:        resource.DeepCopyValue(spec.Body),
 		Transforms:  normalizeTransformStepsForComparison(spec.Transforms),
+		Pagination:  ClonePaginationSpec(spec.Pagination),
+		Idempotent:  cloneBoolPointer(spec.Idempotent),
+		Async:       CloneAsyncSpec(spec.Async),
+		GraphQL:     CloneGraphQLSpec(spec.GraphQL),
+		Strategy:    strings.TrimSpace(spec.Strategy),
 		Validate:    normalizeOperationValidationSpecForComparison(spec.Validate),
 	}
 
@@ -276,6 +276,11 @@ func CompactInferredMetadataDefaults(logicalPath string, inferred ResourceMetada
 		Secret:                 cloneBoolPointer(inferred.Secret),
 		SecretAttributes:       cloneStringSlice(inferred.SecretAttributes),
 		ExternalizedAttributes: cloneExternalizedAttributes(inferred.ExternalizedAttributes),
+		ArrayAttributes:        cloneArrayAttributes(inferred.ArrayAttributes),
+		VersionAttribute:       inferred.VersionAttribute,
+		ConditionalDelete:      cloneBoolPointer(inferred.ConditionalDelete),
+		DependsOn:              cloneStringSlice(inferred.DependsOn),
+		Prune:                  cloneBoolPointer(inferred.Prune),
 		Operations:             cloneOperationMap(inferred.Operations),
 		Transforms:             CloneTransformSteps(inferred.Transforms),
 	}
@@ -294,12 +299,31 @@ func CompactInferredMetadataDefaults(logicalPath string, inferred ResourceMetada
 	return compact, nil
 }
 
+// IsIdempotent reports whether the operation may be replayed safely. An
+// explicit `idempotent` directive wins; otherwise the HTTP method decides
+// (GET, HEAD, OPTIONS, PUT, and DELETE are idempotent).
+func (s OperationSpec) IsIdempotent() bool {
+	if s.Idempotent != nil {
+		return *s.Idempotent
+	}
+	switch strings.ToUpper(strings.TrimSpace(s.Method)) {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
 func renderOperationSpecTemplates(spec OperationSpec, scope map[string]any) (OperationSpec, error) {
 	rendered := OperationSpec{
 		Query:      maps.Clone(spec.Query),
 		Headers:    maps.Clone(spec.Headers),
 		Body:       resource.DeepCopyValue(spec.Body),
 		Transforms: CloneTransformSteps(spec.Transforms),
+		Pagination: ClonePaginationSpec(spec.Pagination),
+		Idempotent: cloneBoolPointer(spec.Idempotent),
+		Async:      CloneAsyncSpec(spec.Async),
+		Strategy:   spec.Strategy,
 		Validate:   cloneOperationValidationSpec(spec.Validate),
 	}
 
@@ -336,6 +360,11 @@ func renderOperationSpecTemplates(spec OperationSpec, scope map[string]any) (Ope
 		rendered.Headers[key] = value
 	}
 
+	rendered.GraphQL, err = renderGraphQLSpec(spec.GraphQL, scope)
+	if err != nil {
+		return OperationSpec{}, err
+	}
+
 	for idx := range rendered.Transforms {
 		if strings.TrimSpace(rendered.Transforms[idx].JQExpression) == "" {
 			continue
@@ -400,6 +429,11 @@ func ValidateOperationSpecTemplates(label string, spec OperationSpec) error {
 			return err
 		}
 	}
+	if spec.GraphQL != nil {
+		if err := graphQLVariableTemplates("graphql.variables", spec.GraphQL.Variables, validate); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,176 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+const (
+	PaginationTypeOffset = "offset"
+	PaginationTypePage   = "page"
+	PaginationTypeCursor = "cursor"
+	PaginationTypeLink   = "link"
+
+	DefaultPaginationMaxPages = 1000
+)
+
+var paginationTypes = []string{
+	PaginationTypeOffset,
+	PaginationTypePage,
+	PaginationTypeCursor,
+	PaginationTypeLink,
+}
+
+// ResolvePaginationSpec returns a copy of spec with type-specific parameter
+// defaults filled in. A nil spec resolves to nil (single-page listing).
+func ResolvePaginationSpec(spec *PaginationSpec) *PaginationSpec {
+	if spec == nil {
+		return nil
+	}
+
+	resolved := ClonePaginationSpec(spec)
+	resolved.Type = strings.ToLower(strings.TrimSpace(resolved.Type))
+	switch resolved.Type {
+	case PaginationTypeOffset:
+		if strings.TrimSpace(resolved.OffsetParam) == "" {
+			resolved.OffsetParam = "offset"
+		}
+		if strings.TrimSpace(resolved.LimitParam) == "" {
+			resolved.LimitParam = "limit"
+		}
+	case PaginationTypePage:
+		if strings.TrimSpace(resolved.PageParam) == "" {
+			resolved.PageParam = "page"
+		}
+		if strings.TrimSpace(resolved.LimitParam) == "" {
+			resolved.LimitParam = "per_page"
+		}
+		if resolved.FirstPage == nil {
+			firstPage := 1
+			resolved.FirstPage = &firstPage
+		}
+	case PaginationTypeCursor:
+		if strings.TrimSpace(resolved.CursorParam) == "" {
+			resolved.CursorParam = "cursor"
+		}
+	}
+	if resolved.MaxPages <= 0 {
+		resolved.MaxPages = DefaultPaginationMaxPages
+	}
+	return resolved
+}
+
+func ValidatePaginationSpec(operation Operation, spec *PaginationSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	label := fmt.Sprintf("operation %q pagination", operation)
+	if operation != OperationList {
+		return faults.Invalid(label+" is only supported on the list operation", nil)
+	}
+
+	paginationType := strings.ToLower(strings.TrimSpace(spec.Type))
+	switch paginationType {
+	case PaginationTypeOffset, PaginationTypePage, PaginationTypeLink:
+	case PaginationTypeCursor:
+		if strings.TrimSpace(spec.CursorPointer) == "" {
+			return faults.Invalid(label+".cursorPointer is required for cursor pagination", nil)
+		}
+		if _, err := resource.ParseJSONPointer(spec.CursorPointer); err != nil {
+			return faults.Invalid(label+".cursorPointer must be a valid JSON pointer", err)
+		}
+	case "":
+		return faults.Invalid(label+".type is required", nil)
+	default:
+		return faults.Invalid(
+			fmt.Sprintf("%s.type %q is not supported (expected one of %s)", label, spec.Type, strings.Join(paginationTypes, ", ")),
+			nil,
+		)
+	}
+
+	if spec.Limit < 0 {
+		return faults.Invalid(label+".limit must not be negative", nil)
+	}
+	if spec.MaxPages < 0 {
+		return faults.Invalid(label+".maxPages must not be negative", nil)
+	}
+	if paginationType == PaginationTypeOffset && spec.Limit == 0 {
+		return faults.Invalid(label+".limit is required for offset pagination", nil)
+	}
+	return nil
+}
+
+func ClonePaginationSpec(value *PaginationSpec) *PaginationSpec {
+	if value == nil {
+		return nil
+	}
+
+	cloned := *value
+	if value.FirstPage != nil {
+		firstPage := *value.FirstPage
+		cloned.FirstPage = &firstPage
+	}
+	return &cloned
+}
+
+func mergePaginationSpec(base *PaginationSpec, overlay *PaginationSpec) *PaginationSpec {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	if overlay == nil {
+		return ClonePaginationSpec(base)
+	}
+
+	merged := ClonePaginationSpec(base)
+	if merged == nil {
+		merged = &PaginationSpec{}
+	}
+
+	if overlay.Type != "" {
+		merged.Type = overlay.Type
+	}
+	if overlay.LimitParam != "" {
+		merged.LimitParam = overlay.LimitParam
+	}
+	if overlay.Limit != 0 {
+		merged.Limit = overlay.Limit
+	}
+	if overlay.OffsetParam != "" {
+		merged.OffsetParam = overlay.OffsetParam
+	}
+	if overlay.PageParam != "" {
+		merged.PageParam = overlay.PageParam
+	}
+	if overlay.FirstPage != nil {
+		firstPage := *overlay.FirstPage
+		merged.FirstPage = &firstPage
+	}
+	if overlay.CursorParam != "" {
+		merged.CursorParam = overlay.CursorParam
+	}
+	if overlay.CursorPointer != "" {
+		merged.CursorPointer = overlay.CursorPointer
+	}
+	if overlay.MaxPages != 0 {
+		merged.MaxPages = overlay.MaxPages
+	}
+	return merged
+}
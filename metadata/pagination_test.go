@@ -0,0 +1,111 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaginationSpecRoundTripsThroughWire(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DecodeResourceMetadataYAML([]byte(`
+operations:
+  list:
+    pagination:
+      type: cursor
+      cursorParam: after
+      cursorPointer: /meta/next
+      maxPages: 50
+`))
+	if err != nil {
+		t.Fatalf("DecodeResourceMetadataYAML returned error: %v", err)
+	}
+
+	pagination := decoded.Operations[string(OperationList)].Pagination
+	if pagination == nil || pagination.Type != PaginationTypeCursor || pagination.CursorPointer != "/meta/next" || pagination.MaxPages != 50 {
+		t.Fatalf("unexpected decoded pagination %#v", pagination)
+	}
+
+	encoded, err := EncodeResourceMetadataYAML(decoded)
+	if err != nil {
+		t.Fatalf("EncodeResourceMetadataYAML returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), "cursorPointer: /meta/next") {
+		t.Fatalf("expected pagination in encoded metadata, got:\n%s", encoded)
+	}
+
+	merged := MergeOperationSpec(
+		OperationSpec{Pagination: &PaginationSpec{Type: PaginationTypePage, Limit: 100}},
+		OperationSpec{Pagination: &PaginationSpec{PageParam: "p"}},
+	)
+	if merged.Pagination.Type != PaginationTypePage || merged.Pagination.Limit != 100 || merged.Pagination.PageParam != "p" {
+		t.Fatalf("expected field-wise pagination merge, got %#v", merged.Pagination)
+	}
+}
+
+func TestResolvePaginationSpecDefaults(t *testing.T) {
+	t.Parallel()
+
+	resolved := ResolvePaginationSpec(&PaginationSpec{Type: "Page"})
+	if resolved.Type != PaginationTypePage ||
+		resolved.PageParam != "page" ||
+		resolved.LimitParam != "per_page" ||
+		resolved.FirstPage == nil || *resolved.FirstPage != 1 ||
+		resolved.MaxPages != DefaultPaginationMaxPages {
+		t.Fatalf("unexpected resolved page pagination %#v", resolved)
+	}
+
+	if ResolvePaginationSpec(nil) != nil {
+		t.Fatal("expected nil pagination to stay nil")
+	}
+}
+
+func TestValidatePaginationSpec(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		operation Operation
+		spec      *PaginationSpec
+		wantErr   string
+	}{
+		{name: "nil", operation: OperationList},
+		{name: "link", operation: OperationList, spec: &PaginationSpec{Type: PaginationTypeLink}},
+		{name: "non_list", operation: OperationGet, spec: &PaginationSpec{Type: PaginationTypeLink}, wantErr: "only supported on the list operation"},
+		{name: "missing_type", operation: OperationList, spec: &PaginationSpec{}, wantErr: "type is required"},
+		{name: "unknown_type", operation: OperationList, spec: &PaginationSpec{Type: "token"}, wantErr: "not supported"},
+		{name: "cursor_without_pointer", operation: OperationList, spec: &PaginationSpec{Type: PaginationTypeCursor}, wantErr: "cursorPointer is required"},
+		{name: "offset_without_limit", operation: OperationList, spec: &PaginationSpec{Type: PaginationTypeOffset}, wantErr: "limit is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePaginationSpec(tc.operation, tc.spec)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
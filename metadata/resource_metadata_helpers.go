@@ -32,6 +32,11 @@ func HasResourceMetadataDirectives(value ResourceMetadata) bool {
 		value.Secret != nil ||
 		value.SecretAttributes != nil ||
 		value.ExternalizedAttributes != nil ||
+		value.ArrayAttributes != nil ||
+		strings.TrimSpace(value.VersionAttribute) != "" ||
+		value.ConditionalDelete != nil ||
+		value.DependsOn != nil ||
+		value.Prune != nil ||
 		value.Operations != nil ||
 		value.Transforms != nil
 }
@@ -48,6 +53,11 @@ func CloneResourceMetadata(value ResourceMetadata) ResourceMetadata {
 		Secret:                 cloneBoolPointer(value.Secret),
 		SecretAttributes:       cloneStringSlice(value.SecretAttributes),
 		ExternalizedAttributes: cloneExternalizedAttributes(value.ExternalizedAttributes),
+		ArrayAttributes:        cloneArrayAttributes(value.ArrayAttributes),
+		VersionAttribute:       value.VersionAttribute,
+		ConditionalDelete:      cloneBoolPointer(value.ConditionalDelete),
+		DependsOn:              cloneStringSlice(value.DependsOn),
+		Prune:                  cloneBoolPointer(value.Prune),
 		Operations:             make(map[string]OperationSpec, len(value.Operations)),
 		Transforms:             CloneTransformSteps(value.Transforms),
 	}
@@ -62,6 +72,11 @@ func CloneResourceMetadata(value ResourceMetadata) ResourceMetadata {
 			ContentType: operationSpec.ContentType,
 			Body:        resource.DeepCopyValue(operationSpec.Body),
 			Transforms:  CloneTransformSteps(operationSpec.Transforms),
+			Pagination:  ClonePaginationSpec(operationSpec.Pagination),
+			Idempotent:  cloneBoolPointer(operationSpec.Idempotent),
+			Async:       CloneAsyncSpec(operationSpec.Async),
+			GraphQL:     CloneGraphQLSpec(operationSpec.GraphQL),
+			Strategy:    operationSpec.Strategy,
 			Validate:    cloneOperationValidationSpec(operationSpec.Validate),
 		}
 	}
@@ -81,6 +96,11 @@ func MergeResourceMetadata(base ResourceMetadata, overlay ResourceMetadata) Reso
 		Secret:                 cloneBoolPointer(base.Secret),
 		SecretAttributes:       cloneStringSlice(base.SecretAttributes),
 		ExternalizedAttributes: cloneExternalizedAttributes(base.ExternalizedAttributes),
+		ArrayAttributes:        cloneArrayAttributes(base.ArrayAttributes),
+		VersionAttribute:       base.VersionAttribute,
+		ConditionalDelete:      cloneBoolPointer(base.ConditionalDelete),
+		DependsOn:              cloneStringSlice(base.DependsOn),
+		Prune:                  cloneBoolPointer(base.Prune),
 		Operations:             cloneOperationMap(base.Operations),
 		Transforms:             CloneTransformSteps(base.Transforms),
 	}
@@ -110,6 +130,21 @@ func MergeResourceMetadata(base ResourceMetadata, overlay ResourceMetadata) Reso
 	if overlay.ExternalizedAttributes != nil {
 		merged.ExternalizedAttributes = cloneExternalizedAttributes(overlay.ExternalizedAttributes)
 	}
+	if overlay.ArrayAttributes != nil {
+		merged.ArrayAttributes = cloneArrayAttributes(overlay.ArrayAttributes)
+	}
+	if overlay.VersionAttribute != "" {
+		merged.VersionAttribute = overlay.VersionAttribute
+	}
+	if overlay.DependsOn != nil {
+		merged.DependsOn = cloneStringSlice(overlay.DependsOn)
+	}
+	if overlay.ConditionalDelete != nil {
+		merged.ConditionalDelete = cloneBoolPointer(overlay.ConditionalDelete)
+	}
+	if overlay.Prune != nil {
+		merged.Prune = cloneBoolPointer(overlay.Prune)
+	}
 	if overlay.Operations != nil {
 		if merged.Operations == nil {
 			merged.Operations = map[string]OperationSpec{}
@@ -156,6 +191,23 @@ func cloneExternalizedAttributes(values []ExternalizedAttribute) []ExternalizedA
 	return cloned
 }
 
+func cloneArrayAttributes(values []ArrayAttribute) []ArrayAttribute {
+	if values == nil {
+		return nil
+	}
+
+	cloned := make([]ArrayAttribute, len(values))
+	for idx := range values {
+		cloned[idx] = ArrayAttribute{
+			Path:      values[idx].Path,
+			SortBy:    cloneStringSlice(values[idx].SortBy),
+			MergeKeys: cloneStringSlice(values[idx].MergeKeys),
+			Unordered: values[idx].Unordered,
+		}
+	}
+	return cloned
+}
+
 func MergeOperationSpec(base OperationSpec, overlay OperationSpec) OperationSpec {
 	merged := OperationSpec{
 		Method:      base.Method,
@@ -166,6 +218,11 @@ func MergeOperationSpec(base OperationSpec, overlay OperationSpec) OperationSpec
 		ContentType: base.ContentType,
 		Body:        resource.DeepCopyValue(base.Body),
 		Transforms:  CloneTransformSteps(base.Transforms),
+		Pagination:  ClonePaginationSpec(base.Pagination),
+		Idempotent:  cloneBoolPointer(base.Idempotent),
+		Async:       CloneAsyncSpec(base.Async),
+		GraphQL:     CloneGraphQLSpec(base.GraphQL),
+		Strategy:    base.Strategy,
 		Validate:    cloneOperationValidationSpec(base.Validate),
 	}
 
@@ -213,6 +270,15 @@ func MergeOperationSpec(base OperationSpec, overlay OperationSpec) OperationSpec
 	if overlay.Transforms != nil {
 		merged.Transforms = CloneTransformSteps(overlay.Transforms)
 	}
+	merged.Pagination = mergePaginationSpec(merged.Pagination, overlay.Pagination)
+	if overlay.Idempotent != nil {
+		merged.Idempotent = cloneBoolPointer(overlay.Idempotent)
+	}
+	merged.Async = mergeAsyncSpec(merged.Async, overlay.Async)
+	merged.GraphQL = mergeGraphQLSpec(merged.GraphQL, overlay.GraphQL)
+	if strings.TrimSpace(overlay.Strategy) != "" {
+		merged.Strategy = overlay.Strategy
+	}
 	merged.Validate = mergeOperationValidationSpec(merged.Validate, overlay.Validate)
 
 	return merged
@@ -234,6 +300,11 @@ func cloneOperationMap(values map[string]OperationSpec) map[string]OperationSpec
 			ContentType: value.ContentType,
 			Body:        resource.DeepCopyValue(value.Body),
 			Transforms:  CloneTransformSteps(value.Transforms),
+			Pagination:  ClonePaginationSpec(value.Pagination),
+			Idempotent:  cloneBoolPointer(value.Idempotent),
+			Async:       CloneAsyncSpec(value.Async),
+			GraphQL:     CloneGraphQLSpec(value.GraphQL),
+			Strategy:    value.Strategy,
 			Validate:    cloneOperationValidationSpec(value.Validate),
 		}
 	}
@@ -51,6 +51,11 @@ type resourceWire struct {
 	Secret                 *bool                        `json:"secret,omitempty" yaml:"secret,omitempty"`
 	SecretAttributes       *[]string                    `json:"secretAttributes,omitempty" yaml:"secretAttributes,omitempty"`
 	ExternalizedAttributes *[]externalizedAttributeWire `json:"externalizedAttributes,omitempty" yaml:"externalizedAttributes,omitempty"`
+	ArrayAttributes        *[]arrayAttributeWire        `json:"arrayAttributes,omitempty" yaml:"arrayAttributes,omitempty"`
+	VersionAttribute       string                       `json:"versionAttribute,omitempty" yaml:"versionAttribute,omitempty"`
+	ConditionalDelete      *bool                        `json:"conditionalDelete,omitempty" yaml:"conditionalDelete,omitempty"`
+	DependsOn              *[]string                    `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Prune                  *bool                        `json:"prune,omitempty" yaml:"prune,omitempty"`
 }
 
 type defaultsSpecWire struct {
@@ -60,6 +65,13 @@ type defaultsSpecWire struct {
 	Profiles    *map[string]any `json:"profiles,omitempty" yaml:"profiles,omitempty"`
 }
 
+type arrayAttributeWire struct {
+	Path      string         `json:"path,omitempty" yaml:"path,omitempty"`
+	SortBy    stringListWire `json:"sortBy,omitempty" yaml:"sortBy,omitempty"`
+	MergeKeys stringListWire `json:"mergeKeys,omitempty" yaml:"mergeKeys,omitempty"`
+	Unordered bool           `json:"unordered,omitempty" yaml:"unordered,omitempty"`
+}
+
 type externalizedAttributeWire struct {
 	Path           string `json:"path,omitempty" yaml:"path,omitempty"`
 	File           string `json:"file,omitempty" yaml:"file,omitempty"`
@@ -101,6 +113,35 @@ type operationValidationWire struct {
 	SchemaRef          string                     `json:"schemaRef,omitempty" yaml:"schemaRef,omitempty"`
 }
 
+type paginationWire struct {
+	Type          string `json:"type,omitempty" yaml:"type,omitempty"`
+	LimitParam    string `json:"limitParam,omitempty" yaml:"limitParam,omitempty"`
+	Limit         int    `json:"limit,omitempty" yaml:"limit,omitempty"`
+	OffsetParam   string `json:"offsetParam,omitempty" yaml:"offsetParam,omitempty"`
+	PageParam     string `json:"pageParam,omitempty" yaml:"pageParam,omitempty"`
+	FirstPage     *int   `json:"firstPage,omitempty" yaml:"firstPage,omitempty"`
+	CursorParam   string `json:"cursorParam,omitempty" yaml:"cursorParam,omitempty"`
+	CursorPointer string `json:"cursorPointer,omitempty" yaml:"cursorPointer,omitempty"`
+	MaxPages      int    `json:"maxPages,omitempty" yaml:"maxPages,omitempty"`
+}
+
+type asyncWire struct {
+	StatusHeader  string `json:"statusHeader,omitempty" yaml:"statusHeader,omitempty"`
+	StatusPointer string `json:"statusPointer,omitempty" yaml:"statusPointer,omitempty"`
+	CompletedWhen string `json:"completedWhen,omitempty" yaml:"completedWhen,omitempty"`
+	FailedWhen    string `json:"failedWhen,omitempty" yaml:"failedWhen,omitempty"`
+	PollInterval  string `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"`
+	Timeout       string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+type graphQLWire struct {
+	Query           string         `json:"query,omitempty" yaml:"query,omitempty"`
+	OperationName   string         `json:"operationName,omitempty" yaml:"operationName,omitempty"`
+	Variables       map[string]any `json:"variables,omitempty" yaml:"variables,omitempty"`
+	PayloadVariable string         `json:"payloadVariable,omitempty" yaml:"payloadVariable,omitempty"`
+	ResultPointer   string         `json:"resultPointer,omitempty" yaml:"resultPointer,omitempty"`
+}
+
 type headerMapWire map[string]string
 
 type resourceOperationWire struct {
@@ -110,6 +151,11 @@ type resourceOperationWire struct {
 	Headers    *headerMapWire           `json:"headers,omitempty" yaml:"headers,omitempty"`
 	Body       any                      `json:"body,omitempty" yaml:"body,omitempty"`
 	Transforms *[]transformStepWire     `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	Pagination *paginationWire          `json:"pagination,omitempty" yaml:"pagination,omitempty"`
+	Idempotent *bool                    `json:"idempotent,omitempty" yaml:"idempotent,omitempty"`
+	Async      *asyncWire               `json:"async,omitempty" yaml:"async,omitempty"`
+	GraphQL    *graphQLWire             `json:"graphql,omitempty" yaml:"graphql,omitempty"`
+	Strategy   string                   `json:"strategy,omitempty" yaml:"strategy,omitempty"`
 	Validate   *operationValidationWire `json:"validate,omitempty" yaml:"validate,omitempty"`
 }
 
@@ -230,6 +276,9 @@ func resourceMetadataToWire(metadata ResourceMetadata) resourceMetadataWire {
 		Format:               metadata.Format,
 		Defaults:             defaultsSpecToWire(metadata.Defaults),
 		Secret:               cloneBoolPointer(metadata.Secret),
+		VersionAttribute:     metadata.VersionAttribute,
+		ConditionalDelete:    cloneBoolPointer(metadata.ConditionalDelete),
+		Prune:                cloneBoolPointer(metadata.Prune),
 	}
 	if metadata.RequiredAttributes != nil {
 		resource.RequiredAttributes = stringSlicePointer(metadata.RequiredAttributes)
@@ -240,6 +289,12 @@ func resourceMetadataToWire(metadata ResourceMetadata) resourceMetadataWire {
 	if metadata.ExternalizedAttributes != nil {
 		resource.ExternalizedAttributes = externalizedAttributeWirePointer(metadata.ExternalizedAttributes)
 	}
+	if metadata.ArrayAttributes != nil {
+		resource.ArrayAttributes = arrayAttributeWirePointer(metadata.ArrayAttributes)
+	}
+	if metadata.DependsOn != nil {
+		resource.DependsOn = stringSlicePointer(metadata.DependsOn)
+	}
 
 	if hasResourceInfo(resource) {
 		wire.Resource = &resource
@@ -312,9 +367,24 @@ func resourceMetadataFromWire(wire resourceMetadataWire) (ResourceMetadata, erro
 		if resource.SecretAttributes != nil {
 			metadata.SecretAttributes = cloneStringSlice(*resource.SecretAttributes)
 		}
+		if versionAttribute := strings.TrimSpace(resource.VersionAttribute); versionAttribute != "" {
+			metadata.VersionAttribute = versionAttribute
+		}
+		if resource.DependsOn != nil {
+			metadata.DependsOn = cloneStringSlice(*resource.DependsOn)
+		}
+		if resource.ConditionalDelete != nil {
+			metadata.ConditionalDelete = cloneBoolPointer(resource.ConditionalDelete)
+		}
+		if resource.Prune != nil {
+			metadata.Prune = cloneBoolPointer(resource.Prune)
+		}
 		if resource.ExternalizedAttributes != nil {
 			metadata.ExternalizedAttributes = externalizedAttributesFromWire(*resource.ExternalizedAttributes)
 		}
+		if resource.ArrayAttributes != nil {
+			metadata.ArrayAttributes = arrayAttributesFromWire(*resource.ArrayAttributes)
+		}
 	}
 
 	if wire.Operations != nil {
@@ -379,7 +449,12 @@ func hasResourceInfo(resource resourceWire) bool {
 		resource.Defaults != nil ||
 		resource.Secret != nil ||
 		resource.SecretAttributes != nil ||
-		resource.ExternalizedAttributes != nil
+		resource.ExternalizedAttributes != nil ||
+		resource.ArrayAttributes != nil ||
+		strings.TrimSpace(resource.VersionAttribute) != "" ||
+		resource.ConditionalDelete != nil ||
+		resource.DependsOn != nil ||
+		resource.Prune != nil
 }
 
 func defaultsSpecToWire(value *DefaultsSpec) *defaultsSpecWire {
@@ -490,10 +565,15 @@ func operationsToMap(info *operationsWire) map[string]OperationSpec {
 
 func operationSpecToWire(_ Operation, spec OperationSpec) *resourceOperationWire {
 	wire := &resourceOperationWire{
-		Method:   spec.Method,
-		Path:     spec.Path,
-		Body:     spec.Body,
-		Validate: operationValidationToWire(spec.Validate),
+		Method:     spec.Method,
+		Path:       spec.Path,
+		Body:       spec.Body,
+		Pagination: paginationToWire(spec.Pagination),
+		Idempotent: cloneBoolPointer(spec.Idempotent),
+		Async:      asyncToWire(spec.Async),
+		GraphQL:    graphQLToWire(spec.GraphQL),
+		Strategy:   spec.Strategy,
+		Validate:   operationValidationToWire(spec.Validate),
 	}
 
 	if spec.Query != nil {
@@ -527,11 +607,112 @@ func operationSpecFromWire(_ Operation, spec resourceOperationWire) OperationSpe
 	if spec.Transforms != nil {
 		decoded.Transforms = transformStepsFromWire(*spec.Transforms)
 	}
+	decoded.Pagination = paginationFromWire(spec.Pagination)
+	decoded.Idempotent = cloneBoolPointer(spec.Idempotent)
+	decoded.Async = asyncFromWire(spec.Async)
+	decoded.GraphQL = graphQLFromWire(spec.GraphQL)
+	decoded.Strategy = strings.TrimSpace(spec.Strategy)
 	decoded.Validate = operationValidationFromWire(spec.Validate)
 
 	return decoded
 }
 
+func paginationToWire(value *PaginationSpec) *paginationWire {
+	if value == nil {
+		return nil
+	}
+
+	cloned := ClonePaginationSpec(value)
+	return &paginationWire{
+		Type:          cloned.Type,
+		LimitParam:    cloned.LimitParam,
+		Limit:         cloned.Limit,
+		OffsetParam:   cloned.OffsetParam,
+		PageParam:     cloned.PageParam,
+		FirstPage:     cloned.FirstPage,
+		CursorParam:   cloned.CursorParam,
+		CursorPointer: cloned.CursorPointer,
+		MaxPages:      cloned.MaxPages,
+	}
+}
+
+func paginationFromWire(value *paginationWire) *PaginationSpec {
+	if value == nil {
+		return nil
+	}
+
+	return ClonePaginationSpec(&PaginationSpec{
+		Type:          strings.TrimSpace(value.Type),
+		LimitParam:    value.LimitParam,
+		Limit:         value.Limit,
+		OffsetParam:   value.OffsetParam,
+		PageParam:     value.PageParam,
+		FirstPage:     value.FirstPage,
+		CursorParam:   value.CursorParam,
+		CursorPointer: value.CursorPointer,
+		MaxPages:      value.MaxPages,
+	})
+}
+
+func asyncToWire(value *AsyncSpec) *asyncWire {
+	if value == nil {
+		return nil
+	}
+
+	return &asyncWire{
+		StatusHeader:  value.StatusHeader,
+		StatusPointer: value.StatusPointer,
+		CompletedWhen: value.CompletedWhen,
+		FailedWhen:    value.FailedWhen,
+		PollInterval:  value.PollInterval,
+		Timeout:       value.Timeout,
+	}
+}
+
+func asyncFromWire(value *asyncWire) *AsyncSpec {
+	if value == nil {
+		return nil
+	}
+
+	return &AsyncSpec{
+		StatusHeader:  strings.TrimSpace(value.StatusHeader),
+		StatusPointer: strings.TrimSpace(value.StatusPointer),
+		CompletedWhen: value.CompletedWhen,
+		FailedWhen:    value.FailedWhen,
+		PollInterval:  strings.TrimSpace(value.PollInterval),
+		Timeout:       strings.TrimSpace(value.Timeout),
+	}
+}
+
+func graphQLToWire(value *GraphQLSpec) *graphQLWire {
+	if value == nil {
+		return nil
+	}
+
+	cloned := CloneGraphQLSpec(value)
+	return &graphQLWire{
+		Query:           cloned.Query,
+		OperationName:   cloned.OperationName,
+		Variables:       cloned.Variables,
+		PayloadVariable: cloned.PayloadVariable,
+		ResultPointer:   cloned.ResultPointer,
+	}
+}
+
+func graphQLFromWire(value *graphQLWire) *GraphQLSpec {
+	if value == nil {
+		return nil
+	}
+
+	return &GraphQLSpec{
+		Query:           value.Query,
+		OperationName:   strings.TrimSpace(value.OperationName),
+		Variables:       cloneGraphQLVariables(value.Variables),
+		PayloadVariable: strings.TrimSpace(value.PayloadVariable),
+		ResultPointer:   strings.TrimSpace(value.ResultPointer),
+	}
+}
+
 func operationValidationToWire(value *OperationValidationSpec) *operationValidationWire {
 	if value == nil {
 		return nil
@@ -757,3 +938,33 @@ func externalizedAttributesFromWire(values []externalizedAttributeWire) []Extern
 	}
 	return cloned
 }
+
+func arrayAttributeWirePointer(values []ArrayAttribute) *[]arrayAttributeWire {
+	if values == nil {
+		return nil
+	}
+
+	cloned := make([]arrayAttributeWire, len(values))
+	for idx := range values {
+		cloned[idx] = arrayAttributeWire{
+			Path:      values[idx].Path,
+			SortBy:    stringListWire(cloneStringSlice(values[idx].SortBy)),
+			MergeKeys: stringListWire(cloneStringSlice(values[idx].MergeKeys)),
+			Unordered: values[idx].Unordered,
+		}
+	}
+	return &cloned
+}
+
+func arrayAttributesFromWire(values []arrayAttributeWire) []ArrayAttribute {
+	cloned := make([]ArrayAttribute, len(values))
+	for idx := range values {
+		cloned[idx] = ArrayAttribute{
+			Path:      values[idx].Path,
+			SortBy:    cloneStringSlice(values[idx].SortBy),
+			MergeKeys: cloneStringSlice(values[idx].MergeKeys),
+			Unordered: values[idx].Unordered,
+		}
+	}
+	return cloned
+}
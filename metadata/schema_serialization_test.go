@@ -17,6 +17,7 @@ package metadata
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
 	"go.yaml.in/yaml/v3"
@@ -735,6 +736,26 @@ func TestResourceMetadataFormatJSONRoundtrip(t *testing.T) {
 	}
 }
 
+func TestResourceMetadataVersionAttributeJSONRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := json.Marshal(ResourceMetadata{VersionAttribute: "/meta/version"})
+	if err != nil {
+		t.Fatalf("marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"versionAttribute":"/meta/version"`) {
+		t.Fatalf("expected nested versionAttribute, got %s", encoded)
+	}
+
+	var decoded ResourceMetadata
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if decoded.VersionAttribute != "/meta/version" {
+		t.Fatalf("expected versionAttribute=/meta/version, got %q", decoded.VersionAttribute)
+	}
+}
+
 func TestResourceMetadataFormatOmittedWhenEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -774,3 +795,74 @@ func TestResourceMetadataLegacyFormatFieldsAreRejected(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceMetadataDependsOnJSONRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := json.Marshal(ResourceMetadata{DependsOn: []string{"/realms/{{/realm}}"}})
+	if err != nil {
+		t.Fatalf("marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"dependsOn":["/realms/{{/realm}}"]`) {
+		t.Fatalf("expected nested dependsOn, got %s", encoded)
+	}
+
+	var decoded ResourceMetadata
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.DependsOn, []string{"/realms/{{/realm}}"}) {
+		t.Fatalf("expected dependsOn to roundtrip, got %#v", decoded.DependsOn)
+	}
+}
+
+func TestResourceMetadataPruneJSONRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	disabled := false
+	encoded, err := json.Marshal(ResourceMetadata{Prune: &disabled})
+	if err != nil {
+		t.Fatalf("marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"prune":false`) {
+		t.Fatalf("expected nested prune, got %s", encoded)
+	}
+
+	var decoded ResourceMetadata
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if decoded.Prune == nil || *decoded.Prune || decoded.AllowsPrune() {
+		t.Fatalf("expected prune=false to roundtrip, got %#v", decoded.Prune)
+	}
+	if !(ResourceMetadata{}).AllowsPrune() {
+		t.Fatal("expected pruning to be allowed by default")
+	}
+}
+
+func TestResourceMetadataConditionalDeleteJSONRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	encoded, err := json.Marshal(ResourceMetadata{ConditionalDelete: &enabled})
+	if err != nil {
+		t.Fatalf("marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"conditionalDelete":true`) {
+		t.Fatalf("expected nested conditionalDelete, got %s", encoded)
+	}
+
+	var decoded ResourceMetadata
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if decoded.ConditionalDelete == nil || !*decoded.ConditionalDelete || !decoded.ReadsVersionBeforeDelete() {
+		t.Fatalf("expected conditionalDelete=true to roundtrip, got %#v", decoded.ConditionalDelete)
+	}
+	if (ResourceMetadata{}).ReadsVersionBeforeDelete() {
+		t.Fatal("expected deletes not to read the remote by default")
+	}
+	if !(ResourceMetadata{VersionAttribute: "/etag"}).ReadsVersionBeforeDelete() {
+		t.Fatal("expected versionAttribute to imply a read before delete")
+	}
+}
@@ -14,6 +14,8 @@
 
 package metadata
 
+import "strings"
+
 type Operation string
 
 const (
@@ -49,6 +51,11 @@ type ResourceMetadata struct {
 	Secret                 *bool                    `json:"secret,omitempty" yaml:"secret,omitempty"`
 	SecretAttributes       []string                 `json:"secretAttributes,omitempty" yaml:"secretAttributes,omitempty"`
 	ExternalizedAttributes []ExternalizedAttribute  `json:"externalizedAttributes,omitempty" yaml:"externalizedAttributes,omitempty"`
+	ArrayAttributes        []ArrayAttribute         `json:"arrayAttributes,omitempty" yaml:"arrayAttributes,omitempty"`
+	VersionAttribute       string                   `json:"versionAttribute,omitempty" yaml:"versionAttribute,omitempty"`
+	ConditionalDelete      *bool                    `json:"conditionalDelete,omitempty" yaml:"conditionalDelete,omitempty"`
+	DependsOn              []string                 `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Prune                  *bool                    `json:"prune,omitempty" yaml:"prune,omitempty"`
 	Operations             map[string]OperationSpec `json:"operations,omitempty" yaml:"operations,omitempty"`
 	Transforms             []TransformStep          `json:"transforms,omitempty" yaml:"transforms,omitempty"`
 }
@@ -57,6 +64,20 @@ func (m ResourceMetadata) IsWholeResourceSecret() bool {
 	return m.Secret != nil && *m.Secret
 }
 
+// ReadsVersionBeforeDelete reports whether deletes read the remote first so
+// they carry its version as a precondition: when resource.versionAttribute is
+// declared or resource.conditionalDelete is true.
+func (m ResourceMetadata) ReadsVersionBeforeDelete() bool {
+	return strings.TrimSpace(m.VersionAttribute) != "" || (m.ConditionalDelete != nil && *m.ConditionalDelete)
+}
+
+// AllowsPrune reports whether remote resources at this path may be deleted
+// when they are missing from the repository. Pruning is allowed unless
+// resource.prune is explicitly false.
+func (m ResourceMetadata) AllowsPrune() bool {
+	return m.Prune == nil || *m.Prune
+}
+
 type ExternalizedAttribute struct {
 	Path           string `json:"path,omitempty" yaml:"path,omitempty"`
 	File           string `json:"file,omitempty" yaml:"file,omitempty"`
@@ -67,6 +88,18 @@ type ExternalizedAttribute struct {
 	Enabled        *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 }
 
+// ArrayAttribute declares how the array at Path is ordered when the resource
+// is saved to the repository and how its elements are matched when local and
+// remote payloads are compared. SortBy and MergeKeys list JSON pointers
+// evaluated against each element; an empty pointer selects the element
+// itself. Unordered arrays ignore element order when compared.
+type ArrayAttribute struct {
+	Path      string   `json:"path,omitempty" yaml:"path,omitempty"`
+	SortBy    []string `json:"sortBy,omitempty" yaml:"sortBy,omitempty"`
+	MergeKeys []string `json:"mergeKeys,omitempty" yaml:"mergeKeys,omitempty"`
+	Unordered bool     `json:"unordered,omitempty" yaml:"unordered,omitempty"`
+}
+
 type ResolvedExternalizedAttribute struct {
 	Path           string
 	File           string
@@ -86,6 +119,11 @@ type OperationSpec struct {
 	ContentType string            `json:"contentType,omitempty" yaml:"contentType,omitempty"`
 	Body        any               `json:"body,omitempty" yaml:"body,omitempty"`
 	Transforms  []TransformStep   `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	Pagination  *PaginationSpec   `json:"pagination,omitempty" yaml:"pagination,omitempty"`
+	Idempotent  *bool             `json:"idempotent,omitempty" yaml:"idempotent,omitempty"`
+	Async       *AsyncSpec        `json:"async,omitempty" yaml:"async,omitempty"`
+	GraphQL     *GraphQLSpec      `json:"graphql,omitempty" yaml:"graphql,omitempty"`
+	Strategy    string            `json:"strategy,omitempty" yaml:"strategy,omitempty"`
 	Validate    *OperationValidationSpec
 }
 
@@ -95,6 +133,43 @@ type TransformStep struct {
 	JQExpression      string   `json:"jqExpression,omitempty" yaml:"jqExpression,omitempty"`
 }
 
+// PaginationSpec describes how a list operation walks a paged remote
+// collection. Only the fields relevant to Type are consulted.
+type PaginationSpec struct {
+	Type          string `json:"type,omitempty" yaml:"type,omitempty"`
+	LimitParam    string `json:"limitParam,omitempty" yaml:"limitParam,omitempty"`
+	Limit         int    `json:"limit,omitempty" yaml:"limit,omitempty"`
+	OffsetParam   string `json:"offsetParam,omitempty" yaml:"offsetParam,omitempty"`
+	PageParam     string `json:"pageParam,omitempty" yaml:"pageParam,omitempty"`
+	FirstPage     *int   `json:"firstPage,omitempty" yaml:"firstPage,omitempty"`
+	CursorParam   string `json:"cursorParam,omitempty" yaml:"cursorParam,omitempty"`
+	CursorPointer string `json:"cursorPointer,omitempty" yaml:"cursorPointer,omitempty"`
+	MaxPages      int    `json:"maxPages,omitempty" yaml:"maxPages,omitempty"`
+}
+
+// AsyncSpec describes how to follow a mutation the remote answered with
+// 202 Accepted until the underlying operation reaches a terminal state.
+type AsyncSpec struct {
+	StatusHeader  string `json:"statusHeader,omitempty" yaml:"statusHeader,omitempty"`
+	StatusPointer string `json:"statusPointer,omitempty" yaml:"statusPointer,omitempty"`
+	CompletedWhen string `json:"completedWhen,omitempty" yaml:"completedWhen,omitempty"`
+	FailedWhen    string `json:"failedWhen,omitempty" yaml:"failedWhen,omitempty"`
+	PollInterval  string `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"`
+	Timeout       string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// GraphQLSpec maps an operation onto a GraphQL query or mutation for managed
+// services configured with managedService.graphql. String variables are
+// metadata templates; payloadVariable receives the transformed payload and
+// resultPointer selects the resource (or list items) inside "data".
+type GraphQLSpec struct {
+	Query           string         `json:"query,omitempty" yaml:"query,omitempty"`
+	OperationName   string         `json:"operationName,omitempty" yaml:"operationName,omitempty"`
+	Variables       map[string]any `json:"variables,omitempty" yaml:"variables,omitempty"`
+	PayloadVariable string         `json:"payloadVariable,omitempty" yaml:"payloadVariable,omitempty"`
+	ResultPointer   string         `json:"resultPointer,omitempty" yaml:"resultPointer,omitempty"`
+}
+
 type OperationValidationSpec struct {
 	RequiredAttributes []string              `json:"requiredAttributes,omitempty" yaml:"requiredAttributes,omitempty"`
 	Assertions         []ValidationAssertion `json:"assertions,omitempty" yaml:"assertions,omitempty"`
@@ -0,0 +1,106 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+const (
+	UpdateStrategyReplace    = "replace"
+	UpdateStrategyMergePatch = "merge-patch"
+	UpdateStrategyJSONPatch  = "json-patch"
+
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+// NormalizeUpdateStrategy lowercases value and resolves an empty strategy to
+// replace, which sends the full desired payload.
+func NormalizeUpdateStrategy(value string) string {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "" {
+		return UpdateStrategyReplace
+	}
+	return normalized
+}
+
+// IsPatchUpdateStrategy reports whether value sends a computed delta instead
+// of the full desired payload.
+func IsPatchUpdateStrategy(value string) bool {
+	switch NormalizeUpdateStrategy(value) {
+	case UpdateStrategyMergePatch, UpdateStrategyJSONPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// UpdateStrategyMediaType returns the request media type of a patch strategy,
+// or an empty string for replace.
+func UpdateStrategyMediaType(value string) string {
+	switch NormalizeUpdateStrategy(value) {
+	case UpdateStrategyMergePatch:
+		return MergePatchMediaType
+	case UpdateStrategyJSONPatch:
+		return JSONPatchMediaType
+	default:
+		return ""
+	}
+}
+
+// ResolveUpdateStrategy returns the normalized operations.update.strategy of md.
+func ResolveUpdateStrategy(md ResourceMetadata) string {
+	return NormalizeUpdateStrategy(md.Operations[string(OperationUpdate)].Strategy)
+}
+
+// ApplyUpdateStrategyDefaults fills the PATCH method and the patch media type
+// on a rendered update spec whose strategy is a patch strategy. Values the
+// metadata sets explicitly are kept.
+func ApplyUpdateStrategyDefaults(spec *OperationSpec, explicitMethod bool, explicitContentType bool) {
+	if spec == nil || !IsPatchUpdateStrategy(spec.Strategy) {
+		return
+	}
+	if !explicitMethod {
+		spec.Method = http.MethodPatch
+	}
+	if !explicitContentType {
+		spec.ContentType = UpdateStrategyMediaType(spec.Strategy)
+	}
+}
+
+func ValidateUpdateStrategy(operation Operation, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	label := fmt.Sprintf("operation %q strategy", operation)
+	if operation != OperationUpdate {
+		return faults.Invalid(label+" is only supported on the update operation", nil)
+	}
+	switch NormalizeUpdateStrategy(value) {
+	case UpdateStrategyReplace, UpdateStrategyMergePatch, UpdateStrategyJSONPatch:
+		return nil
+	default:
+		return faults.Invalid(
+			fmt.Sprintf("%s must be one of %s, %s, %s", label, UpdateStrategyReplace, UpdateStrategyMergePatch, UpdateStrategyJSONPatch),
+			nil,
+		)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestValidateUpdateStrategy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		operation Operation
+		value     string
+		wantErr   bool
+	}{
+		{name: "empty", operation: OperationCreate, value: ""},
+		{name: "replace", operation: OperationUpdate, value: "replace"},
+		{name: "merge_patch", operation: OperationUpdate, value: "Merge-Patch"},
+		{name: "json_patch", operation: OperationUpdate, value: "json-patch"},
+		{name: "unknown", operation: OperationUpdate, value: "strategic-merge", wantErr: true},
+		{name: "not_update", operation: OperationCreate, value: "merge-patch", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateUpdateStrategy(tc.operation, tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestApplyUpdateStrategyDefaults(t *testing.T) {
+	t.Parallel()
+
+	spec := OperationSpec{Method: http.MethodPut, Strategy: UpdateStrategyJSONPatch}
+	ApplyUpdateStrategyDefaults(&spec, false, false)
+	if spec.Method != http.MethodPatch || spec.ContentType != JSONPatchMediaType {
+		t.Fatalf("expected PATCH with JSON patch media type, got %#v", spec)
+	}
+
+	explicit := OperationSpec{Method: http.MethodPost, ContentType: "application/json", Strategy: UpdateStrategyMergePatch}
+	ApplyUpdateStrategyDefaults(&explicit, true, true)
+	if explicit.Method != http.MethodPost || explicit.ContentType != "application/json" {
+		t.Fatalf("expected explicit method and content type to be kept, got %#v", explicit)
+	}
+
+	replace := OperationSpec{Method: http.MethodPut, Strategy: UpdateStrategyReplace}
+	ApplyUpdateStrategyDefaults(&replace, false, false)
+	if replace.Method != http.MethodPut || replace.ContentType != "" {
+		t.Fatalf("expected replace strategy to keep defaults, got %#v", replace)
+	}
+}
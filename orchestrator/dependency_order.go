@@ -0,0 +1,222 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"container/heap"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+	"github.com/crmarques/declarest/resource"
+)
+
+// DependencyOrder is an execution order for a set of mutation targets.
+// Targets is a valid sequential order; Levels groups indexes into Targets so
+// that every target only depends on targets from earlier levels, which lets
+// callers run each level concurrently.
+type DependencyOrder struct {
+	Targets []resource.Resource
+	Levels  [][]int
+}
+
+// DependencyResolver returns the logical paths target depends on. Paths that
+// are not part of the ordered target set are ignored.
+type DependencyResolver func(target resource.Resource) ([]string, error)
+
+// OrderByAncestry orders targets using only their path hierarchy: every
+// target follows its nearest ancestor present in targets.
+func OrderByAncestry(targets []resource.Resource) DependencyOrder {
+	order, _ := NewDependencyOrder(targets, nil)
+	return order
+}
+
+// NewDependencyOrder builds the dependency graph of targets from their
+// nearest ancestor target and the paths returned by resolve, then sorts it
+// topologically. Ties keep the input order, so targets without declared
+// dependencies keep their original sequence. A cycle is reported as a
+// validation error naming the paths involved.
+func NewDependencyOrder(targets []resource.Resource, resolve DependencyResolver) (DependencyOrder, error) {
+	indexByPath := make(map[string]int, len(targets))
+	for idx, target := range targets {
+		indexByPath[target.LogicalPath] = idx
+	}
+
+	dependencies := make([][]int, len(targets))
+	for idx, target := range targets {
+		seen := map[int]struct{}{}
+		addDependency := func(dependencyIdx int) {
+			if dependencyIdx == idx {
+				return
+			}
+			if _, exists := seen[dependencyIdx]; exists {
+				return
+			}
+			seen[dependencyIdx] = struct{}{}
+			dependencies[idx] = append(dependencies[idx], dependencyIdx)
+		}
+
+		if parentIdx, found := nearestAncestorIndex(indexByPath, target.LogicalPath); found {
+			addDependency(parentIdx)
+		}
+		if resolve == nil {
+			continue
+		}
+		dependencyPaths, err := resolve(target)
+		if err != nil {
+			return DependencyOrder{}, err
+		}
+		for _, dependencyPath := range dependencyPaths {
+			if dependencyIdx, found := indexByPath[dependencyPath]; found {
+				addDependency(dependencyIdx)
+			}
+		}
+	}
+
+	return sortDependencyGraph(targets, dependencies)
+}
+
+// Reverse returns the order to use when removing resources: levels run last
+// to first so dependents come before the resources they depend on, while
+// targets within a level keep their relative order.
+func (o DependencyOrder) Reverse() DependencyOrder {
+	reversed := DependencyOrder{
+		Targets: make([]resource.Resource, 0, len(o.Targets)),
+		Levels:  make([][]int, 0, len(o.Levels)),
+	}
+	for levelIdx := len(o.Levels) - 1; levelIdx >= 0; levelIdx-- {
+		level := make([]int, 0, len(o.Levels[levelIdx]))
+		for _, targetIdx := range o.Levels[levelIdx] {
+			level = append(level, len(reversed.Targets))
+			reversed.Targets = append(reversed.Targets, o.Targets[targetIdx])
+		}
+		reversed.Levels = append(reversed.Levels, level)
+	}
+	return reversed
+}
+
+func nearestAncestorIndex(indexByPath map[string]int, logicalPath string) (int, bool) {
+	current := logicalPath
+	for current != "/" && current != "" && current != "." {
+		current = path.Dir(current)
+		if idx, found := indexByPath[current]; found {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+func sortDependencyGraph(targets []resource.Resource, dependencies [][]int) (DependencyOrder, error) {
+	count := len(targets)
+	dependents := make([][]int, count)
+	pending := make([]int, count)
+	for idx, items := range dependencies {
+		pending[idx] = len(items)
+		for _, dependencyIdx := range items {
+			dependents[dependencyIdx] = append(dependents[dependencyIdx], idx)
+		}
+	}
+
+	ready := &indexHeap{}
+	for idx := 0; idx < count; idx++ {
+		if pending[idx] == 0 {
+			heap.Push(ready, idx)
+		}
+	}
+
+	sorted := make([]int, 0, count)
+	levelOf := make([]int, count)
+	for ready.Len() > 0 {
+		idx := heap.Pop(ready).(int)
+		sorted = append(sorted, idx)
+		for _, dependentIdx := range dependents[idx] {
+			if levelOf[idx]+1 > levelOf[dependentIdx] {
+				levelOf[dependentIdx] = levelOf[idx] + 1
+			}
+			pending[dependentIdx]--
+			if pending[dependentIdx] == 0 {
+				heap.Push(ready, dependentIdx)
+			}
+		}
+	}
+	if len(sorted) < count {
+		return DependencyOrder{}, dependencyCycleError(targets, dependencies, pending)
+	}
+
+	order := DependencyOrder{Targets: make([]resource.Resource, count)}
+	for position, idx := range sorted {
+		order.Targets[position] = targets[idx]
+		level := levelOf[idx]
+		for len(order.Levels) <= level {
+			order.Levels = append(order.Levels, nil)
+		}
+		order.Levels[level] = append(order.Levels[level], position)
+	}
+	return order, nil
+}
+
+// dependencyCycleError follows unresolved dependencies from the first
+// blocked target until a path repeats and reports that cycle.
+func dependencyCycleError(targets []resource.Resource, dependencies [][]int, pending []int) error {
+	current := -1
+	for idx := range pending {
+		if pending[idx] > 0 {
+			current = idx
+			break
+		}
+	}
+
+	visitedAt := map[int]int{}
+	walk := make([]int, 0)
+	for current >= 0 {
+		if start, visited := visitedAt[current]; visited {
+			cycle := make([]string, 0, len(walk)-start+1)
+			for _, idx := range walk[start:] {
+				cycle = append(cycle, targets[idx].LogicalPath)
+			}
+			cycle = append(cycle, targets[current].LogicalPath)
+			return faults.Invalid(
+				fmt.Sprintf("dependency cycle detected: %s", strings.Join(cycle, " -> ")),
+				nil,
+			)
+		}
+		visitedAt[current] = len(walk)
+		walk = append(walk, current)
+
+		next := -1
+		for _, dependencyIdx := range dependencies[current] {
+			if pending[dependencyIdx] > 0 {
+				next = dependencyIdx
+				break
+			}
+		}
+		current = next
+	}
+	return faults.Invalid("dependency cycle detected", nil)
+}
+
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *indexHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
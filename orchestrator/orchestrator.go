@@ -77,6 +77,12 @@ type ResourceDiffer interface {
 	Diff(ctx context.Context, logicalPath string) ([]resource.DiffEntry, error)
 }
 
+// DependencyOrderer orders mutation targets so that every resource follows
+// its parent collection and the resources named by its metadata dependsOn.
+type DependencyOrderer interface {
+	OrderByDependencies(ctx context.Context, targets []resource.Resource) (DependencyOrder, error)
+}
+
 type TemplateRenderer interface {
 	Template(ctx context.Context, logicalPath string, content resource.Content) (resource.Content, error)
 }
@@ -95,4 +101,5 @@ type Orchestrator interface {
 	ResourceApplier
 	ResourceDiffer
 	TemplateRenderer
+	DependencyOrderer
 }
@@ -41,6 +41,20 @@ type ResourceArtifactStore interface {
 	ReadResourceArtifact(ctx context.Context, logicalPath string, file string) ([]byte, error)
 }
 
+// ResourceNormalizer is an optional repository capability that rewrites one
+// stored resource payload file in the repository's canonical serialization.
+// transform, when set, is applied to the decoded payload before encoding.
+// It reports whether the file bytes differ from the canonical form; with
+// dryRun set the file is left untouched.
+type ResourceNormalizer interface {
+	NormalizeResource(
+		ctx context.Context,
+		logicalPath string,
+		transform func(resource.Value) (resource.Value, error),
+		dryRun bool,
+	) (bool, error)
+}
+
 // RepositoryCommitter is an optional repository capability used by commands
 // that want to create a local VCS commit after mutating repository files.
 type RepositoryCommitter interface {
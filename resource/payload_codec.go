@@ -31,6 +31,11 @@ const (
 	PayloadTypeHCL         = "hcl"
 	PayloadTypeINI         = "ini"
 	PayloadTypeProperties  = "properties"
+	PayloadTypeTOML        = "toml"
+	PayloadTypeCSV         = "csv"
+	PayloadTypeNDJSON      = "ndjson"
+	PayloadTypeForm        = "form"
+	PayloadTypeMultipart   = "multipart"
 	PayloadTypeText        = "text"
 	PayloadTypeOctetStream = "octet-stream"
 	PayloadTypeBinary      = "binary"
@@ -54,8 +59,13 @@ var payloadCodecs = []PayloadCodec{
 	{Type: PayloadTypeYAML, Extension: ".yaml", MediaType: "application/yaml", Structured: true},
 	{Type: PayloadTypeINI, Extension: ".ini", MediaType: "application/ini", Structured: true},
 	{Type: PayloadTypeProperties, Extension: ".properties", MediaType: "text/x-java-properties", Structured: true},
-	{Type: PayloadTypeXML, Extension: ".xml", MediaType: "application/xml", Text: true},
-	{Type: PayloadTypeHCL, Extension: ".hcl", MediaType: "application/hcl", Text: true},
+	{Type: PayloadTypeXML, Extension: ".xml", MediaType: "application/xml", Structured: true},
+	{Type: PayloadTypeHCL, Extension: ".hcl", MediaType: "application/hcl", Structured: true},
+	{Type: PayloadTypeTOML, Extension: ".toml", MediaType: "application/toml", Structured: true},
+	{Type: PayloadTypeCSV, Extension: ".csv", MediaType: "text/csv", Structured: true},
+	{Type: PayloadTypeNDJSON, Extension: ".ndjson", MediaType: "application/x-ndjson", Structured: true},
+	{Type: PayloadTypeForm, Extension: ".form", MediaType: "application/x-www-form-urlencoded", Structured: true},
+	{Type: PayloadTypeMultipart, Extension: ".multipart", MediaType: "multipart/form-data", Structured: true},
 	{Type: PayloadTypeText, Extension: ".txt", MediaType: "text/plain", Text: true},
 	{Type: PayloadTypeOctetStream, Extension: ".bin", MediaType: "application/octet-stream", Binary: true},
 }
@@ -281,6 +291,48 @@ func decodeStructuredPayload(data []byte, payloadType string) (Value, error) {
 			return nil, err
 		}
 		return Normalize(decoded)
+	case PayloadTypeXML:
+		decoded, err := decodeXMLPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
+	case PayloadTypeHCL:
+		decoded, err := decodeHCLPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
+	case PayloadTypeTOML:
+		decoded, err := decodeTOMLPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
+	case PayloadTypeCSV:
+		decoded, err := decodeCSVPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
+	case PayloadTypeNDJSON:
+		decoded, err := decodeNDJSONPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
+	case PayloadTypeForm:
+		decoded, err := decodeFormPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
+	case PayloadTypeMultipart:
+		decoded, err := decodeMultipartPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(decoded)
 	default:
 		decoder := json.NewDecoder(bytes.NewReader(data))
 		decoder.UseNumber()
@@ -294,6 +346,15 @@ func decodeStructuredPayload(data []byte, payloadType string) (Value, error) {
 }
 
 func encodeStructuredPayload(value Value, payloadType string, pretty bool) ([]byte, error) {
+	// Form encodings normalize leaves themselves so MultipartFile values
+	// survive until they are written as file parts.
+	switch payloadType {
+	case PayloadTypeForm:
+		return encodeFormPayload(value)
+	case PayloadTypeMultipart:
+		return encodeMultipartPayload(value)
+	}
+
 	normalized, err := Normalize(value)
 	if err != nil {
 		return nil, err
@@ -310,6 +371,16 @@ func encodeStructuredPayload(value Value, payloadType string, pretty bool) ([]by
 		return encodeINIPayload(normalized)
 	case PayloadTypeProperties:
 		return encodePropertiesPayload(normalized)
+	case PayloadTypeXML:
+		return encodeXMLPayload(normalized, pretty)
+	case PayloadTypeHCL:
+		return encodeHCLPayload(normalized)
+	case PayloadTypeTOML:
+		return encodeTOMLPayload(normalized)
+	case PayloadTypeCSV:
+		return encodeCSVPayload(normalized)
+	case PayloadTypeNDJSON:
+		return encodeNDJSONPayload(normalized)
 	default:
 		if pretty {
 			encoded, err := json.MarshalIndent(normalized, "", "  ")
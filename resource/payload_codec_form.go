@@ -0,0 +1,284 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+// MultipartFile is a payload value sent as a file part by the multipart
+// codec. The form codec sends its content as a plain field value.
+type MultipartFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type formField struct {
+	name  string
+	value string
+	file  *MultipartFile
+}
+
+var multipartQuoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func decodeFormPayload(data []byte) (map[string]any, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, faults.Invalid("invalid form payload", err)
+	}
+
+	decoded := make(map[string]any, len(values))
+	for key, items := range values {
+		decoded[key] = formFieldValue(items)
+	}
+	return decoded, nil
+}
+
+func encodeFormPayload(value any) ([]byte, error) {
+	fields, err := flattenFormPayload(value, PayloadTypeForm)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make([]string, 0, len(fields))
+	for _, field := range fields {
+		text := field.value
+		if field.file != nil {
+			text = string(field.file.Content)
+		}
+		encoded = append(encoded, url.QueryEscape(field.name)+"="+url.QueryEscape(text))
+	}
+	return []byte(strings.Join(encoded, "&")), nil
+}
+
+// MultipartBoundary returns the boundary of an encoded multipart body, read
+// from its first delimiter line.
+func MultipartBoundary(data []byte) (string, bool) {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	line = bytes.TrimRight(line, "\r")
+	if !bytes.HasPrefix(line, []byte("--")) || len(line) == 2 {
+		return "", false
+	}
+	return string(line[2:]), true
+}
+
+func decodeMultipartPayload(data []byte) (map[string]any, error) {
+	boundary, ok := MultipartBoundary(data)
+	if !ok {
+		return nil, faults.Invalid("invalid multipart payload", fmt.Errorf("missing boundary delimiter"))
+	}
+
+	grouped := map[string][]any{}
+	reader := multipart.NewReader(bytes.NewReader(data), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, faults.Invalid("invalid multipart payload", err)
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, faults.Invalid("invalid multipart payload", err)
+		}
+		name := part.FormName()
+		if name == "" {
+			return nil, faults.Invalid("invalid multipart payload", fmt.Errorf("part without a form name"))
+		}
+
+		var value any = string(content)
+		if part.FileName() != "" && !utf8.Valid(content) {
+			value = BinaryValue{Bytes: content}
+		}
+		grouped[name] = append(grouped[name], value)
+	}
+
+	decoded := make(map[string]any, len(grouped))
+	for name, items := range grouped {
+		if len(items) == 1 {
+			decoded[name] = items[0]
+			continue
+		}
+		decoded[name] = items
+	}
+	return decoded, nil
+}
+
+// encodeMultipartPayload writes value as multipart/form-data. The boundary is
+// derived from the content so retries and cassettes see identical bytes.
+func encodeMultipartPayload(value any) ([]byte, error) {
+	fields, err := flattenFormPayload(value, PayloadTypeMultipart)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	if err := writer.SetBoundary(multipartBoundaryFor(fields)); err != nil {
+		return nil, faults.Internal("failed to encode multipart payload", err)
+	}
+
+	for _, field := range fields {
+		header := textproto.MIMEHeader{}
+		content := []byte(field.value)
+		disposition := fmt.Sprintf(`form-data; name="%s"`, multipartQuoteEscaper.Replace(field.name))
+		if field.file != nil {
+			disposition += fmt.Sprintf(`; filename="%s"`, multipartQuoteEscaper.Replace(field.file.FileName))
+			contentType := strings.TrimSpace(field.file.ContentType)
+			if contentType == "" {
+				contentType = defaultOctetStreamMediaType
+			}
+			header.Set("Content-Type", contentType)
+			content = field.file.Content
+		}
+		header.Set("Content-Disposition", disposition)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, faults.Internal("failed to encode multipart payload", err)
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, faults.Internal("failed to encode multipart payload", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, faults.Internal("failed to encode multipart payload", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func multipartBoundaryFor(fields []formField) string {
+	hash := sha256.New()
+	for _, field := range fields {
+		hash.Write([]byte(field.name))
+		hash.Write([]byte{0})
+		if field.file != nil {
+			hash.Write([]byte(field.file.FileName))
+			hash.Write([]byte{0})
+			hash.Write(field.file.Content)
+		} else {
+			hash.Write([]byte(field.value))
+		}
+		hash.Write([]byte{0})
+	}
+	sum := hash.Sum(nil)
+
+	for {
+		boundary := "declarest-" + hex.EncodeToString(sum[:16])
+		if !slices.ContainsFunc(fields, func(field formField) bool {
+			if field.file != nil {
+				return bytes.Contains(field.file.Content, []byte(boundary))
+			}
+			return strings.Contains(field.value, boundary)
+		}) {
+			return boundary
+		}
+		next := sha256.Sum256(sum)
+		sum = next[:]
+	}
+}
+
+// flattenFormPayload turns an object into ordered form fields. Nested objects
+// use bracket keys (a[b]), arrays of scalars repeat their key, and arrays of
+// objects are indexed (a[0][b]).
+func flattenFormPayload(value any, payloadType string) ([]formField, error) {
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, faults.Invalid(
+			fmt.Sprintf("failed to encode %s payload", payloadType),
+			fmt.Errorf("%s payload requires an object", payloadType),
+		)
+	}
+
+	fields := []formField{}
+	for _, key := range slices.Sorted(maps.Keys(root)) {
+		if err := appendFormFields(&fields, key, root[key], payloadType); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func appendFormFields(fields *[]formField, name string, value any, payloadType string) error {
+	switch typed := value.(type) {
+	case MultipartFile:
+		file := typed
+		*fields = append(*fields, formField{name: name, file: &file})
+	case *MultipartFile:
+		if typed != nil {
+			*fields = append(*fields, formField{name: name, file: typed})
+		}
+	case BinaryValue:
+		*fields = append(*fields, formField{name: name, file: &MultipartFile{FileName: name, Content: typed.Bytes}})
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(typed)) {
+			if err := appendFormFields(fields, name+"["+key+"]", typed[key], payloadType); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for idx, item := range typed {
+			switch item.(type) {
+			case map[string]any, []any:
+				if err := appendFormFields(fields, name+"["+strconv.Itoa(idx)+"]", item, payloadType); err != nil {
+					return err
+				}
+			default:
+				if err := appendFormFields(fields, name, item, payloadType); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		normalized, err := Normalize(typed)
+		if err != nil {
+			return err
+		}
+		text, err := stringifyStructuredTextScalar(normalized, payloadType)
+		if err != nil {
+			return err
+		}
+		*fields = append(*fields, formField{name: name, value: text})
+	}
+	return nil
+}
+
+func formFieldValue(items []string) any {
+	if len(items) == 1 {
+		return items[0]
+	}
+	values := make([]any, len(items))
+	for idx, item := range items {
+		values[idx] = item
+	}
+	return values
+}
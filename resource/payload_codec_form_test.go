@@ -0,0 +1,122 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeEncodeFormPayload(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := EncodePayload(map[string]any{
+		"name":    "alpha beta",
+		"enabled": true,
+		"port":    8080,
+		"tags":    []any{"a", "b"},
+		"owner":   map[string]any{"team": "platform"},
+	}, PayloadTypeForm)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	if want := "enabled=true&name=alpha+beta&owner%5Bteam%5D=platform&port=8080&tags=a&tags=b"; string(encoded) != want {
+		t.Fatalf("unexpected form encoding: got %q want %q", encoded, want)
+	}
+
+	decoded, err := DecodePayload(encoded, PayloadTypeForm)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{
+		"enabled":     "true",
+		"name":        "alpha beta",
+		"owner[team]": "platform",
+		"port":        "8080",
+		"tags":        []any{"a", "b"},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected form payload: got %#v want %#v", decoded, want)
+	}
+
+	if _, err := EncodePayload([]any{"a"}, PayloadTypeForm); err == nil {
+		t.Fatal("expected form encoding of an array to fail")
+	}
+}
+
+func TestDecodeEncodeMultipartPayload(t *testing.T) {
+	t.Parallel()
+
+	value := map[string]any{
+		"name": "deploy",
+		"script": MultipartFile{
+			FileName:    "script.sh",
+			ContentType: "application/x-sh",
+			Content:     []byte("#!/bin/sh\necho ok\n"),
+		},
+	}
+	encoded, err := EncodePayload(value, PayloadTypeMultipart)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+
+	again, err := EncodePayload(value, PayloadTypeMultipart)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	if !bytes.Equal(encoded, again) {
+		t.Fatal("expected multipart encoding to be deterministic")
+	}
+
+	boundary, ok := MultipartBoundary(encoded)
+	if !ok || !strings.HasPrefix(boundary, "declarest-") {
+		t.Fatalf("unexpected multipart boundary %q", boundary)
+	}
+	for _, fragment := range []string{
+		`Content-Disposition: form-data; name="script"; filename="script.sh"`,
+		"Content-Type: application/x-sh",
+		`Content-Disposition: form-data; name="name"`,
+	} {
+		if !strings.Contains(string(encoded), fragment) {
+			t.Fatalf("expected %q in multipart body:\n%s", fragment, encoded)
+		}
+	}
+
+	decoded, err := DecodePayload(encoded, PayloadTypeMultipart)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{"name": "deploy", "script": "#!/bin/sh\necho ok\n"}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected multipart payload: got %#v want %#v", decoded, want)
+	}
+}
+
+func TestFormPayloadDescriptors(t *testing.T) {
+	t.Parallel()
+
+	for mediaType, payloadType := range map[string]string{
+		"application/x-www-form-urlencoded; charset=utf-8": PayloadTypeForm,
+		"multipart/form-data; boundary=abc":                PayloadTypeMultipart,
+		"form":                                             PayloadTypeForm,
+	} {
+		descriptor, ok := PayloadDescriptorForContentType(mediaType)
+		if !ok || descriptor.PayloadType != payloadType {
+			t.Fatalf("expected %q to map to %q, got %#v", mediaType, payloadType, descriptor)
+		}
+	}
+}
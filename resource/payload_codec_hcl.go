@@ -0,0 +1,994 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+// HCL payloads map to the same tree hcl2json produces: attributes are keys,
+// and a block becomes its type name keyed by each label in turn, ending in an
+// array of block bodies, so `path "sys/*" { ... }` is
+// {"path": {"sys/*": [{...}]}}. Expressions other than literals are kept as
+// "${expression}" strings, which HCL evaluates the same way, and template
+// strings keep their interpolations as written. An attribute whose literal
+// value is shaped like blocks is kept as an expression too, so it is not
+// written back as blocks. Comments are not preserved.
+
+type hclParser struct {
+	data []byte
+	pos  int
+}
+
+func decodeHCLPayload(data []byte) (map[string]any, error) {
+	parser := &hclParser{data: data}
+	body, err := parser.parseBody(0)
+	if err != nil {
+		return nil, faults.Invalid("invalid hcl payload", err)
+	}
+	return body, nil
+}
+
+func (p *hclParser) errorf(format string, args ...any) error {
+	line := 1 + bytes.Count(p.data[:min(p.pos, len(p.data))], []byte("\n"))
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *hclParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *hclParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *hclParser) hasPrefix(prefix string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(prefix))
+}
+
+// skipSpace skips blanks and comments, and newlines too when newlines is set.
+// A line comment stops before its newline so it still ends an attribute.
+func (p *hclParser) skipSpace(newlines bool) {
+	for !p.eof() {
+		switch {
+		case p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\r':
+			p.pos++
+		case p.peek() == '\n' && newlines:
+			p.pos++
+		case p.peek() == '#' || p.hasPrefix("//"):
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		case p.hasPrefix("/*"):
+			end := bytes.Index(p.data[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				p.pos = len(p.data)
+				return
+			}
+			p.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+func (p *hclParser) parseBody(closing byte) (map[string]any, error) {
+	body := map[string]any{}
+	attributes := map[string]bool{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			if closing != 0 {
+				return nil, p.errorf("missing closing %q", closing)
+			}
+			return body, nil
+		}
+		if closing != 0 && p.peek() == closing {
+			p.pos++
+			return body, nil
+		}
+
+		name, ok := p.parseIdentifier()
+		if !ok {
+			return nil, p.errorf("expected attribute or block name, got %q", p.peek())
+		}
+		p.skipSpace(false)
+
+		if p.peek() == '=' && !p.hasPrefix("==") {
+			p.pos++
+			if _, exists := body[name]; exists {
+				return nil, p.errorf("duplicate attribute %q", name)
+			}
+			value, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			// A literal shaped like decoded blocks would be written back as
+			// blocks, so it is kept as an expression to stay an attribute.
+			if _, isBlock := hclBlocks(value, nil); isBlock {
+				var expression bytes.Buffer
+				if err := writeHCLInlineValue(&expression, value); err != nil {
+					return nil, p.errorf("%v", err)
+				}
+				value = "${" + expression.String() + "}"
+			}
+			body[name] = value
+			attributes[name] = true
+
+			p.skipSpace(false)
+			if !p.eof() && p.peek() != '\n' && (closing == 0 || p.peek() != closing) {
+				return nil, p.errorf("unexpected %q after attribute %q", p.peek(), name)
+			}
+			continue
+		}
+
+		if attributes[name] {
+			return nil, p.errorf("block %q conflicts with an attribute", name)
+		}
+		labels := []string{}
+		for p.peek() != '{' {
+			if p.peek() == '"' {
+				label, err := p.parseQuotedString()
+				if err != nil {
+					return nil, err
+				}
+				labels = append(labels, label)
+			} else if label, ok := p.parseIdentifier(); ok {
+				labels = append(labels, label)
+			} else {
+				return nil, p.errorf("expected block label or %q after %q", '{', name)
+			}
+			p.skipSpace(false)
+		}
+		p.pos++
+
+		blockBody, err := p.parseBody('}')
+		if err != nil {
+			return nil, err
+		}
+		if err := addHCLBlock(body, name, labels, blockBody); err != nil {
+			return nil, p.errorf("%v", err)
+		}
+	}
+}
+
+func addHCLBlock(body map[string]any, name string, labels []string, blockBody map[string]any) error {
+	container := body
+	key := name
+	for _, label := range labels {
+		existing, found := container[key]
+		if !found {
+			existing = map[string]any{}
+			container[key] = existing
+		}
+		next, ok := existing.(map[string]any)
+		if !ok {
+			return fmt.Errorf("block %q uses an inconsistent number of labels", name)
+		}
+		container = next
+		key = label
+	}
+
+	existing, found := container[key]
+	if !found {
+		container[key] = []any{blockBody}
+		return nil
+	}
+	bodies, ok := existing.([]any)
+	if !ok {
+		return fmt.Errorf("block %q uses an inconsistent number of labels", name)
+	}
+	container[key] = append(bodies, blockBody)
+	return nil
+}
+
+func (p *hclParser) parseIdentifier() (string, bool) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRune(p.data[p.pos:])
+		if p.pos == start && !isHCLIdentifierStart(r) {
+			return "", false
+		}
+		if p.pos > start && !isHCLIdentifierPart(r) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", false
+	}
+	return string(p.data[start:p.pos]), true
+}
+
+func isHCLIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isHCLIdentifierPart(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parseExpression reads a literal value. Anything else, or a literal that
+// continues into an operator, is captured verbatim as "${expression}".
+func (p *hclParser) parseExpression() (any, error) {
+	p.skipSpace(false)
+	start := p.pos
+
+	value, literal, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	if literal {
+		p.skipSpace(false)
+		if p.atExpressionEnd() {
+			return value, nil
+		}
+		p.pos = start
+	}
+	return p.parseRawExpression()
+}
+
+func (p *hclParser) atExpressionEnd() bool {
+	if p.eof() {
+		return true
+	}
+	switch p.peek() {
+	case '\n', ',', ']', '}', ')':
+		return true
+	}
+	return false
+}
+
+func (p *hclParser) parseLiteral() (any, bool, error) {
+	switch {
+	case p.eof():
+		return nil, false, p.errorf("missing expression")
+	case p.peek() == '"':
+		value, err := p.parseQuotedString()
+		return value, err == nil, err
+	case p.hasPrefix("<<"):
+		value, err := p.parseHeredoc()
+		return value, err == nil, err
+	case p.peek() == '[':
+		if p.startsForExpression() {
+			return nil, false, nil
+		}
+		return p.parseTuple()
+	case p.peek() == '{':
+		if p.startsForExpression() {
+			return nil, false, nil
+		}
+		return p.parseObject()
+	case p.peek() == '-' || (p.peek() >= '0' && p.peek() <= '9'):
+		return p.parseNumber()
+	}
+
+	start := p.pos
+	name, ok := p.parseIdentifier()
+	switch {
+	case ok && name == "true":
+		return true, true, nil
+	case ok && name == "false":
+		return false, true, nil
+	case ok && name == "null":
+		return nil, true, nil
+	}
+	p.pos = start
+	return nil, false, nil
+}
+
+func (p *hclParser) startsForExpression() bool {
+	start := p.pos
+	defer func() { p.pos = start }()
+
+	p.pos++
+	p.skipSpace(true)
+	name, ok := p.parseIdentifier()
+	return ok && name == "for" && (p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\n')
+}
+
+func (p *hclParser) parseNumber() (any, bool, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := func() int {
+		from := p.pos
+		for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		return p.pos - from
+	}
+	if digits() == 0 {
+		p.pos = start
+		return nil, false, nil
+	}
+	if p.peek() == '.' {
+		p.pos++
+		if digits() == 0 {
+			p.pos = start
+			return nil, false, nil
+		}
+	}
+	if p.peek() == 'e' || p.peek() == 'E' {
+		p.pos++
+		if p.peek() == '+' || p.peek() == '-' {
+			p.pos++
+		}
+		if digits() == 0 {
+			p.pos = start
+			return nil, false, nil
+		}
+	}
+	return json.Number(p.data[start:p.pos]), true, nil
+}
+
+func (p *hclParser) parseTuple() (any, bool, error) {
+	p.pos++
+	items := []any{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, false, p.errorf("missing closing %q", ']')
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return items, true, nil
+		}
+
+		item, err := p.parseExpression()
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, item)
+
+		p.skipSpace(true)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, false, p.errorf("expected %q or %q in tuple", ',', ']')
+		}
+	}
+}
+
+func (p *hclParser) parseObject() (any, bool, error) {
+	p.pos++
+	object := map[string]any{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, false, p.errorf("missing closing %q", '}')
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return object, true, nil
+		}
+
+		var key string
+		if p.peek() == '"' {
+			quoted, err := p.parseQuotedString()
+			if err != nil {
+				return nil, false, err
+			}
+			key = quoted
+		} else if name, ok := p.parseIdentifier(); ok {
+			key = name
+		} else {
+			return nil, false, p.errorf("expected object key, got %q", p.peek())
+		}
+
+		p.skipSpace(false)
+		if p.peek() != '=' && p.peek() != ':' {
+			return nil, false, p.errorf("expected %q after object key %q", '=', key)
+		}
+		p.pos++
+		if _, exists := object[key]; exists {
+			return nil, false, p.errorf("duplicate object key %q", key)
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, false, err
+		}
+		object[key] = value
+
+		p.skipSpace(false)
+		switch p.peek() {
+		case ',', '\n':
+			p.pos++
+		case '}':
+		default:
+			return nil, false, p.errorf("expected %q or newline in object", ',')
+		}
+	}
+}
+
+// parseQuotedString decodes backslash escapes and keeps template sequences
+// such as ${var.name}, %{if ...} and their $${ escapes as written.
+func (p *hclParser) parseQuotedString() (string, error) {
+	p.pos++
+	var builder strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		switch {
+		case p.peek() == '"':
+			p.pos++
+			return builder.String(), nil
+		case p.hasPrefix("$${") || p.hasPrefix("%%{"):
+			builder.Write(p.data[p.pos : p.pos+3])
+			p.pos += 3
+		case p.hasPrefix("${") || p.hasPrefix("%{"):
+			end, ok := hclTemplateSequenceEnd(p.data, p.pos)
+			if !ok {
+				return "", p.errorf("unterminated template sequence")
+			}
+			builder.Write(p.data[p.pos:end])
+			p.pos = end
+		case p.peek() == '\\':
+			if err := p.parseEscape(&builder); err != nil {
+				return "", err
+			}
+		default:
+			builder.WriteByte(p.peek())
+			p.pos++
+		}
+	}
+}
+
+func (p *hclParser) parseEscape(builder *strings.Builder) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated escape sequence")
+	}
+	escape := p.peek()
+	p.pos++
+	switch escape {
+	case 'n':
+		builder.WriteByte('\n')
+	case 'r':
+		builder.WriteByte('\r')
+	case 't':
+		builder.WriteByte('\t')
+	case '"', '\\':
+		builder.WriteByte(escape)
+	case 'u', 'U':
+		size := 4
+		if escape == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(string(p.data[p.pos:p.pos+size]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape")
+		}
+		builder.WriteRune(rune(code))
+		p.pos += size
+	default:
+		return p.errorf("invalid escape sequence \\%c", escape)
+	}
+	return nil
+}
+
+// hclTemplateSequenceEnd returns the offset just past the "}" closing the
+// template sequence that starts at start, skipping nested braces and strings.
+func hclTemplateSequenceEnd(data []byte, start int) (int, bool) {
+	depth := 0
+	for idx := start + 1; idx < len(data); idx++ {
+		switch data[idx] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return idx + 1, true
+			}
+		case '"':
+			end, ok := hclQuotedEnd(data, idx)
+			if !ok {
+				return 0, false
+			}
+			idx = end - 1
+		case '\n':
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+func hclQuotedEnd(data []byte, start int) (int, bool) {
+	for idx := start + 1; idx < len(data); idx++ {
+		switch {
+		case data[idx] == '\\':
+			idx++
+		case data[idx] == '"':
+			return idx + 1, true
+		case data[idx] == '\n':
+			return 0, false
+		case bytes.HasPrefix(data[idx:], []byte("$${")) || bytes.HasPrefix(data[idx:], []byte("%%{")):
+			idx += 2
+		case bytes.HasPrefix(data[idx:], []byte("${")) || bytes.HasPrefix(data[idx:], []byte("%{")):
+			end, ok := hclTemplateSequenceEnd(data, idx)
+			if !ok {
+				return 0, false
+			}
+			idx = end - 1
+		}
+	}
+	return 0, false
+}
+
+func (p *hclParser) parseHeredoc() (string, error) {
+	p.pos += 2
+	indented := false
+	if p.peek() == '-' {
+		indented = true
+		p.pos++
+	}
+	marker, ok := p.parseIdentifier()
+	if !ok {
+		return "", p.errorf("missing heredoc marker")
+	}
+	p.skipSpace(false)
+	if p.peek() != '\n' {
+		return "", p.errorf("heredoc marker %q must end its line", marker)
+	}
+	p.pos++
+
+	lines := []string{}
+	for {
+		if p.eof() {
+			return "", p.errorf("heredoc %q is not closed", marker)
+		}
+		end := bytes.IndexByte(p.data[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.data) - p.pos
+		}
+		line := strings.TrimSuffix(string(p.data[p.pos:p.pos+end]), "\r")
+		p.pos += end
+		if strings.TrimSpace(line) == marker {
+			break
+		}
+		p.pos++
+		lines = append(lines, line)
+	}
+
+	if indented {
+		trimHCLHeredocIndent(lines)
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func trimHCLHeredocIndent(lines []string) {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		width := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+	for idx, line := range lines {
+		lines[idx] = line[min(indent, len(line)-len(strings.TrimLeft(line, " \t"))):]
+	}
+}
+
+// parseRawExpression captures an expression up to the newline, comma or
+// closing bracket that ends it, balancing brackets and skipping strings.
+func (p *hclParser) parseRawExpression() (any, error) {
+	start := p.pos
+	depth := 0
+	for !p.eof() {
+		switch p.peek() {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return p.rawExpressionValue(start)
+			}
+			depth--
+		case ',', '\n':
+			if depth == 0 {
+				return p.rawExpressionValue(start)
+			}
+		case '"':
+			end, ok := hclQuotedEnd(p.data, p.pos)
+			if !ok {
+				return nil, p.errorf("unterminated string")
+			}
+			p.pos = end
+			continue
+		case '#':
+			if depth == 0 {
+				return p.rawExpressionValue(start)
+			}
+		case '/':
+			if depth == 0 && (p.hasPrefix("//") || p.hasPrefix("/*")) {
+				return p.rawExpressionValue(start)
+			}
+		}
+		p.pos++
+	}
+	if depth > 0 {
+		return nil, p.errorf("unbalanced brackets in expression")
+	}
+	return p.rawExpressionValue(start)
+}
+
+func (p *hclParser) rawExpressionValue(start int) (any, error) {
+	expression := strings.TrimSpace(string(p.data[start:p.pos]))
+	if expression == "" {
+		return nil, p.errorf("missing expression")
+	}
+	return "${" + expression + "}", nil
+}
+
+// encodeHCLPayload writes the tree as an HCL body. Keys are written in order,
+// attributes before blocks; values shaped like blocks (arrays of objects, or
+// objects whose leaves are such arrays) are written as blocks.
+func encodeHCLPayload(value any) ([]byte, error) {
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, faults.Invalid("failed to encode hcl payload", fmt.Errorf("hcl payload requires an object"))
+	}
+
+	var buffer bytes.Buffer
+	if err := writeHCLBody(&buffer, root, 0); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+type hclBlock struct {
+	labels []string
+	body   map[string]any
+}
+
+func writeHCLBody(buffer *bytes.Buffer, body map[string]any, depth int) error {
+	attributeNames := []string{}
+	blockNames := []string{}
+	for _, key := range slices.Sorted(maps.Keys(body)) {
+		if _, isBlock := hclBlocks(body[key], nil); isBlock {
+			blockNames = append(blockNames, key)
+			continue
+		}
+		attributeNames = append(attributeNames, key)
+	}
+
+	width := 0
+	for _, name := range attributeNames {
+		width = max(width, utf8.RuneCountInString(name))
+	}
+	for _, name := range attributeNames {
+		if !isHCLIdentifier(name) {
+			return faults.Invalid("failed to encode hcl payload", fmt.Errorf("invalid hcl attribute name %q", name))
+		}
+		buffer.WriteString(strings.Repeat("  ", depth))
+		buffer.WriteString(name)
+		buffer.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(name)))
+		buffer.WriteString(" = ")
+		if err := writeHCLAttributeValue(buffer, body[name], depth); err != nil {
+			return err
+		}
+		buffer.WriteByte('\n')
+	}
+
+	wrote := len(attributeNames) > 0
+	for _, name := range blockNames {
+		if !isHCLIdentifier(name) {
+			return faults.Invalid("failed to encode hcl payload", fmt.Errorf("invalid hcl block type %q", name))
+		}
+		blocks, _ := hclBlocks(body[name], nil)
+		for _, block := range blocks {
+			if wrote {
+				buffer.WriteByte('\n')
+			}
+			wrote = true
+
+			buffer.WriteString(strings.Repeat("  ", depth))
+			buffer.WriteString(name)
+			for _, label := range block.labels {
+				buffer.WriteByte(' ')
+				buffer.WriteString(quoteHCLString(label))
+			}
+			if len(block.body) == 0 {
+				buffer.WriteString(" {}\n")
+				continue
+			}
+			buffer.WriteString(" {\n")
+			if err := writeHCLBody(buffer, block.body, depth+1); err != nil {
+				return err
+			}
+			buffer.WriteString(strings.Repeat("  ", depth))
+			buffer.WriteString("}\n")
+		}
+	}
+	return nil
+}
+
+// hclBlocks reports whether value has the shape of decoded blocks and lists
+// them with their labels in key order.
+func hclBlocks(value any, labels []string) ([]hclBlock, bool) {
+	switch typed := value.(type) {
+	case []any:
+		if len(typed) == 0 {
+			return nil, false
+		}
+		blocks := make([]hclBlock, 0, len(typed))
+		for _, item := range typed {
+			body, ok := item.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			blocks = append(blocks, hclBlock{labels: labels, body: body})
+		}
+		return blocks, true
+	case map[string]any:
+		if len(typed) == 0 {
+			return nil, false
+		}
+		blocks := []hclBlock{}
+		for _, label := range slices.Sorted(maps.Keys(typed)) {
+			nested, ok := hclBlocks(typed[label], append(slices.Clone(labels), label))
+			if !ok {
+				return nil, false
+			}
+			blocks = append(blocks, nested...)
+		}
+		return blocks, true
+	default:
+		return nil, false
+	}
+}
+
+func writeHCLAttributeValue(buffer *bytes.Buffer, value any, depth int) error {
+	if text, ok := value.(string); ok {
+		if marker, ok := hclHeredocMarker(text); ok {
+			buffer.WriteString("<<" + marker + "\n")
+			buffer.WriteString(text)
+			buffer.WriteString(marker)
+			return nil
+		}
+	}
+	return writeHCLValue(buffer, value, depth)
+}
+
+func writeHCLValue(buffer *bytes.Buffer, value any, depth int) error {
+	switch typed := value.(type) {
+	case nil:
+		buffer.WriteString("null")
+	case bool:
+		buffer.WriteString(strconv.FormatBool(typed))
+	case int64:
+		buffer.WriteString(strconv.FormatInt(typed, 10))
+	case float64:
+		buffer.WriteString(strconv.FormatFloat(typed, 'f', -1, 64))
+	case string:
+		buffer.WriteString(quoteHCLString(typed))
+	case []any:
+		return writeHCLTuple(buffer, typed, depth)
+	case map[string]any:
+		return writeHCLObject(buffer, typed, depth)
+	default:
+		return faults.Invalid("failed to encode hcl payload", fmt.Errorf("unsupported hcl value %T", value))
+	}
+	return nil
+}
+
+func writeHCLTuple(buffer *bytes.Buffer, items []any, depth int) error {
+	inline := true
+	for _, item := range items {
+		switch item.(type) {
+		case []any, map[string]any:
+			inline = false
+		}
+	}
+
+	if inline {
+		buffer.WriteByte('[')
+		for idx, item := range items {
+			if idx > 0 {
+				buffer.WriteString(", ")
+			}
+			if err := writeHCLValue(buffer, item, depth); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	}
+
+	buffer.WriteString("[\n")
+	for _, item := range items {
+		buffer.WriteString(strings.Repeat("  ", depth+1))
+		if err := writeHCLValue(buffer, item, depth+1); err != nil {
+			return err
+		}
+		buffer.WriteString(",\n")
+	}
+	buffer.WriteString(strings.Repeat("  ", depth))
+	buffer.WriteByte(']')
+	return nil
+}
+
+func writeHCLObject(buffer *bytes.Buffer, object map[string]any, depth int) error {
+	if len(object) == 0 {
+		buffer.WriteString("{}")
+		return nil
+	}
+
+	keys := slices.Sorted(maps.Keys(object))
+	written := make([]string, len(keys))
+	width := 0
+	for idx, key := range keys {
+		written[idx] = key
+		if !isHCLIdentifier(key) {
+			written[idx] = quoteHCLString(key)
+		}
+		width = max(width, utf8.RuneCountInString(written[idx]))
+	}
+
+	buffer.WriteString("{\n")
+	for idx, key := range keys {
+		buffer.WriteString(strings.Repeat("  ", depth+1))
+		buffer.WriteString(written[idx])
+		buffer.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(written[idx])))
+		buffer.WriteString(" = ")
+		if err := writeHCLValue(buffer, object[key], depth+1); err != nil {
+			return err
+		}
+		buffer.WriteByte('\n')
+	}
+	buffer.WriteString(strings.Repeat("  ", depth))
+	buffer.WriteByte('}')
+	return nil
+}
+
+// writeHCLInlineValue writes value on one line, as an interpolation needs.
+func writeHCLInlineValue(buffer *bytes.Buffer, value any) error {
+	switch typed := value.(type) {
+	case []any:
+		buffer.WriteByte('[')
+		for idx, item := range typed {
+			if idx > 0 {
+				buffer.WriteString(", ")
+			}
+			if err := writeHCLInlineValue(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	case map[string]any:
+		if len(typed) == 0 {
+			buffer.WriteString("{}")
+			return nil
+		}
+		buffer.WriteString("{ ")
+		for idx, key := range slices.Sorted(maps.Keys(typed)) {
+			if idx > 0 {
+				buffer.WriteString(", ")
+			}
+			if isHCLIdentifier(key) {
+				buffer.WriteString(key)
+			} else {
+				buffer.WriteString(quoteHCLString(key))
+			}
+			buffer.WriteString(" = ")
+			if err := writeHCLInlineValue(buffer, typed[key]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString(" }")
+	case json.Number:
+		buffer.WriteString(typed.String())
+	default:
+		return writeHCLValue(buffer, value, 0)
+	}
+	return nil
+}
+
+func hclHeredocMarker(value string) (string, bool) {
+	if !strings.HasSuffix(value, "\n") || strings.ContainsRune(value, '\r') {
+		return "", false
+	}
+	lines := strings.Split(strings.TrimSuffix(value, "\n"), "\n")
+	if len(lines) < 2 {
+		return "", false
+	}
+	for _, marker := range []string{"EOT", "EOF", "EOH"} {
+		if !slices.ContainsFunc(lines, func(line string) bool { return strings.TrimSpace(line) == marker }) {
+			return marker, true
+		}
+	}
+	return "", false
+}
+
+func quoteHCLString(value string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	data := []byte(value)
+	for idx := 0; idx < len(data); {
+		switch {
+		case bytes.HasPrefix(data[idx:], []byte("$${")) || bytes.HasPrefix(data[idx:], []byte("%%{")):
+			builder.Write(data[idx : idx+3])
+			idx += 3
+			continue
+		case bytes.HasPrefix(data[idx:], []byte("${")) || bytes.HasPrefix(data[idx:], []byte("%{")):
+			if end, ok := hclTemplateSequenceEnd(data, idx); ok {
+				builder.Write(data[idx:end])
+				idx = end
+				continue
+			}
+			builder.WriteByte(data[idx])
+			builder.WriteByte(data[idx])
+			idx++
+			continue
+		}
+
+		r, size := utf8.DecodeRune(data[idx:])
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&builder, `\u%04x`, r)
+		default:
+			builder.WriteRune(r)
+		}
+		idx += size
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+func isHCLIdentifier(value string) bool {
+	for idx, r := range value {
+		if idx == 0 && !isHCLIdentifierStart(r) {
+			return false
+		}
+		if !isHCLIdentifierPart(r) {
+			return false
+		}
+	}
+	return value != ""
+}
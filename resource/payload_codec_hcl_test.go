@@ -0,0 +1,250 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEncodeHCLPolicyPayload(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`# Vault policy
+path "secret/data/*" {
+  capabilities = ["read", "list"]
+}
+
+path "sys/*" { capabilities = ["deny"] }
+`)
+
+	decoded, err := DecodePayload(input, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{
+		"path": map[string]any{
+			"secret/data/*": []any{map[string]any{"capabilities": []any{"read", "list"}}},
+			"sys/*":         []any{map[string]any{"capabilities": []any{"deny"}}},
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected hcl payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload(decoded, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	wantHCL := `path "secret/data/*" {
+  capabilities = ["read", "list"]
+}
+
+path "sys/*" {
+  capabilities = ["deny"]
+}
+`
+	if string(encoded) != wantHCL {
+		t.Fatalf("unexpected hcl encoding:\n%s\nwant:\n%s", encoded, wantHCL)
+	}
+}
+
+func TestDecodeEncodeHCLJobPayload(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`job "web" {
+  datacenters = ["dc1"]
+  priority    = 50
+  meta = {
+    owner = "platform"
+    "app.version" = "1.2"
+  }
+
+  group "app" {
+    count = 2
+
+    task "server" {
+      driver = "docker"
+      env {
+        PORT = "${NOMAD_PORT_http}"
+      }
+      template {
+        data = <<-EOT
+          listen {{ env "PORT" }}
+          root /srv
+        EOT
+        destination = "local/app.conf"
+      }
+      user    = var.user
+      enabled = true
+      weight  = 0.5
+      token   = null
+    }
+  }
+}
+`)
+
+	decoded, err := DecodePayload(input, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	task := map[string]any{
+		"driver": "docker",
+		"env":    []any{map[string]any{"PORT": "${NOMAD_PORT_http}"}},
+		"template": []any{map[string]any{
+			"data":        "listen {{ env \"PORT\" }}\nroot /srv\n",
+			"destination": "local/app.conf",
+		}},
+		"user":    "${var.user}",
+		"enabled": true,
+		"weight":  0.5,
+		"token":   nil,
+	}
+	want := map[string]any{
+		"job": map[string]any{
+			"web": []any{map[string]any{
+				"datacenters": []any{"dc1"},
+				"priority":    int64(50),
+				"meta":        map[string]any{"owner": "platform", "app.version": "1.2"},
+				"group": map[string]any{
+					"app": []any{map[string]any{
+						"count": int64(2),
+						"task":  map[string]any{"server": []any{task}},
+					}},
+				},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected hcl payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload(decoded, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	wantHCL := `job "web" {
+  datacenters = ["dc1"]
+  meta        = {
+    "app.version" = "1.2"
+    owner         = "platform"
+  }
+  priority    = 50
+
+  group "app" {
+    count = 2
+
+    task "server" {
+      driver  = "docker"
+      enabled = true
+      token   = null
+      user    = "${var.user}"
+      weight  = 0.5
+
+      env {
+        PORT = "${NOMAD_PORT_http}"
+      }
+
+      template {
+        data        = <<EOT
+listen {{ env "PORT" }}
+root /srv
+EOT
+        destination = "local/app.conf"
+      }
+    }
+  }
+}
+`
+	if string(encoded) != wantHCL {
+		t.Fatalf("unexpected hcl encoding:\n%s\nwant:\n%s", encoded, wantHCL)
+	}
+
+	again, err := DecodePayload(encoded, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("DecodePayload of encoded hcl returned error: %v", err)
+	}
+	if !reflect.DeepEqual(again, decoded) {
+		t.Fatalf("hcl round trip changed payload: got %#v want %#v", again, decoded)
+	}
+}
+
+func TestDecodeEncodeHCLKeepsObjectListAttributes(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`rules = [
+  { port = 80, "proto.name" = "tcp" },
+  { port = 443 },
+]
+routes = { web = [{ weight = 1 }] }
+
+rule {
+  port = 22
+}
+`)
+
+	decoded, err := DecodePayload(input, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{
+		"rules":  `${[{ port = 80, "proto.name" = "tcp" }, { port = 443 }]}`,
+		"routes": `${{ web = [{ weight = 1 }] }}`,
+		"rule":   []any{map[string]any{"port": int64(22)}},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected hcl payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload(decoded, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	wantHCL := `routes = "${{ web = [{ weight = 1 }] }}"
+rules  = "${[{ port = 80, "proto.name" = "tcp" }, { port = 443 }]}"
+
+rule {
+  port = 22
+}
+`
+	if string(encoded) != wantHCL {
+		t.Fatalf("unexpected hcl encoding:\n%s\nwant:\n%s", encoded, wantHCL)
+	}
+
+	again, err := DecodePayload(encoded, PayloadTypeHCL)
+	if err != nil {
+		t.Fatalf("DecodePayload of encoded hcl returned error: %v", err)
+	}
+	if !reflect.DeepEqual(again, decoded) {
+		t.Fatalf("hcl round trip changed payload: got %#v want %#v", again, decoded)
+	}
+}
+
+func TestDecodeHCLPayloadRejectsMalformedDocuments(t *testing.T) {
+	t.Parallel()
+
+	for name, input := range map[string]string{
+		"unclosed block":      "a {\n  b = 1\n",
+		"duplicate attribute": "a = 1\na = 2\n",
+		"block and attribute": "a = 1\na {}\n",
+		"unterminated string": "a = \"x\n",
+		"unclosed heredoc":    "a = <<EOT\nx\n",
+		"missing value":       "a =\n",
+	} {
+		if _, err := DecodePayload([]byte(input), PayloadTypeHCL); err == nil {
+			t.Fatalf("%s: expected DecodePayload to fail", name)
+		}
+	}
+}
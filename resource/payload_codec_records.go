@@ -0,0 +1,171 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+// CSV and NDJSON payloads are record lists: the payload is an array with one
+// entry per row or line. CSV rows are objects keyed by the header row.
+
+func decodeCSVPayload(data []byte) ([]any, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []any{}, nil
+	}
+	if err != nil {
+		return nil, faults.Invalid("invalid csv payload", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		if name == "" {
+			return nil, faults.Invalid("invalid csv payload", fmt.Errorf("empty column name in header"))
+		}
+		if seen[name] {
+			return nil, faults.Invalid("invalid csv payload", fmt.Errorf("duplicate column %q in header", name))
+		}
+		seen[name] = true
+	}
+
+	rows := []any{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, faults.Invalid("invalid csv payload", err)
+		}
+		row := make(map[string]any, len(header))
+		for idx, name := range header {
+			row[name] = record[idx]
+		}
+		rows = append(rows, row)
+	}
+}
+
+// encodeCSVPayload writes one column per key found in any row, in key order.
+// Cells for keys a row lacks are left empty.
+func encodeCSVPayload(value any) ([]byte, error) {
+	rows, err := recordListPayload(value, PayloadTypeCSV)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]bool{}
+	objects := make([]map[string]any, len(rows))
+	for idx, row := range rows {
+		object, ok := row.(map[string]any)
+		if !ok {
+			return nil, faults.Invalid("failed to encode csv payload", fmt.Errorf("csv row %d must be an object", idx))
+		}
+		for key := range object {
+			columns[key] = true
+		}
+		objects[idx] = object
+	}
+	header := slices.Sorted(maps.Keys(columns))
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if len(header) > 0 {
+		if err := writer.Write(header); err != nil {
+			return nil, faults.Internal("failed to encode csv payload", err)
+		}
+	}
+	for _, object := range objects {
+		record := make([]string, len(header))
+		for idx, name := range header {
+			cell, err := stringifyStructuredTextScalar(object[name], PayloadTypeCSV)
+			if err != nil {
+				return nil, err
+			}
+			record[idx] = cell
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, faults.Internal("failed to encode csv payload", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, faults.Internal("failed to encode csv payload", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeNDJSONPayload(data []byte) ([]any, error) {
+	records := []any{}
+	for idx, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var record any
+		if err := decoder.Decode(&record); err != nil {
+			return nil, faults.Invalid("invalid ndjson payload", fmt.Errorf("line %d: %w", idx+1, err))
+		}
+		if decoder.More() {
+			return nil, faults.Invalid("invalid ndjson payload", fmt.Errorf("line %d: more than one value", idx+1))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func encodeNDJSONPayload(value any) ([]byte, error) {
+	records, err := recordListPayload(value, PayloadTypeNDJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	for _, record := range records {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			return nil, faults.Invalid("failed to encode ndjson payload", err)
+		}
+		buffer.Write(encoded)
+		buffer.WriteByte('\n')
+	}
+	return buffer.Bytes(), nil
+}
+
+func recordListPayload(value any, payloadType string) ([]any, error) {
+	records, ok := value.([]any)
+	if !ok {
+		return nil, faults.Invalid(
+			fmt.Sprintf("failed to encode %s payload", payloadType),
+			fmt.Errorf("%s payload requires an array of records", payloadType),
+		)
+	}
+	return records, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEncodeCSVPayload(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DecodePayload([]byte("name,email,role\nalice,alice@example.com,admin\n\"bob, jr\",bob@example.com,\n"), PayloadTypeCSV)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := []any{
+		map[string]any{"name": "alice", "email": "alice@example.com", "role": "admin"},
+		map[string]any{"name": "bob, jr", "email": "bob@example.com", "role": ""},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected csv payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload([]any{
+		map[string]any{"name": "bob, jr", "port": int64(80)},
+		map[string]any{"name": "alice", "enabled": true},
+	}, PayloadTypeCSV)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	if wantCSV := "enabled,name,port\n,\"bob, jr\",80\ntrue,alice,\n"; string(encoded) != wantCSV {
+		t.Fatalf("unexpected csv encoding: got %q want %q", encoded, wantCSV)
+	}
+
+	for name, value := range map[string]any{
+		"object document": map[string]any{"name": "alice"},
+		"scalar row":      []any{"alice"},
+		"nested cell":     []any{map[string]any{"tags": []any{"a"}}},
+	} {
+		if _, err := EncodePayload(value, PayloadTypeCSV); err == nil {
+			t.Fatalf("%s: expected EncodePayload to fail", name)
+		}
+	}
+	if _, err := DecodePayload([]byte("name,name\na,b\n"), PayloadTypeCSV); err == nil {
+		t.Fatal("expected duplicate csv header to fail")
+	}
+}
+
+func TestDecodeEncodeNDJSONPayload(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DecodePayload([]byte("{\"id\":1,\"name\":\"a\"}\n\n{\"id\":2,\"tags\":[\"x\"]}\n"), PayloadTypeNDJSON)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := []any{
+		map[string]any{"id": int64(1), "name": "a"},
+		map[string]any{"id": int64(2), "tags": []any{"x"}},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected ndjson payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload(decoded, PayloadTypeNDJSON)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	if wantNDJSON := "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"tags\":[\"x\"]}\n"; string(encoded) != wantNDJSON {
+		t.Fatalf("unexpected ndjson encoding: got %q want %q", encoded, wantNDJSON)
+	}
+
+	if _, err := DecodePayload([]byte("{\"id\":1} {\"id\":2}\n"), PayloadTypeNDJSON); err == nil {
+		t.Fatal("expected two values on one ndjson line to fail")
+	}
+}
+
+func TestRecordPayloadDescriptors(t *testing.T) {
+	t.Parallel()
+
+	for value, payloadType := range map[string]string{
+		"application/toml":        PayloadTypeTOML,
+		"text/csv; charset=utf-8": PayloadTypeCSV,
+		"application/x-ndjson":    PayloadTypeNDJSON,
+		"application/jsonl":       PayloadTypeNDJSON,
+		"jsonl":                   PayloadTypeNDJSON,
+		"toml":                    PayloadTypeTOML,
+	} {
+		descriptor, ok := PayloadDescriptorForContentType(value)
+		if !ok || descriptor.PayloadType != payloadType {
+			t.Fatalf("expected %q to map to %q, got %#v", value, payloadType, descriptor)
+		}
+	}
+	for extension, payloadType := range map[string]string{
+		".toml":   PayloadTypeTOML,
+		".csv":    PayloadTypeCSV,
+		".ndjson": PayloadTypeNDJSON,
+		".jsonl":  PayloadTypeNDJSON,
+	} {
+		descriptor, ok := PayloadDescriptorForExtension(extension)
+		if !ok || descriptor.PayloadType != payloadType {
+			t.Fatalf("expected %q to map to %q, got %#v", extension, payloadType, descriptor)
+		}
+	}
+}
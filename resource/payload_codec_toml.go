@@ -0,0 +1,809 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+// TOML dates and times have no counterpart in the payload tree, so a date or
+// time literal decodes to an object whose only key is "#datetime", holding the
+// literal as written, and is written back unquoted. Strings are always quoted.
+const TOMLDateTimeKey = "#datetime"
+
+var (
+	tomlDateTimePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[+-]\d{2}:\d{2})?)?$`)
+	tomlTimePattern     = regexp.MustCompile(`^\d{2}:\d{2}(:\d{2}(\.\d+)?)?$`)
+	tomlBareKeyPattern  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+type tomlParser struct {
+	data []byte
+	pos  int
+	root map[string]any
+	// headers holds the table paths opened by a [table] header, and arrays
+	// the paths of arrays of tables, so neither is redefined.
+	headers map[string]bool
+	arrays  map[string]bool
+}
+
+func decodeTOMLPayload(data []byte) (map[string]any, error) {
+	parser := &tomlParser{
+		data:    data,
+		root:    map[string]any{},
+		headers: map[string]bool{},
+		arrays:  map[string]bool{},
+	}
+	if err := parser.parse(); err != nil {
+		return nil, faults.Invalid("invalid toml payload", err)
+	}
+	return parser.root, nil
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	line := 1 + bytes.Count(p.data[:min(p.pos, len(p.data))], []byte("\n"))
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *tomlParser) hasPrefix(prefix string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(prefix))
+}
+
+// skipSpace skips blanks and comments, and newlines too when newlines is set.
+func (p *tomlParser) skipSpace(newlines bool) {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			if !newlines {
+				return
+			}
+			p.pos++
+		case '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace(false)
+	if !p.eof() && p.peek() != '\n' {
+		return p.errorf("unexpected %q at end of line", p.peek())
+	}
+	return nil
+}
+
+func (p *tomlParser) parse() error {
+	current := p.root
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil
+		}
+
+		if p.peek() == '[' {
+			table, err := p.parseHeader()
+			if err != nil {
+				return err
+			}
+			current = table
+		} else if err := p.parseKeyValue(current); err != nil {
+			return err
+		}
+		if err := p.expectLineEnd(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *tomlParser) parseHeader() (map[string]any, error) {
+	arrayTable := p.hasPrefix("[[")
+	if arrayTable {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	keys, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	closing := "]"
+	if arrayTable {
+		closing = "]]"
+	}
+	p.skipSpace(false)
+	if !p.hasPrefix(closing) {
+		return nil, p.errorf("expected %q after table header", closing)
+	}
+	p.pos += len(closing)
+
+	table := p.root
+	path := ""
+	for _, key := range keys[:len(keys)-1] {
+		next, nextPath, err := p.descend(table, path, key)
+		if err != nil {
+			return nil, err
+		}
+		table, path = next, nextPath
+	}
+
+	last := keys[len(keys)-1]
+	path += "\x00" + last
+	existing, found := table[last]
+	if arrayTable {
+		if !found {
+			created := map[string]any{}
+			table[last] = []any{created}
+			p.arrays[path] = true
+			return created, nil
+		}
+		items, ok := existing.([]any)
+		if !ok || !p.arrays[path] {
+			return nil, p.errorf("key %q is not an array of tables", last)
+		}
+		created := map[string]any{}
+		table[last] = append(items, created)
+		return created, nil
+	}
+
+	if p.headers[path] {
+		return nil, p.errorf("table %q is defined more than once", strings.Join(keys, "."))
+	}
+	p.headers[path] = true
+	if !found {
+		created := map[string]any{}
+		table[last] = created
+		return created, nil
+	}
+	existingTable, ok := existing.(map[string]any)
+	if !ok {
+		return nil, p.errorf("key %q is not a table", last)
+	}
+	return existingTable, nil
+}
+
+// descend returns the table stored under key, creating it when missing; an
+// array of tables resolves to its last element.
+func (p *tomlParser) descend(table map[string]any, path string, key string) (map[string]any, string, error) {
+	path += "\x00" + key
+	switch typed := table[key].(type) {
+	case nil:
+		created := map[string]any{}
+		table[key] = created
+		return created, path, nil
+	case map[string]any:
+		return typed, path, nil
+	case []any:
+		if p.arrays[path] && len(typed) > 0 {
+			last, _ := typed[len(typed)-1].(map[string]any)
+			return last, path + "\x00" + strconv.Itoa(len(typed)-1), nil
+		}
+	}
+	return nil, "", p.errorf("key %q is not a table", key)
+}
+
+func (p *tomlParser) parseKeyValue(table map[string]any) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace(false)
+	if p.peek() != '=' {
+		return p.errorf("expected %q after key %q", '=', strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace(false)
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := table[key]
+		if !ok {
+			created := map[string]any{}
+			table[key] = created
+			table = created
+			continue
+		}
+		nested, ok := next.(map[string]any)
+		if !ok {
+			return p.errorf("key %q is not a table", key)
+		}
+		table = nested
+	}
+	last := keys[len(keys)-1]
+	if _, exists := table[last]; exists {
+		return p.errorf("duplicate key %q", strings.Join(keys, "."))
+	}
+	table[last] = value
+	return nil
+}
+
+func (p *tomlParser) parseKey() ([]string, error) {
+	keys := []string{}
+	for {
+		p.skipSpace(false)
+		var key string
+		switch p.peek() {
+		case '"':
+			quoted, err := p.parseBasicString()
+			if err != nil {
+				return nil, err
+			}
+			key = quoted
+		case '\'':
+			quoted, err := p.parseLiteralString()
+			if err != nil {
+				return nil, err
+			}
+			key = quoted
+		default:
+			start := p.pos
+			for !p.eof() && isTOMLBareKeyByte(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("expected key, got %q", p.peek())
+			}
+			key = string(p.data[start:p.pos])
+		}
+		keys = append(keys, key)
+
+		p.skipSpace(false)
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isTOMLBareKeyByte(value byte) bool {
+	return value == '_' || value == '-' ||
+		(value >= 'a' && value <= 'z') ||
+		(value >= 'A' && value <= 'Z') ||
+		(value >= '0' && value <= '9')
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch {
+	case p.eof():
+		return nil, p.errorf("missing value")
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineBasicString()
+	case p.hasPrefix(`'''`):
+		return p.parseMultilineLiteralString()
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	}
+	return p.parseBareValue()
+}
+
+func (p *tomlParser) parseArray() (any, error) {
+	p.pos++
+	items := []any{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, p.errorf("missing closing %q", ']')
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return items, nil
+		}
+
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipSpace(true)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected %q or %q in array", ',', ']')
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (any, error) {
+	p.pos++
+	table := map[string]any{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, p.errorf("missing closing %q", '}')
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return table, nil
+		}
+
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace(true)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+		default:
+			return nil, p.errorf("expected %q or %q in inline table", ',', '}')
+		}
+	}
+}
+
+func (p *tomlParser) parseBareValue() (any, error) {
+	start := p.pos
+	for !p.eof() && isTOMLBareValueByte(p.peek()) {
+		p.pos++
+	}
+	// A local date followed by a space may continue into its time.
+	if p.pos-start == 10 && p.peek() == ' ' && p.pos+3 < len(p.data) && p.data[p.pos+3] == ':' {
+		p.pos++
+		for !p.eof() && isTOMLBareValueByte(p.peek()) {
+			p.pos++
+		}
+	}
+	token := string(p.data[start:p.pos])
+
+	switch {
+	case token == "":
+		return nil, p.errorf("unexpected %q", p.peek())
+	case token == "true":
+		return true, nil
+	case token == "false":
+		return false, nil
+	case isTOMLDateTimeLiteral(token):
+		return map[string]any{TOMLDateTimeKey: token}, nil
+	}
+
+	trimmed := strings.TrimLeft(token, "+-")
+	switch trimmed {
+	case "inf", "nan":
+		return nil, p.errorf("non-finite number %q is not supported", token)
+	}
+
+	digits := strings.ReplaceAll(token, "_", "")
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if rest, ok := strings.CutPrefix(digits, prefix); ok {
+			value, err := strconv.ParseInt(rest, base, 64)
+			if err != nil {
+				return nil, p.errorf("invalid integer %q", token)
+			}
+			return value, nil
+		}
+	}
+	if !strings.ContainsAny(digits, ".eE") {
+		value, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid value %q", token)
+		}
+		return value, nil
+	}
+	value, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return nil, p.errorf("invalid value %q", token)
+	}
+	return value, nil
+}
+
+func isTOMLBareValueByte(value byte) bool {
+	return isTOMLBareKeyByte(value) || value == '+' || value == '.' || value == ':'
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+	var builder strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		switch p.peek() {
+		case '"':
+			p.pos++
+			return builder.String(), nil
+		case '\\':
+			if err := p.parseEscape(&builder); err != nil {
+				return "", err
+			}
+		default:
+			builder.WriteByte(p.peek())
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) parseMultilineBasicString() (string, error) {
+	p.pos += 3
+	p.skipNewline()
+	var builder strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated multi-line string")
+		}
+		if p.hasPrefix(`"""`) {
+			quotes := p.countQuotes('"')
+			builder.WriteString(strings.Repeat(`"`, quotes-3))
+			p.pos += quotes
+			return builder.String(), nil
+		}
+		if p.peek() != '\\' {
+			builder.WriteByte(p.peek())
+			p.pos++
+			continue
+		}
+
+		// A backslash ending a line trims the line break and the
+		// whitespace that follows it.
+		lineEnd := p.pos + 1
+		for lineEnd < len(p.data) && (p.data[lineEnd] == ' ' || p.data[lineEnd] == '\t' || p.data[lineEnd] == '\r') {
+			lineEnd++
+		}
+		if lineEnd < len(p.data) && p.data[lineEnd] == '\n' {
+			p.pos = lineEnd
+			for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) >= 0 {
+				p.pos++
+			}
+			continue
+		}
+		if err := p.parseEscape(&builder); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	start := p.pos
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		if p.peek() == '\'' {
+			value := string(p.data[start:p.pos])
+			p.pos++
+			return value, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *tomlParser) parseMultilineLiteralString() (string, error) {
+	p.pos += 3
+	p.skipNewline()
+	start := p.pos
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated multi-line string")
+		}
+		if p.hasPrefix(`'''`) {
+			quotes := p.countQuotes('\'')
+			value := string(p.data[start:p.pos]) + strings.Repeat("'", quotes-3)
+			p.pos += quotes
+			return value, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *tomlParser) skipNewline() {
+	if p.hasPrefix("\r\n") {
+		p.pos += 2
+	} else if p.peek() == '\n' {
+		p.pos++
+	}
+}
+
+// countQuotes counts the closing delimiter run, which may absorb up to two
+// quotes that end the string content.
+func (p *tomlParser) countQuotes(quote byte) int {
+	count := 0
+	for p.pos+count < len(p.data) && p.data[p.pos+count] == quote && count < 5 {
+		count++
+	}
+	return count
+}
+
+func (p *tomlParser) parseEscape(builder *strings.Builder) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated escape sequence")
+	}
+	escape := p.peek()
+	p.pos++
+	switch escape {
+	case 'b':
+		builder.WriteByte('\b')
+	case 't':
+		builder.WriteByte('\t')
+	case 'n':
+		builder.WriteByte('\n')
+	case 'f':
+		builder.WriteByte('\f')
+	case 'r':
+		builder.WriteByte('\r')
+	case 'e':
+		builder.WriteByte(0x1b)
+	case '"', '\\':
+		builder.WriteByte(escape)
+	case 'u', 'U':
+		size := 4
+		if escape == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(string(p.data[p.pos:p.pos+size]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape")
+		}
+		builder.WriteRune(rune(code))
+		p.pos += size
+	default:
+		return p.errorf("invalid escape sequence \\%c", escape)
+	}
+	return nil
+}
+
+// encodeTOMLPayload writes plain keys first, then sub-tables and arrays of
+// tables, each group in key order. Tables holding only sub-tables are left
+// implicit.
+func encodeTOMLPayload(value any) ([]byte, error) {
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, faults.Invalid("failed to encode toml payload", fmt.Errorf("toml payload requires an object"))
+	}
+
+	var buffer bytes.Buffer
+	if err := writeTOMLTable(&buffer, nil, root); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeTOMLTable(buffer *bytes.Buffer, path []string, table map[string]any) error {
+	valueKeys := []string{}
+	tableKeys := []string{}
+	for _, key := range slices.Sorted(maps.Keys(table)) {
+		if isTOMLTable(table[key]) || isTOMLArrayOfTables(table[key]) {
+			tableKeys = append(tableKeys, key)
+			continue
+		}
+		valueKeys = append(valueKeys, key)
+	}
+
+	for _, key := range valueKeys {
+		buffer.WriteString(tomlKey(key))
+		buffer.WriteString(" = ")
+		if err := writeTOMLValue(buffer, table[key], true); err != nil {
+			return err
+		}
+		buffer.WriteByte('\n')
+	}
+
+	for _, key := range tableKeys {
+		childPath := append(slices.Clone(path), key)
+		header := make([]string, len(childPath))
+		for idx, segment := range childPath {
+			header[idx] = tomlKey(segment)
+		}
+
+		if child, isTable := table[key].(map[string]any); isTable {
+			if tomlTableNeedsHeader(child) {
+				writeTOMLHeader(buffer, "["+strings.Join(header, ".")+"]")
+			}
+			if err := writeTOMLTable(buffer, childPath, child); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, item := range table[key].([]any) {
+			writeTOMLHeader(buffer, "[["+strings.Join(header, ".")+"]]")
+			if err := writeTOMLTable(buffer, childPath, item.(map[string]any)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTOMLHeader(buffer *bytes.Buffer, header string) {
+	if buffer.Len() > 0 {
+		buffer.WriteByte('\n')
+	}
+	buffer.WriteString(header)
+	buffer.WriteByte('\n')
+}
+
+func tomlTableNeedsHeader(table map[string]any) bool {
+	if len(table) == 0 {
+		return true
+	}
+	for _, value := range table {
+		if !isTOMLTable(value) && !isTOMLArrayOfTables(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTOMLArrayOfTables(value any) bool {
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !isTOMLTable(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTOMLTable(value any) bool {
+	table, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, isDateTime := tomlDateTime(table)
+	return !isDateTime
+}
+
+// tomlDateTime returns the literal of a decoded date or time object.
+func tomlDateTime(value map[string]any) (string, bool) {
+	if len(value) != 1 {
+		return "", false
+	}
+	literal, ok := value[TOMLDateTimeKey].(string)
+	if !ok || !isTOMLDateTimeLiteral(literal) {
+		return "", false
+	}
+	return literal, true
+}
+
+func isTOMLDateTimeLiteral(value string) bool {
+	return tomlDateTimePattern.MatchString(value) || tomlTimePattern.MatchString(value)
+}
+
+func writeTOMLValue(buffer *bytes.Buffer, value any, multiline bool) error {
+	switch typed := value.(type) {
+	case nil:
+		return faults.Invalid("failed to encode toml payload", fmt.Errorf("toml payloads do not support null values"))
+	case bool:
+		buffer.WriteString(strconv.FormatBool(typed))
+	case int64:
+		buffer.WriteString(strconv.FormatInt(typed, 10))
+	case float64:
+		text := strconv.FormatFloat(typed, 'f', -1, 64)
+		if !strings.ContainsAny(text, ".eE") {
+			text += ".0"
+		}
+		buffer.WriteString(text)
+	case string:
+		switch {
+		case multiline && strings.Contains(typed, "\n"):
+			buffer.WriteString(`"""` + "\n")
+			buffer.WriteString(escapeTOMLString(typed, true))
+			buffer.WriteString(`"""`)
+		default:
+			buffer.WriteString(`"` + escapeTOMLString(typed, false) + `"`)
+		}
+	case []any:
+		buffer.WriteByte('[')
+		for idx, item := range typed {
+			if idx > 0 {
+				buffer.WriteString(", ")
+			}
+			if err := writeTOMLValue(buffer, item, false); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	case map[string]any:
+		if literal, ok := tomlDateTime(typed); ok {
+			buffer.WriteString(literal)
+			return nil
+		}
+		if len(typed) == 0 {
+			buffer.WriteString("{}")
+			return nil
+		}
+		buffer.WriteString("{ ")
+		for idx, key := range slices.Sorted(maps.Keys(typed)) {
+			if idx > 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteString(tomlKey(key))
+			buffer.WriteString(" = ")
+			if err := writeTOMLValue(buffer, typed[key], false); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString(" }")
+	default:
+		return faults.Invalid("failed to encode toml payload", fmt.Errorf("unsupported toml value %T", value))
+	}
+	return nil
+}
+
+func tomlKey(key string) string {
+	if tomlBareKeyPattern.MatchString(key) {
+		return key
+	}
+	return `"` + escapeTOMLString(key, false) + `"`
+}
+
+func escapeTOMLString(value string, multiline bool) string {
+	var builder strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r == '\n' && multiline:
+			builder.WriteByte('\n')
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&builder, `\u%04X`, r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
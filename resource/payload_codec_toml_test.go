@@ -0,0 +1,201 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEncodeTOMLPayload(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`# service config
+title = "edge"
+port = 8_080
+ratio = 0.75
+enabled = true
+released = 1979-05-27T07:32:00Z
+tags = [
+  "a",
+  'b', # literal
+]
+owner = { name = "platform", contact.email = "ops@example.com" }
+
+[database]
+hosts = ["db1", "db2"]
+motd = """
+Welcome,
+  operator.
+"""
+
+[database.pool]
+size = 0x10
+
+[[routes]]
+path = "/api"
+
+[[routes]]
+path = "/static"
+"cache.ttl" = 60
+`)
+
+	decoded, err := DecodePayload(input, PayloadTypeTOML)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{
+		"title":    "edge",
+		"port":     int64(8080),
+		"ratio":    0.75,
+		"enabled":  true,
+		"released": map[string]any{TOMLDateTimeKey: "1979-05-27T07:32:00Z"},
+		"tags":     []any{"a", "b"},
+		"owner": map[string]any{
+			"name":    "platform",
+			"contact": map[string]any{"email": "ops@example.com"},
+		},
+		"database": map[string]any{
+			"hosts": []any{"db1", "db2"},
+			"motd":  "Welcome,\n  operator.\n",
+			"pool":  map[string]any{"size": int64(16)},
+		},
+		"routes": []any{
+			map[string]any{"path": "/api"},
+			map[string]any{"path": "/static", "cache.ttl": int64(60)},
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected toml payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload(decoded, PayloadTypeTOML)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	wantTOML := `enabled = true
+port = 8080
+ratio = 0.75
+released = 1979-05-27T07:32:00Z
+tags = ["a", "b"]
+title = "edge"
+
+[database]
+hosts = ["db1", "db2"]
+motd = """
+Welcome,
+  operator.
+"""
+
+[database.pool]
+size = 16
+
+[owner]
+name = "platform"
+
+[owner.contact]
+email = "ops@example.com"
+
+[[routes]]
+path = "/api"
+
+[[routes]]
+"cache.ttl" = 60
+path = "/static"
+`
+	if string(encoded) != wantTOML {
+		t.Fatalf("unexpected toml encoding:\n%s\nwant:\n%s", encoded, wantTOML)
+	}
+
+	again, err := DecodePayload(encoded, PayloadTypeTOML)
+	if err != nil {
+		t.Fatalf("DecodePayload of encoded toml returned error: %v", err)
+	}
+	if !reflect.DeepEqual(again, decoded) {
+		t.Fatalf("toml round trip changed payload: got %#v want %#v", again, decoded)
+	}
+}
+
+func TestTOMLPayloadQuotesDateShapedStrings(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`day = 2024-01-01
+at = 10:00:00
+date = "2024-01-01"
+invalid = "9999-99-99"
+short = "10:00"
+spaced = "2024-01-01 10:00"
+`)
+
+	decoded, err := DecodePayload(input, PayloadTypeTOML)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{
+		"day":     map[string]any{TOMLDateTimeKey: "2024-01-01"},
+		"at":      map[string]any{TOMLDateTimeKey: "10:00:00"},
+		"date":    "2024-01-01",
+		"invalid": "9999-99-99",
+		"short":   "10:00",
+		"spaced":  "2024-01-01 10:00",
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected toml payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayload(decoded, PayloadTypeTOML)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	wantTOML := `at = 10:00:00
+date = "2024-01-01"
+day = 2024-01-01
+invalid = "9999-99-99"
+short = "10:00"
+spaced = "2024-01-01 10:00"
+`
+	if string(encoded) != wantTOML {
+		t.Fatalf("unexpected toml encoding:\n%s\nwant:\n%s", encoded, wantTOML)
+	}
+
+	again, err := DecodePayload(encoded, PayloadTypeTOML)
+	if err != nil {
+		t.Fatalf("DecodePayload of encoded toml returned error: %v", err)
+	}
+	if !reflect.DeepEqual(again, decoded) {
+		t.Fatalf("toml round trip changed payload: got %#v want %#v", again, decoded)
+	}
+}
+
+func TestTOMLPayloadRejectsInvalidDocuments(t *testing.T) {
+	t.Parallel()
+
+	for name, input := range map[string]string{
+		"duplicate key":       "a = 1\na = 2\n",
+		"duplicate table":     "[a]\nb = 1\n[a]\nc = 2\n",
+		"table over value":    "a = 1\n[a]\n",
+		"unterminated string": "a = \"x\n",
+		"missing value":       "a =\n",
+		"trailing garbage":    "a = 1 b\n",
+	} {
+		if _, err := DecodePayload([]byte(input), PayloadTypeTOML); err == nil {
+			t.Fatalf("%s: expected DecodePayload to fail", name)
+		}
+	}
+
+	if _, err := EncodePayload(map[string]any{"a": nil}, PayloadTypeTOML); err == nil {
+		t.Fatal("expected toml encoding of null to fail")
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/crmarques/declarest/faults"
+)
+
+// XML payloads map to a canonical tree: the document is an object with one
+// key, the root element name. An element without attributes or child
+// elements is its text; any other element is an object whose attributes are
+// "@name" keys, whose non-blank text is "#text", and whose child elements are
+// keyed by name, with repeated elements collected into arrays. Prefixed names
+// and xmlns declarations are kept exactly as written.
+const (
+	XMLAttributePrefix = "@"
+	XMLTextKey         = "#text"
+)
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8"?>`
+
+var (
+	xmlTextEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	xmlAttributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\r", "&#xD;", "\n", "&#xA;", "\t", "&#x9;")
+)
+
+type xmlElement struct {
+	name       string
+	attributes map[string]any
+	children   map[string][]any
+	text       strings.Builder
+}
+
+func decodeXMLPayload(data []byte) (map[string]any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var stack []*xmlElement
+	var root map[string]any
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, faults.Invalid("invalid xml payload", err)
+		}
+
+		switch typed := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && root != nil {
+				return nil, faults.Invalid("invalid xml payload", fmt.Errorf("multiple root elements"))
+			}
+			element := &xmlElement{
+				name:       xmlName(typed.Name),
+				attributes: make(map[string]any, len(typed.Attr)),
+				children:   map[string][]any{},
+			}
+			for _, attr := range typed.Attr {
+				element.attributes[XMLAttributePrefix+xmlName(attr.Name)] = attr.Value
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, faults.Invalid("invalid xml payload", fmt.Errorf("unexpected end element %q", xmlName(typed.Name)))
+			}
+			element := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if element.name != xmlName(typed.Name) {
+				return nil, faults.Invalid(
+					"invalid xml payload",
+					fmt.Errorf("element %q closed by %q", element.name, xmlName(typed.Name)),
+				)
+			}
+
+			value := element.value()
+			if len(stack) == 0 {
+				root = map[string]any{element.name: value}
+				continue
+			}
+			parent := stack[len(stack)-1]
+			parent.children[element.name] = append(parent.children[element.name], value)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(typed)
+			} else if strings.TrimSpace(string(typed)) != "" {
+				return nil, faults.Invalid("invalid xml payload", fmt.Errorf("text outside the root element"))
+			}
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, faults.Invalid("invalid xml payload", fmt.Errorf("element %q is not closed", stack[len(stack)-1].name))
+	}
+	if root == nil {
+		return nil, faults.Invalid("invalid xml payload", fmt.Errorf("missing root element"))
+	}
+	return root, nil
+}
+
+func (e *xmlElement) value() any {
+	text := e.text.String()
+	if len(e.attributes) == 0 && len(e.children) == 0 {
+		return text
+	}
+
+	value := make(map[string]any, len(e.attributes)+len(e.children)+1)
+	maps.Copy(value, e.attributes)
+	for name, items := range e.children {
+		if len(items) == 1 {
+			value[name] = items[0]
+			continue
+		}
+		value[name] = items
+	}
+	if trimmed := strings.TrimSpace(text); trimmed != "" {
+		if len(e.children) == 0 {
+			value[XMLTextKey] = text
+		} else {
+			value[XMLTextKey] = trimmed
+		}
+	}
+	return value
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// encodeXMLPayload writes the canonical tree back as XML. Attributes and
+// child elements are written in key order, so documents whose schema fixes
+// the order of different sibling elements may need it restored by transforms.
+func encodeXMLPayload(value any, pretty bool) ([]byte, error) {
+	root, ok := value.(map[string]any)
+	if !ok || len(root) != 1 {
+		return nil, faults.Invalid("failed to encode xml payload", fmt.Errorf("xml payload requires an object with one root element"))
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(xmlHeader)
+	if pretty {
+		buffer.WriteByte('\n')
+	}
+	for name, element := range root {
+		if _, isArray := element.([]any); isArray {
+			return nil, faults.Invalid("failed to encode xml payload", fmt.Errorf("xml root element %q must not be an array", name))
+		}
+		if err := writeXMLElement(&buffer, name, element, pretty, 0); err != nil {
+			return nil, err
+		}
+	}
+	if pretty {
+		buffer.WriteByte('\n')
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeXMLElement(buffer *bytes.Buffer, name string, value any, pretty bool, depth int) error {
+	if err := validateXMLName(name); err != nil {
+		return err
+	}
+
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		text, err := stringifyStructuredTextScalar(value, PayloadTypeXML)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString("<" + name + ">")
+		buffer.WriteString(xmlTextEscaper.Replace(text))
+		buffer.WriteString("</" + name + ">")
+		return nil
+	}
+
+	buffer.WriteString("<" + name)
+	childNames := make([]string, 0, len(object))
+	for _, key := range slices.Sorted(maps.Keys(object)) {
+		if key == XMLTextKey {
+			continue
+		}
+		attributeName, isAttribute := strings.CutPrefix(key, XMLAttributePrefix)
+		if !isAttribute {
+			childNames = append(childNames, key)
+			continue
+		}
+		if err := validateXMLName(attributeName); err != nil {
+			return err
+		}
+		text, err := stringifyStructuredTextScalar(object[key], PayloadTypeXML)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(" " + attributeName + `="` + xmlAttributeEscaper.Replace(text) + `"`)
+	}
+
+	text, err := stringifyStructuredTextScalar(object[XMLTextKey], PayloadTypeXML)
+	if err != nil {
+		return err
+	}
+	if len(childNames) == 0 && text == "" {
+		buffer.WriteString("/>")
+		return nil
+	}
+	buffer.WriteByte('>')
+	if len(childNames) == 0 {
+		buffer.WriteString(xmlTextEscaper.Replace(text))
+		buffer.WriteString("</" + name + ">")
+		return nil
+	}
+
+	if text != "" {
+		writeXMLIndent(buffer, pretty, depth+1)
+		buffer.WriteString(xmlTextEscaper.Replace(text))
+	}
+	for _, childName := range childNames {
+		items, isArray := object[childName].([]any)
+		if !isArray {
+			items = []any{object[childName]}
+		}
+		for _, item := range items {
+			if _, nested := item.([]any); nested {
+				return faults.Invalid("failed to encode xml payload", fmt.Errorf("xml element %q must not contain nested arrays", childName))
+			}
+			writeXMLIndent(buffer, pretty, depth+1)
+			if err := writeXMLElement(buffer, childName, item, pretty, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	writeXMLIndent(buffer, pretty, depth)
+	buffer.WriteString("</" + name + ">")
+	return nil
+}
+
+func writeXMLIndent(buffer *bytes.Buffer, pretty bool, depth int) {
+	if !pretty {
+		return
+	}
+	buffer.WriteByte('\n')
+	buffer.WriteString(strings.Repeat("  ", depth))
+}
+
+func validateXMLName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\r\n<>&\"'=/") {
+		return faults.Invalid("failed to encode xml payload", fmt.Errorf("invalid xml name %q", name))
+	}
+	return nil
+}
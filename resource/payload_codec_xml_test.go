@@ -0,0 +1,153 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEncodeXMLPayload(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<joblist xmlns:rd="urn:rundeck">
+  <job id="a1" rd:scope="project">
+    <name>deploy &amp; verify</name>
+    <option required="true">env</option>
+    <option>region</option>
+    <empty/>
+  </job>
+</joblist>
+`)
+
+	decoded, err := DecodePayload(input, PayloadTypeXML)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{
+		"joblist": map[string]any{
+			"@xmlns:rd": "urn:rundeck",
+			"job": map[string]any{
+				"@id":       "a1",
+				"@rd:scope": "project",
+				"name":      "deploy & verify",
+				"option": []any{
+					map[string]any{"@required": "true", "#text": "env"},
+					"region",
+				},
+				"empty": "",
+			},
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected xml payload: got %#v want %#v", decoded, want)
+	}
+
+	encoded, err := EncodePayloadPretty(decoded, PayloadTypeXML)
+	if err != nil {
+		t.Fatalf("EncodePayloadPretty returned error: %v", err)
+	}
+	wantXML := `<?xml version="1.0" encoding="UTF-8"?>
+<joblist xmlns:rd="urn:rundeck">
+  <job id="a1" rd:scope="project">
+    <empty></empty>
+    <name>deploy &amp; verify</name>
+    <option required="true">env</option>
+    <option>region</option>
+  </job>
+</joblist>
+`
+	if string(encoded) != wantXML {
+		t.Fatalf("unexpected xml encoding:\n%s\nwant:\n%s", encoded, wantXML)
+	}
+
+	again, err := DecodePayload(encoded, PayloadTypeXML)
+	if err != nil {
+		t.Fatalf("DecodePayload of encoded xml returned error: %v", err)
+	}
+	if !reflect.DeepEqual(again, decoded) {
+		t.Fatalf("xml round trip changed payload: got %#v want %#v", again, decoded)
+	}
+}
+
+func TestDecodeXMLPayloadMixedContent(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := DecodePayload([]byte(`<note lang="en">  padded  </note>`), PayloadTypeXML)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want := map[string]any{"note": map[string]any{"@lang": "en", "#text": "  padded  "}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected xml payload: got %#v want %#v", decoded, want)
+	}
+
+	decoded, err = DecodePayload([]byte(`<note>intro<b>bold</b></note>`), PayloadTypeXML)
+	if err != nil {
+		t.Fatalf("DecodePayload returned error: %v", err)
+	}
+	want = map[string]any{"note": map[string]any{"#text": "intro", "b": "bold"}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("unexpected xml payload: got %#v want %#v", decoded, want)
+	}
+}
+
+func TestDecodeXMLPayloadRejectsMalformedDocuments(t *testing.T) {
+	t.Parallel()
+
+	for name, input := range map[string]string{
+		"multiple roots": `<a/><b/>`,
+		"unclosed":       `<a><b></b>`,
+		"mismatched":     `<a></b>`,
+		"text outside":   `text<a/>`,
+		"empty":          ``,
+	} {
+		if _, err := DecodePayload([]byte(input), PayloadTypeXML); err == nil {
+			t.Fatalf("%s: expected DecodePayload to fail", name)
+		}
+	}
+}
+
+func TestEncodeXMLPayloadRejectsInvalidTrees(t *testing.T) {
+	t.Parallel()
+
+	for name, value := range map[string]any{
+		"array document": []any{"a"},
+		"two roots":      map[string]any{"a": "1", "b": "2"},
+		"array root":     map[string]any{"a": []any{"1", "2"}},
+		"nested arrays":  map[string]any{"a": map[string]any{"b": []any{[]any{"1"}}}},
+		"invalid name":   map[string]any{"a b": "1"},
+	} {
+		if _, err := EncodePayload(value, PayloadTypeXML); err == nil {
+			t.Fatalf("%s: expected EncodePayload to fail", name)
+		}
+	}
+}
+
+func TestEncodeXMLPayloadCompact(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := EncodePayload(map[string]any{
+		"user": map[string]any{"@id": int64(7), "name": "a<b", "roles": map[string]any{}},
+	}, PayloadTypeXML)
+	if err != nil {
+		t.Fatalf("EncodePayload returned error: %v", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?><user id="7"><name>a&lt;b</name><roles/></user>`
+	if string(encoded) != want {
+		t.Fatalf("unexpected xml encoding: got %q want %q", encoded, want)
+	}
+}
@@ -120,6 +120,16 @@ func PayloadDescriptorForContentType(value string) (PayloadDescriptor, bool) {
 		return canonicalDescriptor(PayloadTypeINI), true
 	case PayloadTypeProperties:
 		return canonicalDescriptor(PayloadTypeProperties), true
+	case PayloadTypeTOML:
+		return canonicalDescriptor(PayloadTypeTOML), true
+	case PayloadTypeCSV:
+		return canonicalDescriptor(PayloadTypeCSV), true
+	case PayloadTypeNDJSON, "jsonl":
+		return canonicalDescriptor(PayloadTypeNDJSON), true
+	case PayloadTypeForm:
+		return canonicalDescriptor(PayloadTypeForm), true
+	case PayloadTypeMultipart:
+		return canonicalDescriptor(PayloadTypeMultipart), true
 	case PayloadTypeText, "txt":
 		return canonicalDescriptor(PayloadTypeText), true
 	case PayloadTypeBinary, PayloadTypeOctetStream:
@@ -136,10 +146,18 @@ func PayloadDescriptorForContentType(value string) (PayloadDescriptor, bool) {
 		return PayloadDescriptor{PayloadType: PayloadTypeINI, MediaType: normalized, Extension: ".ini"}, true
 	case "application/properties", "text/properties", "text/x-java-properties":
 		return PayloadDescriptor{PayloadType: PayloadTypeProperties, MediaType: normalized, Extension: ".properties"}, true
+	case "application/toml", "text/toml":
+		return PayloadDescriptor{PayloadType: PayloadTypeTOML, MediaType: normalized, Extension: ".toml"}, true
+	case "text/csv", "application/csv":
+		return PayloadDescriptor{PayloadType: PayloadTypeCSV, MediaType: normalized, Extension: ".csv"}, true
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return PayloadDescriptor{PayloadType: PayloadTypeNDJSON, MediaType: normalized, Extension: ".ndjson"}, true
+	case "application/x-www-form-urlencoded":
+		return PayloadDescriptor{PayloadType: PayloadTypeForm, MediaType: normalized, Extension: ".form"}, true
+	case "multipart/form-data":
+		return PayloadDescriptor{PayloadType: PayloadTypeMultipart, MediaType: normalized, Extension: ".multipart"}, true
 	case "text/plain":
 		return PayloadDescriptor{PayloadType: PayloadTypeText, MediaType: normalized, Extension: ".txt"}, true
-	case "text/csv":
-		return PayloadDescriptor{PayloadType: PayloadTypeText, MediaType: normalized, Extension: ".csv"}, true
 	case "text/html":
 		return PayloadDescriptor{PayloadType: PayloadTypeText, MediaType: normalized, Extension: ".html"}, true
 	case "text/css":
@@ -179,10 +197,18 @@ func PayloadDescriptorForExtension(extension string) (PayloadDescriptor, bool) {
 		return PayloadDescriptor{PayloadType: PayloadTypeINI, MediaType: "application/ini", Extension: normalized}, true
 	case ".properties", ".props":
 		return PayloadDescriptor{PayloadType: PayloadTypeProperties, MediaType: "text/x-java-properties", Extension: normalized}, true
+	case ".toml":
+		return PayloadDescriptor{PayloadType: PayloadTypeTOML, MediaType: "application/toml", Extension: normalized}, true
+	case ".csv":
+		return PayloadDescriptor{PayloadType: PayloadTypeCSV, MediaType: "text/csv", Extension: normalized}, true
+	case ".ndjson", ".jsonl":
+		return PayloadDescriptor{PayloadType: PayloadTypeNDJSON, MediaType: "application/x-ndjson", Extension: normalized}, true
+	case ".form":
+		return PayloadDescriptor{PayloadType: PayloadTypeForm, MediaType: "application/x-www-form-urlencoded", Extension: normalized}, true
+	case ".multipart":
+		return PayloadDescriptor{PayloadType: PayloadTypeMultipart, MediaType: "multipart/form-data", Extension: normalized}, true
 	case ".txt", ".text":
 		return PayloadDescriptor{PayloadType: PayloadTypeText, MediaType: "text/plain", Extension: normalized}, true
-	case ".csv":
-		return PayloadDescriptor{PayloadType: PayloadTypeText, MediaType: "text/csv", Extension: normalized}, true
 	case ".html", ".htm":
 		return PayloadDescriptor{PayloadType: PayloadTypeText, MediaType: "text/html", Extension: normalized}, true
 	case ".css":
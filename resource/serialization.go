@@ -0,0 +1,234 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+
+	"github.com/crmarques/declarest/faults"
+	"go.yaml.in/yaml/v3"
+)
+
+// SerializationPolicy controls how payloads are written to repository files
+// so that equivalent payloads always produce the same bytes. The zero value
+// keeps the pretty output of each payload codec.
+type SerializationPolicy struct {
+	// KeyOrder lists object keys written first, in this order, at every
+	// nesting level of JSON and YAML payloads. Remaining keys follow in
+	// lexical order.
+	KeyOrder []string
+	// NormalizeNumbers writes integral floating point numbers as integers,
+	// so 1.0 and 1 serialize identically.
+	NormalizeNumbers bool
+	// YAMLIndent is the YAML indentation width. Zero keeps the codec default.
+	YAMLIndent int
+	// YAMLCompactSequences writes block sequence items at the indentation of
+	// their parent key instead of one level deeper.
+	YAMLCompactSequences bool
+	// TrailingNewline terminates structured payload files with a newline.
+	TrailingNewline bool
+}
+
+func (p SerializationPolicy) IsZero() bool {
+	return len(p.KeyOrder) == 0 &&
+		!p.NormalizeNumbers &&
+		p.YAMLIndent == 0 &&
+		!p.YAMLCompactSequences &&
+		!p.TrailingNewline
+}
+
+// EncodeContentCanonical encodes content for a repository file following
+// policy. Text and binary payloads are written unchanged.
+func EncodeContentCanonical(content Content, policy SerializationPolicy) ([]byte, error) {
+	if policy.IsZero() {
+		return EncodeContentPretty(content)
+	}
+
+	descriptor := NormalizePayloadDescriptor(content.Descriptor)
+	codec, err := PayloadCodecForType(descriptor.PayloadType)
+	if err != nil {
+		return nil, err
+	}
+	if !codec.Structured || content.Value == nil {
+		return EncodePayloadPretty(content.Value, codec.Type)
+	}
+
+	value, err := Normalize(content.Value)
+	if err != nil {
+		return nil, err
+	}
+	if policy.NormalizeNumbers {
+		value = normalizeIntegralNumbers(value)
+	}
+
+	var encoded []byte
+	switch codec.Type {
+	case PayloadTypeJSON:
+		encoded, err = encodeCanonicalJSON(value, policy)
+	case PayloadTypeYAML:
+		encoded, err = encodeCanonicalYAML(value, policy)
+	default:
+		encoded, err = EncodePayloadPretty(value, codec.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.TrailingNewline && len(encoded) > 0 && !bytes.HasSuffix(encoded, []byte("\n")) {
+		encoded = append(encoded, '\n')
+	}
+	return encoded, nil
+}
+
+func normalizeIntegralNumbers(value any) any {
+	switch typed := value.(type) {
+	case float64:
+		if typed == math.Trunc(typed) && typed >= math.MinInt64 && typed < math.MaxInt64 {
+			return int64(typed)
+		}
+		return typed
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = normalizeIntegralNumbers(item)
+		}
+		return typed
+	case []any:
+		for idx, item := range typed {
+			typed[idx] = normalizeIntegralNumbers(item)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// orderedKeys returns the keys of object with the policy keys first.
+func orderedKeys(object map[string]any, keyOrder []string) []string {
+	keys := make([]string, 0, len(object))
+	for _, key := range keyOrder {
+		if _, found := object[key]; found && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	rest := make([]string, 0, len(object)-len(keys))
+	for key := range object {
+		if !slices.Contains(keys, key) {
+			rest = append(rest, key)
+		}
+	}
+	slices.Sort(rest)
+	return append(keys, rest...)
+}
+
+// orderedJSONObject marshals a map with its keys in policy order.
+type orderedJSONObject struct {
+	object   map[string]any
+	keyOrder []string
+}
+
+func (o orderedJSONObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for idx, key := range orderedKeys(o.object, o.keyOrder) {
+		if idx > 0 {
+			buffer.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(withJSONKeyOrder(o.object[key], o.keyOrder))
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(encodedKey)
+		buffer.WriteByte(':')
+		buffer.Write(encodedValue)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func withJSONKeyOrder(value any, keyOrder []string) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return orderedJSONObject{object: typed, keyOrder: keyOrder}
+	case []any:
+		items := make([]any, len(typed))
+		for idx, item := range typed {
+			items[idx] = withJSONKeyOrder(item, keyOrder)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+func encodeCanonicalJSON(value any, policy SerializationPolicy) ([]byte, error) {
+	encoded, err := json.MarshalIndent(withJSONKeyOrder(value, policy.KeyOrder), "", "  ")
+	if err != nil {
+		return nil, faults.Invalid("failed to encode json payload", err)
+	}
+	return encoded, nil
+}
+
+func encodeCanonicalYAML(value any, policy SerializationPolicy) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return nil, faults.Invalid("failed to encode yaml payload", err)
+	}
+	orderYAMLMappingKeys(&node, policy.KeyOrder)
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	if policy.YAMLIndent > 0 {
+		encoder.SetIndent(policy.YAMLIndent)
+	}
+	if policy.YAMLCompactSequences {
+		encoder.CompactSeqIndent()
+	}
+	if err := encoder.Encode(&node); err != nil {
+		return nil, faults.Invalid("failed to encode yaml payload", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, faults.Invalid("failed to encode yaml payload", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func orderYAMLMappingKeys(node *yaml.Node, keyOrder []string) {
+	for _, child := range node.Content {
+		orderYAMLMappingKeys(child, keyOrder)
+	}
+	if node.Kind != yaml.MappingNode || len(keyOrder) == 0 {
+		return
+	}
+
+	pairs := make(map[string][]*yaml.Node, len(node.Content)/2)
+	object := make(map[string]any, len(node.Content)/2)
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		key := node.Content[idx].Value
+		pairs[key] = node.Content[idx : idx+2]
+		object[key] = nil
+	}
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, key := range orderedKeys(object, keyOrder) {
+		content = append(content, pairs[key]...)
+	}
+	node.Content = content
+}
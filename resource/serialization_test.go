@@ -0,0 +1,119 @@
+// Copyright 2026 Carlos Marques
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import "testing"
+
+func TestEncodeContentCanonicalJSON(t *testing.T) {
+	t.Parallel()
+
+	policy := SerializationPolicy{
+		KeyOrder:         []string{"id", "name"},
+		NormalizeNumbers: true,
+		TrailingNewline:  true,
+	}
+	encoded, err := EncodeContentCanonical(Content{
+		Value: map[string]any{
+			"enabled": true,
+			"name":    "web",
+			"id":      "c1",
+			"weight":  2.0,
+			"ratio":   0.5,
+			"mappers": []any{map[string]any{"config": map[string]any{}, "name": "email", "id": "m1"}},
+		},
+		Descriptor: PayloadDescriptor{PayloadType: PayloadTypeJSON},
+	}, policy)
+	if err != nil {
+		t.Fatalf("EncodeContentCanonical returned error: %v", err)
+	}
+	want := `{
+  "id": "c1",
+  "name": "web",
+  "enabled": true,
+  "mappers": [
+    {
+      "id": "m1",
+      "name": "email",
+      "config": {}
+    }
+  ],
+  "ratio": 0.5,
+  "weight": 2
+}
+`
+	if string(encoded) != want {
+		t.Fatalf("unexpected canonical json:\n%s\nwant:\n%s", encoded, want)
+	}
+}
+
+func TestEncodeContentCanonicalYAML(t *testing.T) {
+	t.Parallel()
+
+	value := map[string]any{
+		"name":   "web",
+		"id":     "c1",
+		"uris":   []any{"a", "b"},
+		"nested": map[string]any{"z": "true", "id": int64(1)},
+	}
+	encoded, err := EncodeContentCanonical(Content{
+		Value:      value,
+		Descriptor: PayloadDescriptor{PayloadType: PayloadTypeYAML},
+	}, SerializationPolicy{KeyOrder: []string{"id"}, YAMLIndent: 2, YAMLCompactSequences: true})
+	if err != nil {
+		t.Fatalf("EncodeContentCanonical returned error: %v", err)
+	}
+	want := `id: c1
+name: web
+nested:
+  id: 1
+  z: "true"
+uris:
+- a
+- b
+`
+	if string(encoded) != want {
+		t.Fatalf("unexpected canonical yaml:\n%s\nwant:\n%s", encoded, want)
+	}
+
+	pretty, err := EncodePayloadPretty(value, PayloadTypeYAML)
+	if err != nil {
+		t.Fatalf("EncodePayloadPretty returned error: %v", err)
+	}
+	zero, err := EncodeContentCanonical(Content{
+		Value:      value,
+		Descriptor: PayloadDescriptor{PayloadType: PayloadTypeYAML},
+	}, SerializationPolicy{})
+	if err != nil {
+		t.Fatalf("EncodeContentCanonical returned error: %v", err)
+	}
+	if string(zero) != string(pretty) {
+		t.Fatalf("expected zero policy to match pretty output: got %q want %q", zero, pretty)
+	}
+}
+
+func TestEncodeContentCanonicalLeavesTextPayloadsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := EncodeContentCanonical(Content{
+		Value:      "listen 80",
+		Descriptor: PayloadDescriptor{PayloadType: PayloadTypeText},
+	}, SerializationPolicy{TrailingNewline: true, NormalizeNumbers: true})
+	if err != nil {
+		t.Fatalf("EncodeContentCanonical returned error: %v", err)
+	}
+	if string(encoded) != "listen 80" {
+		t.Fatalf("expected text payload to be written unchanged, got %q", encoded)
+	}
+}
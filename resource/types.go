@@ -25,6 +25,18 @@ type PayloadDescriptor struct {
 type Content struct {
 	Value      Value
 	Descriptor PayloadDescriptor
+	Version    Version
+}
+
+// Version is the remote concurrency token observed when a resource was read.
+// Update and delete requests replay it as a conditional-request precondition.
+type Version struct {
+	ETag         string
+	LastModified string
+}
+
+func (v Version) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
 }
 
 type Resource struct {
@@ -35,6 +47,10 @@ type Resource struct {
 	ResolvedRemotePath string
 	Payload            Value
 	PayloadDescriptor  PayloadDescriptor
+	Version            Version
+	// Patch is the update delta computed for a patch update strategy. When
+	// set, update requests send it instead of Payload.
+	Patch Value
 }
 
 type DiffEntry struct {